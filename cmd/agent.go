@@ -18,21 +18,28 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
 
 	"github.com/fleeks-inc/fleeks-cli/internal/client"
 	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 // agentCmd represents the agent command
@@ -74,6 +81,16 @@ Examples:
   fleeks agent watch agent-123
   fleeks agent list --project my-api
 
+  # Start from a reusable task template
+  fleeks agent templates
+  fleeks agent start --project my-api --template add-tests
+
+  # See which tools and skills the agent can use
+  fleeks agent tools --project-type web
+
+  # Stream agent events as newline-delimited JSON
+  fleeks agent watch agent-123 --output ndjson | jq .type
+
   # Chat with your software engineer
   fleeks chat my-project
 `,
@@ -90,7 +107,28 @@ The agent automatically adapts its expertise based on your task:
    "Implement ML model"  AI/ML expertise
    "Setup CI/CD"  DevOps expertise
 
-No need to specify roles - the agent figures it out!`,
+No need to specify roles - the agent figures it out!
+
+Use --propose to review before anything is written: the agent produces a
+patch set, which you inspect with 'fleeks agent diff' and commit with
+'fleeks agent apply'.
+
+Use --model to pick the model the agent runs on (the server default is used
+otherwise), and --temperature and --max-tokens to tune its sampling:
+  fleeks agent start --project my-api --task "Fix the flaky tests" --model claude-sonnet --temperature 0.2
+
+Use --template to start from a named task preset; --task, --max-iterations
+and --context override or extend what the template provides:
+  fleeks agent start --project my-api --template add-tests --max-iterations 5
+
+Pass several projects to run the same task across them, one coordinated
+agent per project. The agents share a group ID, and unless --detached their
+streams are shown together, each line prefixed with its project:
+  fleeks agent start --project web,api --task "Rename the user 'handle' field to 'username'"
+
+Use --mirror to copy the agent's file changes into the local workspace while
+its stream is shown (see 'fleeks agent watch --help'):
+  fleeks agent start --project my-api --task "Add request logging" --mirror`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return startAgent(cmd)
 	},
@@ -118,6 +156,30 @@ Features:
 - File changes monitoring
 - Progress tracking
 - Dynamic expertise switching
+- Live file edits rendered as compact colorized diffs (--no-diff to hide)
+- Automatic reconnection that resumes from the last received event
+- Message type filtering (--filter to include, --exclude to drop)
+- Live local copy of the agent's edits (--mirror)
+
+Message types: thought, tool_call, skill_loaded, type_detected, output,
+progress, complete, error, file_change, patch, proposal_ready.
+
+Examples:
+  # Only show which tools ran and which files changed
+  fleeks agent watch agent-123 --filter tool_call,file_change,patch
+
+  # Everything except the agent's thoughts
+  fleeks agent watch agent-123 --exclude thought
+
+  # Keep the local workspace in step with the agent's edits
+  fleeks agent watch agent-123 --mirror
+
+With --mirror every file the agent creates or modifies is downloaded into
+the local workspace (./workspace/<project> unless workspace.local_path is
+set) and deleted files are removed, so your editor sees the work as it
+happens. Changes to the same file in quick succession are downloaded once,
+files matching workspace.ignore_patterns are left alone, and message filters
+don't affect what is mirrored.
 
 Watch as your AI software engineer adapts to different project types!`,
 	Args: cobra.ExactArgs(1),
@@ -126,6 +188,24 @@ Watch as your AI software engineer adapts to different project types!`,
 	},
 }
 
+var agentAttachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Watch the running agent of a project",
+	Long: `Find the running agent of a project and stream it, like 'fleeks agent
+watch', without looking up its ID first.
+
+When several agents are running in the project you are asked which one to
+watch; without a terminal to ask on, the most recently started one is used.
+
+Examples:
+  fleeks agent start --project my-app --task "Add login" --detached
+  fleeks agent attach --project my-app`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return attachAgent(cmd)
+	},
+}
+
 var agentStatusCmd = &cobra.Command{
 	Use:   "status [agent-id]",
 	Short: "Get agent status",
@@ -135,18 +215,108 @@ var agentStatusCmd = &cobra.Command{
 - Active skills loaded
 - Tool usage statistics
 - Execution timeline
-- Resource usage`,
+- Resource usage
+
+Use --wait to block until the agent reaches a terminal state (completed,
+failed or stopped). The command exits 0 when the agent completed and
+non-zero otherwise, which makes it suitable for CI pipelines:
+
+  fleeks agent start --project my-api --task "Run the test suite" --detached
+  fleeks agent status agent-123 --wait --timeout 30m`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return getAgentStatus(args[0], cmd)
 	},
 }
 
+var agentToolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "List the agent's tools and skills",
+	Long: `List the tools and skills available to the AI software engineer,
+grouped by project type (web, mobile, blockchain, games, AI/ML, IoT, ...).
+
+Use --project-type to show a single group.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listAgentTools(cmd)
+	},
+}
+
+var agentTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List agent task templates",
+	Long: `List the task presets usable with 'agent start --template'.
+
+Templates come from the server catalog and from the agent.templates section
+of your config file; a local template replaces a server one of the same name:
+
+  agent:
+    templates:
+      add-tests:
+        description: Add missing unit tests
+        task: Add unit tests for untested code paths
+        max_iterations: 20
+        context: ["README.md", "src/*.go"]
+
+Context patterns match within one directory level each, as in src/*/*.go;
+** is not supported.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listAgentTemplates()
+	},
+}
+
+var agentDiffCmd = &cobra.Command{
+	Use:   "diff [agent-id]",
+	Short: "Show an agent's proposed changes",
+	Long: `Show the unified diffs proposed by an agent started with --propose.
+
+Nothing is written to the workspace until the proposal is applied with
+'fleeks agent apply'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showAgentProposal(args[0], cmd)
+	},
+}
+
+var agentApplyCmd = &cobra.Command{
+	Use:   "apply [agent-id]",
+	Short: "Apply an agent's proposed changes",
+	Long: `Write the changes proposed by an agent started with --propose to the
+workspace. Review them first with 'fleeks agent diff'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return applyAgentProposal(args[0], cmd)
+	},
+}
+
+var agentRetryCmd = &cobra.Command{
+	Use:   "retry [agent-id]",
+	Short: "Start a new agent with a finished agent's parameters",
+	Long: `Start a new agent with the same project, task and context files as a failed
+or stopped agent, without retyping the start command.
+
+Override the iteration limit with --max-iterations, or add a clarification
+with --task; it is appended to the original task rather than replacing it.
+The new agent records the original agent ID in its metadata.
+
+Examples:
+  fleeks agent retry agent-123
+  fleeks agent retry agent-123 --max-iterations 20 --task "Use the existing test fixtures"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return retryAgent(args[0], cmd)
+	},
+}
+
 var agentStopCmd = &cobra.Command{
 	Use:   "stop [agent-id]",
 	Short: "Stop an agent",
 	Long: `Stop a running agent and clean up resources.
 
+By default the stop is graceful: the agent finishes its current step and
+checkpoints its work before stopping, and the command waits (up to --timeout)
+for it to do so. Use --force (or --graceful=false) to terminate the agent
+immediately; any step in progress is lost.
+
 The agent's state and context will be preserved for potential restart.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -154,28 +324,116 @@ The agent's state and context will be preserved for potential restart.`,
 	},
 }
 
+var agentFeedbackCmd = &cobra.Command{
+	Use:   "feedback [agent-id]",
+	Short: "Rate or correct a finished agent run",
+	Long: `Send feedback on a finished agent run: a 1-5 rating, a comment, and
+corrected versions of files the agent got wrong. Feedback is used to evaluate
+and improve the agent.
+
+--correction takes the workspace path of a file the agent wrote, optionally
+followed by =LOCAL_FILE when the corrected copy isn't at the same path locally.
+Repeat it for several files.
+
+Examples:
+  fleeks agent feedback agent-123 --rating 4 --comment "Good fix, missed one test"
+  fleeks agent feedback agent-123 --rating 2 --correction src/app.py
+  fleeks agent feedback agent-123 --correction src/app.py=./fixed/app.py`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return sendAgentFeedback(args[0], cmd)
+	},
+}
+
 func init() {
 	// Add subcommands
 	agentCmd.AddCommand(agentStartCmd)
 	agentCmd.AddCommand(agentListCmd)
 	agentCmd.AddCommand(agentWatchCmd)
+	agentCmd.AddCommand(agentAttachCmd)
 	agentCmd.AddCommand(agentStatusCmd)
 	agentCmd.AddCommand(agentStopCmd)
+	agentCmd.AddCommand(agentRetryCmd)
+	agentCmd.AddCommand(agentToolsCmd)
+	agentCmd.AddCommand(agentTemplatesCmd)
+	agentCmd.AddCommand(agentDiffCmd)
+	agentCmd.AddCommand(agentApplyCmd)
+	agentCmd.AddCommand(agentFeedbackCmd)
 
 	// Start command flags
-	agentStartCmd.Flags().StringP("project", "p", "", "Project ID (required)")
+	agentStartCmd.Flags().StringSliceP("project", "p", []string{}, "Project ID (required; repeat or comma-separate to run on several projects)")
 	agentStartCmd.Flags().StringP("task", "t", "", "Initial task for the agent")
 	agentStartCmd.Flags().IntP("max-iterations", "m", 0, "Maximum iterations (0 = use default)")
 	agentStartCmd.Flags().BoolP("detached", "d", false, "Run agent in detached mode")
 	agentStartCmd.Flags().StringSliceP("context", "c", []string{}, "Additional context files")
+	agentStartCmd.Flags().Bool("ignore-missing-context", false, "Warn instead of failing when a context file cannot be read")
+	agentStartCmd.Flags().String("template", "", "Start from a task template (see 'fleeks agent templates')")
+	registerFlagCompletion(agentStartCmd, "template", completeAgentTemplates)
+	agentStartCmd.Flags().Bool("propose", false, "Produce a reviewable patch set instead of writing files")
+	agentStartCmd.Flags().String("id-file", "", idFileFlagUsage+" (one line per project)")
+	agentStartCmd.Flags().String("model", "", "Model to run the agent on (default: server default)")
+	agentStartCmd.Flags().Float64("temperature", 0, "Sampling temperature, 0-2 (default: model default)")
+	agentStartCmd.Flags().Int("max-tokens", 0, "Maximum tokens per model response (0 = use default)")
+	agentStartCmd.Flags().Bool("mirror", false, "Download the agent's file changes into the local workspace as they happen")
+	agentStartCmd.MarkFlagsMutuallyExclusive("mirror", "detached")
+	agentStartCmd.MarkFlagsMutuallyExclusive("mirror", "propose")
+
+	// Diff command flags
+	agentDiffCmd.Flags().Bool("stat", false, "Only list the changed files")
+
+	// Apply command flags
+	agentApplyCmd.Flags().BoolP("yes", "y", false, "Apply without confirmation")
 
 	// List command flags
 	agentListCmd.Flags().StringP("project", "p", "", "Filter by project ID")
 	agentListCmd.Flags().StringP("status", "s", "", "Filter by status")
+	registerFlagCompletion(agentListCmd, "status", completeValues(agentStatusValues...))
+	agentListCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
 
 	// Watch command flags
 	agentWatchCmd.Flags().BoolP("follow", "f", true, "Follow new messages")
 	agentWatchCmd.Flags().IntP("tail", "", 50, "Number of recent messages to show")
+	agentWatchCmd.Flags().Bool("no-diff", false, "Show changed file paths without diffs")
+	agentWatchCmd.Flags().StringSlice("filter", nil, "Only show these message types (comma-separated, e.g. tool_call,file_change)")
+	agentWatchCmd.Flags().StringSlice("exclude", nil, "Hide these message types (comma-separated, e.g. thought)")
+	agentWatchCmd.MarkFlagsMutuallyExclusive("filter", "exclude")
+	agentWatchCmd.Flags().Bool("mirror", false, "Download the agent's file changes into the local workspace as they happen")
+
+	// Attach command flags
+	agentAttachCmd.Flags().StringP("project", "p", "", "Project whose running agent to watch (required)")
+	agentAttachCmd.Flags().Bool("no-diff", false, "Show changed file paths without diffs")
+	agentAttachCmd.Flags().StringSlice("filter", nil, "Only show these message types (comma-separated, e.g. tool_call,file_change)")
+	agentAttachCmd.Flags().StringSlice("exclude", nil, "Hide these message types (comma-separated, e.g. thought)")
+	agentAttachCmd.MarkFlagsMutuallyExclusive("filter", "exclude")
+	agentAttachCmd.MarkFlagRequired("project")
+
+	// Status command flags
+	agentStatusCmd.Flags().BoolP("wait", "w", false, "Wait until the agent reaches a terminal state")
+	agentStatusCmd.Flags().Duration("timeout", 0, "Maximum time to wait with --wait (0 = no limit)")
+	agentStatusCmd.Flags().Duration("interval", 5*time.Second, "Polling interval with --wait")
+	markFlagRequires(agentStatusCmd, "timeout", "wait")
+	markFlagRequires(agentStatusCmd, "interval", "wait")
+
+	// Stop command flags
+	agentStopCmd.Flags().Bool("graceful", true, "Let the agent finish its current step and checkpoint before stopping")
+	agentStopCmd.Flags().Bool("force", false, "Terminate the agent immediately without checkpointing")
+	agentStopCmd.Flags().Duration("timeout", 2*time.Minute, "Maximum time to wait for a graceful stop (0 = no limit)")
+	agentStopCmd.MarkFlagsMutuallyExclusive("graceful", "force")
+	agentStopCmd.MarkFlagsMutuallyExclusive("timeout", "force")
+
+	// Retry command flags
+	agentRetryCmd.Flags().StringP("task", "t", "", "Clarification appended to the original task")
+	agentRetryCmd.Flags().IntP("max-iterations", "m", 0, "Maximum iterations (0 = same as the original agent)")
+	agentRetryCmd.Flags().BoolP("detached", "d", false, "Run agent in detached mode")
+	agentRetryCmd.Flags().Bool("force", false, "Retry even if the original agent completed successfully")
+
+	// Feedback command flags
+	agentFeedbackCmd.Flags().Int("rating", 0, "Rating from 1 (poor) to 5 (excellent)")
+	agentFeedbackCmd.Flags().String("comment", "", "What the agent did well or got wrong")
+	agentFeedbackCmd.Flags().StringArray("correction", nil, "Corrected file as PATH or PATH=LOCAL_FILE (repeatable)")
+
+	// Tools command flags
+	agentToolsCmd.Flags().String("project-type", "", "Only show tools and skills for this project type")
 
 	// Mark required flags
 	agentStartCmd.MarkFlagRequired("project")
@@ -186,7 +444,66 @@ type AgentStartRequest struct {
 	ProjectID     string            `json:"project_id"`
 	Task          string            `json:"task,omitempty"`
 	MaxIterations int               `json:"max_iterations,omitempty"`
-	Context       map[string]string `json:"context,omitempty"`
+	ContextRefs   []ContextRef      `json:"context_refs,omitempty"`
+	Propose       bool              `json:"propose,omitempty"`
+	Model         string            `json:"model,omitempty"`
+	Temperature   *float64          `json:"temperature,omitempty"`
+	MaxTokens     int               `json:"max_tokens,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// Agent start metadata keys
+const (
+	// agentRetryOfKey links a retried agent back to the one it replaces
+	agentRetryOfKey = "retry_of"
+	// agentGroupKey and agentGroupProjectsKey tie together the agents
+	// started across several projects by one 'agent start'
+	agentGroupKey         = "group_id"
+	agentGroupProjectsKey = "group_projects"
+)
+
+// agentStatusProposalReady is reported by agents started with --propose once
+// their patch set is ready for review
+const agentStatusProposalReady = "proposal_ready"
+
+// ProposedPatch is one file change in an agent proposal
+type ProposedPatch struct {
+	Path   string `json:"path"`
+	Change string `json:"change"` // created, modified or deleted
+	Patch  string `json:"patch"`  // unified diff
+}
+
+// AgentProposal is the patch set produced by an agent in proposal mode
+type AgentProposal struct {
+	AgentID string          `json:"agent_id"`
+	Status  string          `json:"status"`
+	Summary string          `json:"summary,omitempty"`
+	Patches []ProposedPatch `json:"patches"`
+}
+
+// ProposalApplyResponse reports the outcome of applying a proposal
+type ProposalApplyResponse struct {
+	Applied []string `json:"applied"`
+	Failed  []string `json:"failed,omitempty"`
+}
+
+// Agent stop modes
+const (
+	agentStopGraceful = "graceful"
+	agentStopForce    = "force"
+)
+
+// AgentStopRequest selects how an agent is stopped
+type AgentStopRequest struct {
+	Mode string `json:"mode"`
+}
+
+// AgentStopResponse reports the outcome of a stop request
+type AgentStopResponse struct {
+	AgentID      string `json:"agent_id"`
+	Status       string `json:"status"`
+	Mode         string `json:"mode"`
+	CheckpointID string `json:"checkpoint_id,omitempty"`
 }
 
 // AgentResponse represents agent response
@@ -204,21 +521,69 @@ type AgentResponse struct {
 
 // AgentStatus represents detailed agent status
 type AgentStatus struct {
-	AgentID         string     `json:"agent_id"`
-	ProjectID       string     `json:"project_id"`
-	Status          string     `json:"status"`
-	Task            string     `json:"task"`
-	Progress        int        `json:"progress"`
-	CurrentStep     string     `json:"current_step,omitempty"`
-	DetectedTypes   []string   `json:"detected_types,omitempty"`
-	ActiveSkills    []string   `json:"active_skills,omitempty"`
-	Iterations      int        `json:"iterations_completed"`
-	MaxIterations   int        `json:"max_iterations"`
-	StartedAt       time.Time  `json:"started_at"`
-	CompletedAt     *time.Time `json:"completed_at,omitempty"`
-	ExecutionTimeMs *float64   `json:"execution_time_ms,omitempty"`
-	ToolsUsed       []string   `json:"tools_used,omitempty"`
-	FilesModified   []string   `json:"files_modified,omitempty"`
+	AgentID         string       `json:"agent_id"`
+	ProjectID       string       `json:"project_id"`
+	Status          string       `json:"status"`
+	Task            string       `json:"task"`
+	Progress        int          `json:"progress"`
+	CurrentStep     string       `json:"current_step,omitempty"`
+	DetectedTypes   []string     `json:"detected_types,omitempty"`
+	ActiveSkills    []string     `json:"active_skills,omitempty"`
+	Iterations      int          `json:"iterations_completed"`
+	MaxIterations   int          `json:"max_iterations"`
+	Model           string       `json:"model,omitempty"`
+	Temperature     *float64     `json:"temperature,omitempty"`
+	MaxTokens       int          `json:"max_tokens,omitempty"`
+	StartedAt       time.Time    `json:"started_at"`
+	CompletedAt     *time.Time   `json:"completed_at,omitempty"`
+	ExecutionTimeMs *float64     `json:"execution_time_ms,omitempty"`
+	ToolsUsed       []string     `json:"tools_used,omitempty"`
+	FilesModified   []string     `json:"files_modified,omitempty"`
+	ContextRefs     []ContextRef `json:"context_refs,omitempty"`
+}
+
+// AgentCapability is a single tool or skill the agent can use
+type AgentCapability struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// AgentModel is a model the agent can run on, from the server's allowed list
+type AgentModel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Default     bool   `json:"default,omitempty"`
+}
+
+// Limits for 'agent start --temperature'
+const (
+	minAgentTemperature = 0.0
+	maxAgentTemperature = 2.0
+)
+
+// AgentTemplate is a reusable task preset for 'agent start --template'
+type AgentTemplate struct {
+	Name          string   `json:"name" mapstructure:"name"`
+	Description   string   `json:"description,omitempty" mapstructure:"description"`
+	Task          string   `json:"task" mapstructure:"task"`
+	MaxIterations int      `json:"max_iterations,omitempty" mapstructure:"max_iterations"`
+	Context       []string `json:"context,omitempty" mapstructure:"context"` // glob patterns
+	Source        string   `json:"source" mapstructure:"-"`
+}
+
+// Where an agent template was defined
+const (
+	templateSourceServer = "server"
+	templateSourceConfig = "config"
+)
+
+// ProjectTypeCapabilities groups tools and skills for one project type
+type ProjectTypeCapabilities struct {
+	ProjectType string            `json:"project_type"`
+	Description string            `json:"description,omitempty"`
+	Tools       []AgentCapability `json:"tools"`
+	Skills      []AgentCapability `json:"skills"`
 }
 
 func startAgent(cmd *cobra.Command) error {
@@ -232,11 +597,75 @@ func startAgent(cmd *cobra.Command) error {
 	}
 
 	// Get flags
-	projectID, _ := cmd.Flags().GetString("project")
+	projectIDs, _ := cmd.Flags().GetStringSlice("project")
 	task, _ := cmd.Flags().GetString("task")
 	maxIterations, _ := cmd.Flags().GetInt("max-iterations")
 	detached, _ := cmd.Flags().GetBool("detached")
 	contextFiles, _ := cmd.Flags().GetStringSlice("context")
+	ignoreMissingContext, _ := cmd.Flags().GetBool("ignore-missing-context")
+	templateName, _ := cmd.Flags().GetString("template")
+	propose, _ := cmd.Flags().GetBool("propose")
+	model, _ := cmd.Flags().GetString("model")
+	temperature, _ := cmd.Flags().GetFloat64("temperature")
+	maxTokens, _ := cmd.Flags().GetInt("max-tokens")
+
+	// --project "" or "a,,b" leaves empty entries behind
+	projects := projectIDs[:0]
+	for _, projectID := range projectIDs {
+		if projectID = strings.TrimSpace(projectID); projectID != "" {
+			projects = append(projects, projectID)
+		}
+	}
+	if projectIDs = projects; len(projectIDs) == 0 {
+		return fmt.Errorf("--project is required")
+	}
+
+	if cmd.Flags().Changed("temperature") && (temperature < minAgentTemperature || temperature > maxAgentTemperature) {
+		return fmt.Errorf("--temperature must be between %g and %g", minAgentTemperature, maxAgentTemperature)
+	}
+	if maxTokens < 0 {
+		return fmt.Errorf("--max-tokens cannot be negative")
+	}
+
+	if contextFiles, err = expandPaths(contextFiles); err != nil {
+		return err
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	// Check the model before prompting so a typo fails fast
+	if model = strings.TrimSpace(model); model != "" {
+		if err := validateAgentModel(apiClient, model); err != nil {
+			return err
+		}
+	}
+
+	// Template values fill in whatever the flags didn't set
+	if templateName != "" {
+		template, err := findAgentTemplate(apiClient, templateName)
+		if err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("task") {
+			task = template.Task
+		}
+		if !cmd.Flags().Changed("max-iterations") && template.MaxIterations > 0 {
+			maxIterations = template.MaxIterations
+		}
+		templateFiles, err := expandContextGlobs(template.Context)
+		if err != nil {
+			return err
+		}
+		contextFiles = append(templateFiles, contextFiles...)
+	}
+
+	// Check context files before prompting so a bad path fails fast
+	pendingContext, err := collectContextFiles(contextFiles, ignoreMissingContext)
+	if err != nil {
+		return err
+	}
 
 	// If no task provided, prompt for it
 	if task == "" {
@@ -255,73 +684,237 @@ func startAgent(cmd *cobra.Command) error {
 		}
 	}
 
-	// Build context from files
-	context := make(map[string]string)
-	for _, file := range contextFiles {
-		if content, err := os.ReadFile(file); err == nil {
-			context[file] = string(content)
-		}
-	}
-
-	// Create API client
-	apiClient := client.NewAPIClient()
-	apiClient.SetAPIKey(cfg.GetAPIKey())
-
-	// Start spinner
-	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
-	s.Suffix = " Starting AI software engineer..."
-	s.Start()
-	defer s.Stop()
+	status := newStatusReporter()
+	defer status.Stop()
 
-	// Prepare request
 	request := AgentStartRequest{
-		ProjectID:     projectID,
 		Task:          task,
 		MaxIterations: maxIterations,
-		Context:       context,
+		Propose:       propose,
+		Model:         model,
+		MaxTokens:     maxTokens,
+	}
+	if cmd.Flags().Changed("temperature") {
+		request.Temperature = &temperature
+	}
+	if len(projectIDs) > 1 {
+		return startAgentGroup(apiClient, projectIDs, request, pendingContext, status, detached, cmd)
 	}
 
-	// Start agent
-	var response AgentResponse
-	if err := apiClient.POST("/api/v1/sdk/agents", request, &response); err != nil {
-		s.Stop()
-		return fmt.Errorf("failed to start agent: %w", err)
+	// Context is uploaded to the workspace and referenced by path, keeping
+	// the start request small however large the context is
+	request.ProjectID = projectIDs[0]
+	request.ContextRefs, err = uploadContextFiles(apiClient, request.ProjectID, pendingContext, status)
+	if err != nil {
+		return err
 	}
+	return launchAgent(apiClient, request, status, detached, cmd)
+}
 
-	s.Stop()
+// AgentGroupStart is the --output json result of starting agents across
+// several projects
+type AgentGroupStart struct {
+	GroupID string          `json:"group_id"`
+	Agents  []AgentResponse `json:"agents"`
+	Results BulkReport      `json:"results"`
+}
+
+// startAgentGroup starts one agent per project for a shared task. A project
+// that fails to start is reported and the rest still start; the agents that
+// did start are listed and, unless detached, watched together.
+func startAgentGroup(apiClient *client.APIClient, projectIDs []string, request AgentStartRequest, pendingContext []contextFile, status *statusReporter, detached bool, cmd *cobra.Command) error {
+	groupID := client.NewIdempotencyKey()
+	var started []AgentResponse
+	result := newBulkResult("project", "start an agent")
+
+	for _, projectID := range projectIDs {
+		projectStatus := status.Group(projectID)
+		projectRequest := request
+		projectRequest.ProjectID = projectID
+		projectRequest.Metadata = map[string]string{
+			agentGroupKey:         groupID,
+			agentGroupProjectsKey: strings.Join(projectIDs, ","),
+		}
+
+		contextRefs, err := uploadContextFiles(apiClient, projectID, pendingContext, projectStatus)
+		if err == nil {
+			projectRequest.ContextRefs = contextRefs
+			var response *AgentResponse
+			if response, err = sendAgentStart(apiClient, projectRequest, projectStatus); err == nil {
+				started = append(started, *response)
+				result.Record(projectID, nil)
+				continue
+			}
+		}
+		projectStatus.Stop()
+		result.Record(projectID, err)
+		fmt.Fprintf(os.Stderr, "%s %s: %v\n", ui.Red("❌"), projectID, err)
+	}
+
+	agentIDs := make([]string, len(started))
+	for i, response := range started {
+		agentIDs[i] = response.AgentID
+	}
+	if err := writeIDFile(cmd, agentIDs...); err != nil {
+		return err
+	}
+
+	if isJSONOutput() {
+		if err := printJSON(AgentGroupStart{GroupID: groupID, Agents: started, Results: result.Report()}); err != nil {
+			return err
+		}
+		return result.Err(cmd)
+	}
+	if len(started) > 0 {
+		fmt.Printf("\n%s %s\n\n",
+			ui.Green(" AI Software Engineers started across projects"),
+			ui.Muted("(group "+groupID+")"))
+		table := newListTable("Project", "Agent ID", "Status")
+		for _, response := range started {
+			table.Append([]string{response.ProjectID, response.AgentID, response.Status})
+		}
+		table.Render()
+	}
+	result.PrintSummary()
+
+	// The agents that did start run either way, so they are watched even
+	// if some projects failed; the failures still decide the exit status
+	switch {
+	case len(started) == 0:
+	case detached:
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint(" Monitor agents:"))
+		for _, response := range started {
+			fmt.Printf("  %s\n", ui.Cyan("fleeks agent watch "+response.AgentID))
+		}
+	default:
+		fmt.Printf("\n%s Streaming %d agents...\n", ui.Cyan(""), len(started))
+		if err := watchAgentGroup(started, cmd); err != nil {
+			return err
+		}
+	}
+	return result.Err(cmd)
+}
+
+// launchAgent sends the start request, prints the new agent and, unless
+// detached, streams its execution
+func launchAgent(apiClient *client.APIClient, request AgentStartRequest, status *statusReporter, detached bool, cmd *cobra.Command) error {
+	response, err := sendAgentStart(apiClient, request, status)
+	if err != nil {
+		return err
+	}
+	if err := writeIDFile(cmd, response.AgentID); err != nil {
+		return err
+	}
 
 	// Success output
 	fmt.Printf("\n%s %s\n",
-		color.GreenString(" AI Software Engineer started!"),
-		color.CyanString(response.AgentID))
+		ui.Green(" AI Software Engineer started!"),
+		ui.Cyan(response.AgentID))
 
-	fmt.Printf("Project:      %s\n", color.BlueString(response.ProjectID))
-	fmt.Printf("Task:         %s\n", color.WhiteString(response.Task))
+	fmt.Printf("Project:      %s\n", ui.Blue(response.ProjectID))
+	fmt.Printf("Task:         %s\n", ui.White(response.Task))
 	fmt.Printf("Status:       %s\n", getStatusColor(response.Status))
 
 	if len(response.DetectedTypes) > 0 {
-		fmt.Printf("Detected:     %s\n", color.MagentaString(strings.Join(response.DetectedTypes, ", ")))
+		fmt.Printf("Detected:     %s\n", ui.Magenta(strings.Join(response.DetectedTypes, ", ")))
 	}
 
 	if len(response.ActiveSkills) > 0 {
-		fmt.Printf("Skills:       %s\n", color.YellowString(fmt.Sprintf("%d skills loaded", len(response.ActiveSkills))))
+		fmt.Printf("Skills:       %s\n", ui.Yellow(fmt.Sprintf("%d skills loaded", len(response.ActiveSkills))))
 	}
 
-	fmt.Printf("Started:      %s\n", color.MagentaString(response.StartedAt.Format("2006-01-02 15:04:05")))
+	fmt.Printf("Started:      %s\n", ui.Magenta(response.StartedAt.Format("2006-01-02 15:04:05")))
 
 	if !detached {
-		fmt.Printf("\n%s Streaming agent execution...\n", color.CyanString(""))
+		fmt.Printf("\n%s Streaming agent execution...\n", ui.Cyan(""))
 		return watchAgent(response.AgentID, cmd)
 	}
 
 	// Show monitoring commands
-	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint(" Monitor agent:"))
-	fmt.Printf("  %s\n", color.CyanString("fleeks agent watch "+response.AgentID))
-	fmt.Printf("  %s\n", color.CyanString("fleeks agent status "+response.AgentID))
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint(" Monitor agent:"))
+	fmt.Printf("  %s\n", ui.Cyan("fleeks agent watch "+response.AgentID))
+	fmt.Printf("  %s\n", ui.Cyan("fleeks agent status "+response.AgentID))
 
 	return nil
 }
 
+// sendAgentStart sends the start request as a status step
+func sendAgentStart(apiClient *client.APIClient, request AgentStartRequest, status *statusReporter) (*AgentResponse, error) {
+	// One key per logical start so retries can't launch duplicate agents
+	idempotencyKey := client.NewIdempotencyKey()
+	if IsVerbose() {
+		fmt.Printf("%s %s\n", ui.Muted("Idempotency-Key:"), idempotencyKey)
+	}
+
+	status.Start("Starting AI software engineer")
+	var response AgentResponse
+	if err := apiClient.POSTIdempotent("/api/v1/sdk/agents", idempotencyKey, request, &response); err != nil {
+		return nil, status.Fail(fmt.Errorf("failed to start agent: %w", err))
+	}
+	status.Done("Agent started")
+	return &response, nil
+}
+
+func retryAgent(agentID string, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	clarification, _ := cmd.Flags().GetString("task")
+	maxIterations, _ := cmd.Flags().GetInt("max-iterations")
+	detached, _ := cmd.Flags().GetBool("detached")
+	force, _ := cmd.Flags().GetBool("force")
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	var original AgentStatus
+	if err := apiClient.GET(fmt.Sprintf("/api/v1/sdk/agents/%s", agentID), &original); err != nil {
+		return fmt.Errorf("failed to get agent: %w", err)
+	}
+	switch {
+	case !isTerminalAgentStatus(original.Status):
+		return fmt.Errorf("agent %s is still %s; stop it first with 'fleeks agent stop %s'", agentID, original.Status, agentID)
+	case original.Status == "completed" && !force:
+		return fmt.Errorf("agent %s completed successfully; use --force to run it again", agentID)
+	}
+
+	task := original.Task
+	if clarification = strings.TrimSpace(clarification); clarification != "" {
+		task += "\n\nAdditional instructions: " + clarification
+	}
+	if maxIterations == 0 {
+		maxIterations = original.MaxIterations
+	}
+
+	fmt.Printf("%s Retrying agent %s (%s) on %s\n",
+		ui.Cyan(""), ui.Yellow(agentID), getStatusColor(original.Status), ui.Blue(original.ProjectID))
+	if len(original.ContextRefs) > 0 {
+		fmt.Printf("Reusing %d context file(s) already in the workspace\n", len(original.ContextRefs))
+	}
+
+	request := AgentStartRequest{
+		ProjectID:     original.ProjectID,
+		Task:          task,
+		MaxIterations: maxIterations,
+		ContextRefs:   original.ContextRefs,
+		Model:         original.Model,
+		Temperature:   original.Temperature,
+		MaxTokens:     original.MaxTokens,
+		Metadata:      map[string]string{agentRetryOfKey: agentID},
+	}
+
+	status := newStatusReporter()
+	defer status.Stop()
+	return launchAgent(apiClient, request, status, detached, cmd)
+}
+
 func listAgents(cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -360,51 +953,45 @@ func listAgents(cmd *cobra.Command) error {
 	}
 
 	if len(agents) == 0 {
-		fmt.Printf("%s No active agents found.\n", color.YellowString(""))
+		fmt.Printf("%s No active agents found.\n", ui.Yellow(""))
 		fmt.Printf("Start one with: %s\n",
-			color.CyanString("fleeks agent start --project my-project --task \"Build user auth\""))
+			ui.Cyan("fleeks agent start --project my-project --task \"Build user auth\""))
 		return nil
 	}
 
 	// Create table
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Agent ID", "Project", "Status", "Progress", "Detected Types", "Task"})
+	table := newListTable("Agent ID", "Project", "Status", "Progress", "Detected Types", "Task")
 	table.SetHeaderColor(
-		tablewriter.Colors{tablewriter.FgHiCyanColor},
-		tablewriter.Colors{tablewriter.FgHiBlueColor},
-		tablewriter.Colors{tablewriter.FgHiGreenColor},
-		tablewriter.Colors{tablewriter.FgHiMagentaColor},
-		tablewriter.Colors{tablewriter.FgHiYellowColor},
-		tablewriter.Colors{tablewriter.FgHiWhiteColor},
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiBlue),
+		ui.TableColors(color.FgHiGreen),
+		ui.TableColors(color.FgHiMagenta),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiWhite),
 	)
+	if err := table.SelectColumns(cmd, AgentStatus{}); err != nil {
+		return err
+	}
 
 	for _, agent := range agents {
-		task := agent.Task
-		if len(task) > 40 {
-			task = task[:37] + "..."
-		}
-
 		detectedTypes := "auto"
 		if len(agent.DetectedTypes) > 0 {
 			detectedTypes = strings.Join(agent.DetectedTypes, ", ")
-			if len(detectedTypes) > 20 {
-				detectedTypes = detectedTypes[:17] + "..."
-			}
 		}
 
-		table.Append([]string{
+		table.AppendRecord([]string{
 			agent.AgentID[:8] + "...",
 			agent.ProjectID,
 			agent.Status,
 			fmt.Sprintf("%d%%", agent.Progress),
 			detectedTypes,
-			task,
-		})
+			agent.Task,
+		}, agent)
 	}
 
 	fmt.Printf("\n%s %s\n\n",
-		color.New(color.Bold).Sprint(" Active AI Software Engineers:"),
-		color.GreenString(fmt.Sprintf("(%d total)", len(agents))))
+		ui.New(color.Bold).Sprint(" Active AI Software Engineers:"),
+		ui.Green(fmt.Sprintf("(%d total)", len(agents))))
 
 	table.Render()
 	return nil
@@ -420,20 +1007,38 @@ func watchAgent(agentID string, cmd *cobra.Command) error {
 		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
 	}
 
+	// Not every caller defines the flags (agent start streams too)
+	noDiff, _ := cmd.Flags().GetBool("no-diff")
+	include, _ := cmd.Flags().GetStringSlice("filter")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+
+	types, err := newMessageTypeFilter(include, exclude, client.AgentMessageTypes)
+	if err != nil {
+		return err
+	}
+	opts := agentRenderOptions{showDiffs: !noDiff, types: types}
+
 	// Create API client
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
-	// Create stream reader
-	streamPath := fmt.Sprintf("/ws/agents/%s/stream", agentID)
-	stream, err := apiClient.NewStreamReader(streamPath)
-	if err != nil {
-		return fmt.Errorf("failed to connect to agent stream: %w", err)
+	if mirror, _ := cmd.Flags().GetBool("mirror"); mirror {
+		var agent AgentStatus
+		if err := apiClient.GET(fmt.Sprintf("/api/v1/sdk/agents/%s", agentID), &agent); err != nil {
+			return fmt.Errorf("failed to get agent: %w", err)
+		}
+		// Mirror reports are printed from their own goroutine
+		opts.mu = &sync.Mutex{}
+		if opts.mirror, err = newAgentMirror(cfg, apiClient, agent.ProjectID, opts); err != nil {
+			return err
+		}
+		defer opts.mirror.close()
 	}
-	defer stream.Close()
 
-	fmt.Printf("%s Watching AI engineer %s (Press Ctrl+C to exit)\n\n",
-		color.CyanString(""), color.YellowString(agentID[:12]))
+	if !isNDJSONOutput() {
+		fmt.Printf("%s Watching AI engineer %s (Press Ctrl+C to exit)\n\n",
+			ui.Cyan(""), ui.Yellow(agentID[:12]))
+	}
 
 	// Handle graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -441,84 +1046,21 @@ func watchAgent(agentID string, cmd *cobra.Command) error {
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
 	go func() {
 		<-c
-		fmt.Printf("\n%s Disconnecting from agent stream...\n",
-			color.YellowString(""))
+		if !isNDJSONOutput() {
+			fmt.Printf("\n%s Disconnecting from agent stream...\n",
+				ui.Yellow(""))
+		}
 		cancel()
 	}()
 
-	// Stream messages
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case msg, ok := <-stream.Messages():
-			if !ok {
-				fmt.Printf("\n%s Agent session ended\n", color.GreenString(""))
-				return nil
-			}
-
-			timestamp := msg.Timestamp.Format("15:04:05")
-			switch msg.Type {
-			case "thought":
-				fmt.Printf("[%s] %s %s\n",
-					color.MagentaString(timestamp),
-					color.CyanString(""),
-					msg.Content)
-			case "tool_call":
-				tool := msg.Metadata["tool"]
-				fmt.Printf("[%s] %s Using: %s\n",
-					color.MagentaString(timestamp),
-					color.YellowString(""),
-					color.GreenString(fmt.Sprintf("%v", tool)))
-			case "skill_loaded":
-				skill := msg.Metadata["skill"]
-				projectType := msg.Metadata["project_type"]
-				fmt.Printf("[%s] %s [%s] Loaded skill: %s\n",
-					color.MagentaString(timestamp),
-					color.MagentaString(""),
-					color.YellowString(fmt.Sprintf("%v", projectType)),
-					color.GreenString(fmt.Sprintf("%v", skill)))
-			case "type_detected":
-				projectType := msg.Metadata["project_type"]
-				fmt.Printf("[%s] %s Detected project type: %s\n",
-					color.MagentaString(timestamp),
-					color.CyanString(""),
-					color.YellowString(fmt.Sprintf("%v", projectType)))
-			case "output":
-				fmt.Printf("[%s] %s %s\n",
-					color.MagentaString(timestamp),
-					color.BlueString(""),
-					msg.Content)
-			case "progress":
-				progress := msg.Metadata["progress"]
-				fmt.Printf("[%s] %s Progress: %s\n",
-					color.MagentaString(timestamp),
-					color.GreenString(""),
-					color.CyanString(fmt.Sprintf("%v%%", progress)))
-			case "complete":
-				fmt.Printf("[%s] %s Task completed!\n",
-					color.MagentaString(timestamp),
-					color.GreenString(""))
-				return nil
-			case "error":
-				fmt.Printf("[%s] %s Error: %s\n",
-					color.MagentaString(timestamp),
-					color.RedString(""),
-					color.RedString(msg.Content))
-			}
-
-		case err, ok := <-stream.Errors():
-			if !ok {
-				return nil
-			}
-			return fmt.Errorf("stream error: %w", err)
-		}
-	}
+	return followAgentStream(ctx, apiClient, agentID, opts)
 }
 
-func getAgentStatus(agentID string, cmd *cobra.Command) error {
+// attachAgent watches the running agent of the --project project
+func attachAgent(cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -528,64 +1070,591 @@ func getAgentStatus(agentID string, cmd *cobra.Command) error {
 		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
 	}
 
-	// Create API client
+	projectID, _ := cmd.Flags().GetString("project")
+
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
-	// Get agent status
-	var agent AgentStatus
-	endpoint := fmt.Sprintf("/api/v1/sdk/agents/%s", agentID)
-	if err := apiClient.GET(endpoint, &agent); err != nil {
-		return fmt.Errorf("failed to get agent status: %w", err)
+	var agents []AgentStatus
+	endpoint := "/api/v1/sdk/agents?project_id=" + url.QueryEscape(projectID)
+	if err := apiClient.GET(endpoint, &agents); err != nil {
+		return fmt.Errorf("failed to list agents: %w", err)
 	}
 
-	// Display agent status
-	fmt.Printf("\n%s %s\n\n",
-		color.New(color.Bold).Sprint(" AI Software Engineer Status:"),
-		color.CyanString(agentID))
-
-	fmt.Printf("%-20s %s\n", "Agent ID:", color.CyanString(agent.AgentID))
-	fmt.Printf("%-20s %s\n", "Project:", color.BlueString(agent.ProjectID))
-	fmt.Printf("%-20s %s\n", "Status:", getStatusColor(agent.Status))
-	fmt.Printf("%-20s %s\n", "Task:", agent.Task)
-	fmt.Printf("%-20s %s\n", "Progress:", color.GreenString(fmt.Sprintf("%d%%", agent.Progress)))
-
-	if len(agent.DetectedTypes) > 0 {
-		fmt.Printf("%-20s %s\n", "Detected Types:", color.YellowString(strings.Join(agent.DetectedTypes, ", ")))
+	var running []AgentStatus
+	for _, agent := range agents {
+		if !isTerminalAgentStatus(agent.Status) {
+			running = append(running, agent)
+		}
 	}
-
-	if len(agent.ActiveSkills) > 0 {
-		fmt.Printf("%-20s %s\n", "Active Skills:", color.MagentaString(fmt.Sprintf("%d loaded", len(agent.ActiveSkills))))
+	if len(running) == 0 {
+		return fmt.Errorf("no running agent in project %s; start one with 'fleeks agent start --project %s'", projectID, projectID)
 	}
-
-	if agent.CurrentStep != "" {
-		fmt.Printf("%-20s %s\n", "Current Step:", agent.CurrentStep)
+	// Most recently started first
+	sort.SliceStable(running, func(i, j int) bool {
+		return running[i].StartedAt.After(running[j].StartedAt)
+	})
+
+	agent := running[0]
+	if len(running) > 1 {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			if agent, err = pickAgent(running); err != nil {
+				return err
+			}
+		} else if !isNDJSONOutput() {
+			fmt.Printf("%s %d agents are running in %s; attaching to the most recent\n",
+				ui.Yellow("⚠️"), len(running), projectID)
+		}
 	}
-	fmt.Printf("%-20s %s\n", "Iterations:", color.MagentaString(fmt.Sprintf("%d/%d", agent.Iterations, agent.MaxIterations)))
-	fmt.Printf("%-20s %s\n", "Started:", color.MagentaString(agent.StartedAt.Format("2006-01-02 15:04:05")))
+	return watchAgent(agent.AgentID, cmd)
+}
+
+// pickAgent asks which of several running agents to watch
+func pickAgent(agents []AgentStatus) (AgentStatus, error) {
+	prompt := promptui.Select{
+		Label: "Several agents are running; watch which one",
+		Items: agents,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "▸ {{ .AgentID | cyan }} {{ .Status | yellow }} {{ .Task | faint }}",
+			Inactive: "  {{ .AgentID }} {{ .Status | faint }} {{ .Task | faint }}",
+			Selected: "Agent: {{ .AgentID | green }}",
+		},
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		return AgentStatus{}, fmt.Errorf("agent selection cancelled")
+	}
+	return agents[index], nil
+}
+
+// watchAgentGroup streams several agents at once, prefixing each line with
+// the agent's project, until all of them finish or the user quits
+func watchAgentGroup(agents []AgentResponse, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	noDiff, _ := cmd.Flags().GetBool("no-diff")
+	mirror, _ := cmd.Flags().GetBool("mirror")
+
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
+	go func() {
+		<-c
+		if !isNDJSONOutput() {
+			fmt.Printf("\n%s Disconnecting from agent streams...\n",
+				ui.Yellow(""))
+		}
+		cancel()
+	}()
+
+	width := 0
+	for _, agent := range agents {
+		if len(agent.ProjectID) > width {
+			width = len(agent.ProjectID)
+		}
+	}
+	prefixColors := []func(format string, a ...interface{}) string{
+		ui.Cyan, ui.Green, ui.Yellow, ui.Blue, ui.Magenta,
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(agents))
+	for i, agent := range agents {
+		opts := agentRenderOptions{
+			showDiffs: !noDiff,
+			prefix:    prefixColors[i%len(prefixColors)]("%-*s | ", width, agent.ProjectID),
+			mu:        &mu,
+		}
+		if mirror {
+			if opts.mirror, err = newAgentMirror(cfg, apiClient, agent.ProjectID, opts); err != nil {
+				return err
+			}
+			defer opts.mirror.close()
+		}
+		wg.Add(1)
+		go func(i int, agentID string) {
+			defer wg.Done()
+			if err := followAgentStream(ctx, apiClient, agentID, opts); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", agents[i].ProjectID, err)
+			}
+		}(i, agent.AgentID)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// followAgentStream streams one agent until its session ends or ctx is
+// cancelled, reconnecting after interruptions
+func followAgentStream(ctx context.Context, apiClient *client.APIClient, agentID string, opts agentRenderOptions) error {
+	reconnectDelay := viper.GetDuration("streaming.reconnect_delay")
+	if reconnectDelay <= 0 {
+		reconnectDelay = 5 * time.Second
+	}
+
+	// The last event seen is sent back on reconnect so the server resumes
+	// right after it instead of replaying or skipping part of the log.
+	streamPath := fmt.Sprintf("/ws/agents/%s/stream", agentID)
+	lastEventID := ""
+	failures := 0
+
+	for {
+		done, err := streamAgentEvents(ctx, apiClient, client.ResumePath(streamPath, lastEventID), &lastEventID, &failures, opts)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if done {
+			return err
+		}
+
+		failures++
+		if failures > maxAgentReconnects {
+			return fmt.Errorf("agent stream lost after %d reconnect attempts: %w", maxAgentReconnects, err)
+		}
+
+		if !isNDJSONOutput() {
+			opts.printf("\n%s Stream interrupted (%v), reconnecting in %s...\n",
+				ui.Yellow(""), err, reconnectDelay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// maxAgentReconnects bounds consecutive failed reconnects in agent watch
+const maxAgentReconnects = 10
+
+// streamAgentEvents consumes one connection of the agent stream. It returns
+// done=true when the session finished (or the user quit) and an error when the
+// connection dropped and should be resumed. lastEventID is updated as events
+// arrive and failures is reset once a connection delivers data.
+func streamAgentEvents(ctx context.Context, apiClient *client.APIClient, streamPath string, lastEventID *string, failures *int, opts agentRenderOptions) (bool, error) {
+	stream, err := apiClient.NewStreamReader(streamPath)
+	if err != nil {
+		// Nothing to resume on the very first attempt - the agent likely doesn't exist
+		if *lastEventID == "" && *failures == 0 {
+			return true, fmt.Errorf("failed to connect to agent stream: %w", err)
+		}
+		return false, err
+	}
+	defer stream.Close()
+	defer reportDroppedMessages(stream)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, nil
+		case msg, ok := <-stream.Messages():
+			if !ok {
+				// The reader closes both channels when it stops; an error
+				// left behind means the connection dropped rather than ended
+				select {
+				case err, ok := <-stream.Errors():
+					if ok {
+						return false, fmt.Errorf("stream error: %w", err)
+					}
+				default:
+				}
+				if !isNDJSONOutput() {
+					opts.printf("\n%s Agent session ended\n", ui.Green(""))
+				}
+				return true, nil
+			}
+
+			*failures = 0
+			if id := msg.EventID(); id != "" {
+				*lastEventID = id
+			}
+
+			// Mirroring follows every change, whatever is shown
+			if opts.mirror != nil && (msg.Type == client.MessageFileChange || msg.Type == client.MessagePatch) {
+				opts.mirror.queue(msg)
+			}
+
+			if !opts.types.keeps(msg.Type) {
+				// Hidden messages still end the session
+				if msg.Type == client.MessageComplete || msg.Type == client.MessageProposal {
+					return true, nil
+				}
+				continue
+			}
+
+			if isNDJSONOutput() {
+				opts.lock()
+				err := printStreamEvent("agent", msg)
+				opts.unlock()
+				if err != nil {
+					return true, err
+				}
+				if msg.Type == client.MessageComplete {
+					return true, nil
+				}
+				continue
+			}
+
+			opts.lock()
+			finished := renderAgentMessage(msg, opts)
+			opts.unlock()
+			if finished {
+				return true, nil
+			}
+
+		case err, ok := <-stream.Errors():
+			if !ok {
+				return true, nil
+			}
+			return false, fmt.Errorf("stream error: %w", err)
+		}
+	}
+}
+
+// agentRenderOptions controls how agent stream messages are printed
+type agentRenderOptions struct {
+	showDiffs bool
+	types     *messageTypeFilter // message types to show; nil shows all
+	prefix    string             // put before each line when several agents share the output
+	mu        *sync.Mutex        // serializes output shared with other agents' streams
+	mirror    *agentMirror       // copies file changes into the local workspace; nil when off
+}
+
+func (opts agentRenderOptions) lock() {
+	if opts.mu != nil {
+		opts.mu.Lock()
+	}
+}
+
+func (opts agentRenderOptions) unlock() {
+	if opts.mu != nil {
+		opts.mu.Unlock()
+	}
+}
+
+// printf prints a status line with the agent prefix
+func (opts agentRenderOptions) printf(format string, a ...interface{}) {
+	opts.lock()
+	defer opts.unlock()
+	if strings.HasPrefix(format, "\n") && opts.prefix != "" {
+		// Keep the prefix on the line itself in shared output
+		format = format[1:]
+	}
+	fmt.Print(opts.prefix)
+	fmt.Printf(format, a...)
+}
+
+// renderAgentMessage prints a single agent stream message and reports
+// whether it marks the end of the task.
+func renderAgentMessage(msg client.StreamMessage, opts agentRenderOptions) bool {
+	timestamp := msg.Timestamp.Format("15:04:05")
+	switch msg.KnownType() {
+	case client.MessageThought:
+		fmt.Printf("%s[%s] %s %s\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Cyan(""),
+			msg.Content)
+	case client.MessageToolCall:
+		tool := msg.Metadata["tool"]
+		fmt.Printf("%s[%s] %s Using: %s\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Yellow(""),
+			ui.Green(fmt.Sprintf("%v", tool)))
+	case client.MessageSkillLoaded:
+		skill := msg.Metadata["skill"]
+		projectType := msg.Metadata["project_type"]
+		fmt.Printf("%s[%s] %s [%s] Loaded skill: %s\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Magenta(""),
+			ui.Yellow(fmt.Sprintf("%v", projectType)),
+			ui.Green(fmt.Sprintf("%v", skill)))
+	case client.MessageTypeDetected:
+		projectType := msg.Metadata["project_type"]
+		fmt.Printf("%s[%s] %s Detected project type: %s\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Cyan(""),
+			ui.Yellow(fmt.Sprintf("%v", projectType)))
+	case client.MessageOutput:
+		fmt.Printf("%s[%s] %s %s\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Blue(""),
+			msg.Content)
+	case client.MessageProgress:
+		progress := msg.Metadata["progress"]
+		fmt.Printf("%s[%s] %s Progress: %s\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Green(""),
+			ui.Cyan(fmt.Sprintf("%v%%", progress)))
+	case client.MessageComplete:
+		fmt.Printf("%s[%s] %s Task completed!\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Green(""))
+		return true
+	case client.MessageError:
+		fmt.Printf("%s[%s] %s Error: %s\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Red(""),
+			ui.Red(msg.Content))
+	case client.MessageFileChange, client.MessagePatch:
+		renderAgentFileChange(timestamp, msg, opts)
+	case client.MessageProposal:
+		agentID := stringMetadata(msg.Metadata, "agent_id")
+		fmt.Printf("%s[%s] %s Proposal ready for review\n",
+			opts.prefix,
+			ui.Magenta(timestamp),
+			ui.Green(""))
+		if agentID != "" {
+			fmt.Printf("%s  %s\n", opts.prefix, ui.Cyan("fleeks agent diff "+agentID))
+			fmt.Printf("%s  %s\n", opts.prefix, ui.Cyan("fleeks agent apply "+agentID))
+		}
+		return true
+	default:
+		logUnknownMessage("agent", msg)
+	}
+	return false
+}
+
+// renderAgentFileChange prints the path of a file the agent changed and,
+// unless disabled, a compact diff. The server sends either a unified patch or
+// the before/after content in the message metadata.
+func renderAgentFileChange(timestamp string, msg client.StreamMessage, opts agentRenderOptions) {
+	path := stringMetadata(msg.Metadata, "path")
+	change := stringMetadata(msg.Metadata, "change")
+	if change == "" {
+		change = "modified"
+	}
+
+	var label string
+	switch change {
+	case "created":
+		label = ui.Green("CREATED")
+	case "deleted":
+		label = ui.Red("DELETED")
+	default:
+		label = ui.Yellow(strings.ToUpper(change))
+	}
+
+	fmt.Printf("%s[%s] %s %s %s\n",
+		opts.prefix,
+		ui.Magenta(timestamp),
+		ui.Yellow(""),
+		label,
+		ui.Cyan(path))
+
+	if !opts.showDiffs {
+		return
+	}
+
+	patch := stringMetadata(msg.Metadata, "patch")
+	if patch == "" && msg.Type == client.MessagePatch {
+		patch = msg.Content
+	}
+	if patch == "" {
+		patch = lineDiff(stringMetadata(msg.Metadata, "before"), stringMetadata(msg.Metadata, "after"))
+	}
+	if patch != "" {
+		printColorDiff(patch, opts.prefix+"    ", maxDiffLines)
+	}
+}
+
+// stringMetadata returns a metadata value as a string, or "" if absent
+func stringMetadata(metadata map[string]interface{}, key string) string {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func getAgentStatus(agentID string, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	wait, _ := cmd.Flags().GetBool("wait")
+	if wait {
+		silenceUsage(cmd)
+		return waitForAgent(apiClient, agentID, cmd)
+	}
+
+	// Get agent status
+	var agent AgentStatus
+	endpoint := fmt.Sprintf("/api/v1/sdk/agents/%s", agentID)
+	if err := apiClient.GET(endpoint, &agent); err != nil {
+		return fmt.Errorf("failed to get agent status: %w", err)
+	}
+
+	printAgentStatus(agentID, agent)
+	return nil
+}
+
+// isTerminalAgentStatus reports whether an agent has finished running
+func isTerminalAgentStatus(status string) bool {
+	switch status {
+	case "completed", "failed", "stopped", agentStatusProposalReady:
+		return true
+	}
+	return false
+}
+
+// waitForAgent polls the agent until it reaches a terminal state, prints the
+// final status, and returns an error unless the agent completed successfully.
+func waitForAgent(apiClient *client.APIClient, agentID string, cmd *cobra.Command) error {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
+	s.Suffix = " Waiting for agent..."
+	s.Start()
+	defer s.Stop()
+
+	endpoint := fmt.Sprintf("/api/v1/sdk/agents/%s", agentID)
+	var agent AgentStatus
+	for {
+		if err := apiClient.GET(endpoint, &agent); err != nil {
+			s.Stop()
+			return fmt.Errorf("failed to get agent status: %w", err)
+		}
+
+		if isTerminalAgentStatus(agent.Status) {
+			break
+		}
+
+		s.Lock()
+		s.Suffix = fmt.Sprintf(" Waiting for agent... %s (%d%%)", agent.Status, agent.Progress)
+		s.Unlock()
+
+		sleep := interval
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				s.Stop()
+				return fmt.Errorf("timed out after %s waiting for agent %s (last status: %s)",
+					timeout, agentID, agent.Status)
+			}
+			if remaining < sleep {
+				sleep = remaining
+			}
+		}
+		time.Sleep(sleep)
+	}
+
+	s.Stop()
+	printAgentStatus(agentID, agent)
+
+	if agent.Status == agentStatusProposalReady {
+		fmt.Printf("\nReview with %s\n", ui.Cyan("fleeks agent diff "+agentID))
+		return nil
+	}
+	if agent.Status != "completed" {
+		return fmt.Errorf("agent %s finished with status %s", agentID, agent.Status)
+	}
+	return nil
+}
+
+// printAgentStatus renders the detailed status view for an agent
+func printAgentStatus(agentID string, agent AgentStatus) {
+	// Display agent status
+	fmt.Printf("\n%s %s\n\n",
+		ui.New(color.Bold).Sprint(" AI Software Engineer Status:"),
+		ui.Cyan(agentID))
+
+	fmt.Printf("%-20s %s\n", "Agent ID:", ui.Cyan(agent.AgentID))
+	fmt.Printf("%-20s %s\n", "Project:", ui.Blue(agent.ProjectID))
+	fmt.Printf("%-20s %s\n", "Status:", getStatusColor(agent.Status))
+	fmt.Printf("%-20s %s\n", "Task:", agent.Task)
+	fmt.Printf("%-20s %s\n", "Progress:", ui.Green(fmt.Sprintf("%d%%", agent.Progress)))
+
+	if agent.Model != "" {
+		model := agent.Model
+		var tuning []string
+		if agent.Temperature != nil {
+			tuning = append(tuning, fmt.Sprintf("temperature %g", *agent.Temperature))
+		}
+		if agent.MaxTokens > 0 {
+			tuning = append(tuning, fmt.Sprintf("max %d tokens", agent.MaxTokens))
+		}
+		if len(tuning) > 0 {
+			model += ui.Muted(" (" + strings.Join(tuning, ", ") + ")")
+		}
+		fmt.Printf("%-20s %s\n", "Model:", ui.Cyan(model))
+	}
+
+	if len(agent.DetectedTypes) > 0 {
+		fmt.Printf("%-20s %s\n", "Detected Types:", ui.Yellow(strings.Join(agent.DetectedTypes, ", ")))
+	}
+
+	if len(agent.ActiveSkills) > 0 {
+		fmt.Printf("%-20s %s\n", "Active Skills:", ui.Magenta(fmt.Sprintf("%d loaded", len(agent.ActiveSkills))))
+	}
+
+	if agent.CurrentStep != "" {
+		fmt.Printf("%-20s %s\n", "Current Step:", agent.CurrentStep)
+	}
+	fmt.Printf("%-20s %s\n", "Iterations:", ui.Magenta(fmt.Sprintf("%d/%d", agent.Iterations, agent.MaxIterations)))
+	fmt.Printf("%-20s %s\n", "Started:", ui.Magenta(agent.StartedAt.Format("2006-01-02 15:04:05")))
 
 	if agent.CompletedAt != nil {
-		fmt.Printf("%-20s %s\n", "Completed:", color.MagentaString(agent.CompletedAt.Format("2006-01-02 15:04:05")))
+		fmt.Printf("%-20s %s\n", "Completed:", ui.Magenta(agent.CompletedAt.Format("2006-01-02 15:04:05")))
 	}
 
 	if agent.ExecutionTimeMs != nil {
 		duration := time.Duration(*agent.ExecutionTimeMs) * time.Millisecond
-		fmt.Printf("%-20s %s\n", "Execution Time:", color.MagentaString(duration.String()))
+		fmt.Printf("%-20s %s\n", "Execution Time:", ui.Magenta(duration.String()))
 	}
 
 	// Tools and files
 	if len(agent.ToolsUsed) > 0 {
-		fmt.Printf("\n%s\n", color.New(color.Bold).Sprint(" Tools Used:"))
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint(" Tools Used:"))
 		for _, tool := range agent.ToolsUsed {
-			fmt.Printf("   %s\n", color.GreenString(tool))
+			fmt.Printf("   %s\n", ui.Green(tool))
 		}
 	}
 
 	if len(agent.ActiveSkills) > 0 {
-		fmt.Printf("\n%s\n", color.New(color.Bold).Sprint(" Active Skills:"))
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint(" Active Skills:"))
 		for i, skill := range agent.ActiveSkills {
 			if i < 10 { // Show first 10
-				fmt.Printf("   %s\n", color.YellowString(skill))
+				fmt.Printf("   %s\n", ui.Yellow(skill))
 			}
 		}
 		if len(agent.ActiveSkills) > 10 {
@@ -594,11 +1663,81 @@ func getAgentStatus(agentID string, cmd *cobra.Command) error {
 	}
 
 	if len(agent.FilesModified) > 0 {
-		fmt.Printf("\n%s\n", color.New(color.Bold).Sprint(" Files Modified:"))
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint(" Files Modified:"))
 		for _, file := range agent.FilesModified {
-			fmt.Printf("   %s\n", color.BlueString(file))
+			fmt.Printf("   %s\n", ui.Blue(file))
 		}
 	}
+}
+
+func listAgentTools(cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	projectType, _ := cmd.Flags().GetString("project-type")
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	endpoint := "/api/v1/sdk/agents/capabilities"
+	if projectType != "" {
+		endpoint += "?project_type=" + url.QueryEscape(projectType)
+	}
+
+	var groups []ProjectTypeCapabilities
+	if err := cachedGET(apiClient, endpoint, capabilityCacheTTL, false, &groups); err != nil {
+		return fmt.Errorf("failed to get agent capabilities: %w", err)
+	}
+
+	// Filter locally too in case the server ignores the parameter
+	if projectType != "" {
+		filtered := groups[:0]
+		for _, group := range groups {
+			if strings.EqualFold(group.ProjectType, projectType) {
+				filtered = append(filtered, group)
+			}
+		}
+		groups = filtered
+	}
+
+	if isJSONOutput() {
+		return printJSON(groups)
+	}
+
+	if len(groups) == 0 {
+		if projectType != "" {
+			return fmt.Errorf("no capabilities found for project type %q", projectType)
+		}
+		fmt.Printf("%s No capabilities reported by the server.\n", ui.Yellow(""))
+		return nil
+	}
+
+	for _, group := range groups {
+		fmt.Printf("\n%s %s\n",
+			ui.New(color.Bold).Sprint(" "+strings.ToUpper(group.ProjectType)),
+			ui.New(color.FgHiBlack).Sprint(group.Description))
+
+		if len(group.Skills) > 0 {
+			fmt.Printf("  %s\n", ui.Magenta("Skills:"))
+			for _, skill := range group.Skills {
+				fmt.Printf("    %s %s\n", ui.Yellow(fmt.Sprintf("%-24s", skill.Name)), skill.Description)
+			}
+		}
+		if len(group.Tools) > 0 {
+			fmt.Printf("  %s\n", ui.Cyan("Tools:"))
+			for _, tool := range group.Tools {
+				fmt.Printf("    %s %s\n", ui.Green(fmt.Sprintf("%-24s", tool.Name)), tool.Description)
+			}
+		}
+	}
+	fmt.Println()
 
 	return nil
 }
@@ -617,14 +1756,382 @@ func stopAgent(agentID string, cmd *cobra.Command) error {
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
+	mode := agentStopGraceful
+	graceful, _ := cmd.Flags().GetBool("graceful")
+	if force, _ := cmd.Flags().GetBool("force"); force || !graceful {
+		mode = agentStopForce
+	}
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if mode == agentStopForce && cmd.Flags().Changed("timeout") {
+		return fmt.Errorf("--timeout only applies to a graceful stop")
+	}
+
 	// Stop agent
 	endpoint := fmt.Sprintf("/api/v1/sdk/agents/%s/stop", agentID)
-	if err := apiClient.POST(endpoint, nil, nil); err != nil {
+	var result AgentStopResponse
+	if err := apiClient.POST(endpoint, AgentStopRequest{Mode: mode}, &result); err != nil {
 		return fmt.Errorf("failed to stop agent: %w", err)
 	}
+	result.AgentID = agentID
+	result.Mode = mode
+
+	// A graceful stop only takes effect once the current step finishes
+	if mode == agentStopGraceful && !isTerminalAgentStatus(result.Status) {
+		status, err := waitForAgentStop(apiClient, agentID, timeout)
+		if err != nil {
+			return err
+		}
+		result.Status = status
+	}
+
+	if isJSONOutput() {
+		return printJSON(result)
+	}
+
+	fmt.Printf("%s AI Software Engineer %s stopped (%s)\n",
+		ui.Green(""), ui.Cyan(agentID), mode)
+	if result.Status != "" {
+		fmt.Printf("%-20s %s\n", "Final state:", getStatusColor(result.Status))
+	}
+	if result.CheckpointID != "" {
+		fmt.Printf("%-20s %s\n", "Checkpoint:", ui.Cyan(result.CheckpointID))
+	}
+
+	return nil
+}
+
+// waitForAgentStop polls a gracefully stopping agent until it reaches a
+// terminal state and returns that state
+func waitForAgentStop(apiClient *client.APIClient, agentID string, timeout time.Duration) (string, error) {
+	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
+	s.Suffix = " Waiting for the agent to finish its current step..."
+	s.Start()
+	defer s.Stop()
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/sdk/agents/%s", agentID)
+	for {
+		var agent AgentStatus
+		if err := apiClient.GET(endpoint, &agent); err != nil {
+			return "", fmt.Errorf("failed to get agent status: %w", err)
+		}
+		if isTerminalAgentStatus(agent.Status) {
+			return agent.Status, nil
+		}
+		if agent.CurrentStep != "" {
+			s.Lock()
+			s.Suffix = fmt.Sprintf(" Waiting for the agent to finish: %s", agent.CurrentStep)
+			s.Unlock()
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return "", fmt.Errorf("agent %s is still %s after %s; use --force to stop it now",
+				agentID, agent.Status, timeout)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// validateAgentModel checks a --model value against the models the server
+// allows, so a typo fails before anything is uploaded or started
+func validateAgentModel(apiClient *client.APIClient, model string) error {
+	var models []AgentModel
+	if err := cachedGET(apiClient, "/api/v1/sdk/agents/models", capabilityCacheTTL, false, &models); err != nil {
+		return fmt.Errorf("failed to get available models: %w", err)
+	}
+
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		if m.ID == model {
+			return nil
+		}
+		ids = append(ids, m.ID)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("unknown model %q (the server offers no models)", model)
+	}
+
+	sort.Strings(ids)
+	if suggestions := closestMatches(model, ids, 3); len(suggestions) > 0 {
+		return fmt.Errorf("unknown model %q (did you mean %s?)", model, strings.Join(suggestions, ", "))
+	}
+	return fmt.Errorf("unknown model %q (available: %s)", model, strings.Join(ids, ", "))
+}
+
+// fetchAgentTemplates merges the server's template catalog with the
+// agent.templates config section. Config templates win on name clashes, and
+// an unreachable catalog only hides the server templates.
+func fetchAgentTemplates(apiClient *client.APIClient) ([]AgentTemplate, error) {
+	byName := make(map[string]AgentTemplate)
+
+	var remote []AgentTemplate
+	if err := cachedGET(apiClient, "/api/v1/sdk/agents/templates", templateCacheTTL, false, &remote); err != nil {
+		if errors.Is(err, client.ErrRawResponse) {
+			return nil, err
+		}
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Could not fetch server templates: %v\n", err)
+		}
+	}
+	for _, template := range remote {
+		template.Source = templateSourceServer
+		byName[template.Name] = template
+	}
+
+	var local map[string]AgentTemplate
+	if err := viper.UnmarshalKey("agent.templates", &local); err != nil {
+		return nil, fmt.Errorf("invalid agent.templates in config: %w", err)
+	}
+	for name, template := range local {
+		template.Name = name
+		template.Source = templateSourceConfig
+		byName[name] = template
+	}
+
+	templates := make([]AgentTemplate, 0, len(byName))
+	for _, template := range byName {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// findAgentTemplate looks up a template by name
+func findAgentTemplate(apiClient *client.APIClient, name string) (*AgentTemplate, error) {
+	templates, err := fetchAgentTemplates(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(templates))
+	for i := range templates {
+		if templates[i].Name == name {
+			return &templates[i], nil
+		}
+		names = append(names, templates[i].Name)
+	}
+
+	if suggestions := closestMatches(name, names, 3); len(suggestions) > 0 {
+		return nil, fmt.Errorf("unknown agent template %q (did you mean %s?)", name, strings.Join(suggestions, ", "))
+	}
+	return nil, fmt.Errorf("unknown agent template %q (see 'fleeks agent templates')", name)
+}
+
+// expandContextGlobs resolves template context patterns to files with
+// filepath.Glob, so ** is an ordinary *. Patterns that match nothing are
+// skipped; literal paths are kept so a missing file is reported by
+// loadContextFiles.
+func expandContextGlobs(patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		pattern, err := expandPath(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.ContainsAny(pattern, "*?[") {
+			files = append(files, pattern)
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid context pattern %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() {
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
+
+func listAgentTemplates() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	templates, err := fetchAgentTemplates(apiClient)
+	if err != nil {
+		return err
+	}
+
+	if isJSONOutput() {
+		return printJSON(templates)
+	}
+
+	if len(templates) == 0 {
+		fmt.Printf("%s No agent templates available. Define some under agent.templates in your config.\n",
+			ui.Yellow(""))
+		return nil
+	}
+
+	table := newListTable("Template", "Source", "Max Iter", "Task")
+	table.SetHeaderColor(
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiBlack),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiWhite),
+	)
+
+	for _, template := range templates {
+		maxIterations := "-"
+		if template.MaxIterations > 0 {
+			maxIterations = fmt.Sprintf("%d", template.MaxIterations)
+		}
+		task := template.Task
+		if template.Description != "" {
+			task = template.Description
+		}
+		table.Append([]string{template.Name, template.Source, maxIterations, task})
+	}
+
+	table.Render()
+	return nil
+}
+
+// fetchAgentProposal loads the patch set proposed by an agent
+func fetchAgentProposal(apiClient *client.APIClient, agentID string) (*AgentProposal, error) {
+	var proposal AgentProposal
+	endpoint := fmt.Sprintf("/api/v1/sdk/agents/%s/proposal", agentID)
+	if err := apiClient.GET(endpoint, &proposal); err != nil {
+		return nil, fmt.Errorf("failed to get agent proposal: %w", err)
+	}
+	return &proposal, nil
+}
+
+func showAgentProposal(agentID string, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	stat, _ := cmd.Flags().GetBool("stat")
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	proposal, err := fetchAgentProposal(apiClient, agentID)
+	if err != nil {
+		return err
+	}
+
+	if isJSONOutput() {
+		return printJSON(proposal)
+	}
+
+	if len(proposal.Patches) == 0 {
+		fmt.Printf("%s Agent %s has no proposed changes (status: %s)\n",
+			ui.Yellow(""), agentID, getStatusColor(proposal.Status))
+		return nil
+	}
+
+	defer startPager().Close()
+
+	fmt.Printf("\n%s %s\n",
+		ui.New(color.Bold).Sprint(" Proposed changes:"),
+		ui.Cyan(agentID))
+	if proposal.Summary != "" {
+		fmt.Printf("%s\n", proposal.Summary)
+	}
+	fmt.Println()
 
-	fmt.Printf("%s AI Software Engineer %s stopped successfully\n",
-		color.GreenString(""), color.CyanString(agentID))
+	for _, patch := range proposal.Patches {
+		fmt.Printf("%s %s\n", proposalChangeLabel(patch.Change), ui.Cyan(patch.Path))
+		if !stat && patch.Patch != "" {
+			printColorDiff(patch.Patch, "    ", 0)
+			fmt.Println()
+		}
+	}
 
+	fmt.Printf("%d file(s) changed. Apply with %s\n",
+		len(proposal.Patches), ui.Cyan("fleeks agent apply "+agentID))
 	return nil
 }
+
+func applyAgentProposal(agentID string, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	assumeYes, _ := cmd.Flags().GetBool("yes")
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	proposal, err := fetchAgentProposal(apiClient, agentID)
+	if err != nil {
+		return err
+	}
+	if len(proposal.Patches) == 0 {
+		return fmt.Errorf("agent %s has no proposed changes to apply", agentID)
+	}
+
+	if !assumeYes {
+		for _, patch := range proposal.Patches {
+			fmt.Printf("%s %s\n", proposalChangeLabel(patch.Change), ui.Cyan(patch.Path))
+		}
+		fmt.Printf("\nApply %d change(s) to the workspace? [y/N] ", len(proposal.Patches))
+
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println("Apply cancelled.")
+			return nil
+		}
+	}
+
+	var response ProposalApplyResponse
+	endpoint := fmt.Sprintf("/api/v1/sdk/agents/%s/proposal/apply", agentID)
+	if err := apiClient.POST(endpoint, nil, &response); err != nil {
+		return fmt.Errorf("failed to apply proposal: %w", err)
+	}
+
+	if isJSONOutput() {
+		return printJSON(response)
+	}
+
+	fmt.Printf("%s Applied %d change(s)\n", ui.Green(""), len(response.Applied))
+	for _, path := range response.Failed {
+		fmt.Printf("%s Failed to apply %s\n", ui.Red(""), ui.Cyan(path))
+	}
+	if len(response.Failed) > 0 {
+		return fmt.Errorf("%d change(s) could not be applied", len(response.Failed))
+	}
+	return nil
+}
+
+// proposalChangeLabel colors the kind of change in a proposal listing
+func proposalChangeLabel(change string) string {
+	switch change {
+	case "created":
+		return ui.Green("%-8s", "CREATED")
+	case "deleted":
+		return ui.Red("%-8s", "DELETED")
+	default:
+		return ui.Yellow("%-8s", "MODIFIED")
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+const (
+	// agentContextDir is the scratch area in the workspace that context
+	// files are uploaded to before the agent starts
+	agentContextDir = "/.fleeks/context"
+	// contextWarnRatio is how close to agent.max_context_bytes the total
+	// context can get before a warning is shown
+	contextWarnRatio = 0.8
+)
+
+// ContextRef points the agent at a context file uploaded to the workspace
+type ContextRef struct {
+	Name string `json:"name"` // the path as given on the command line
+	Path string `json:"path"` // where it was uploaded in the workspace
+	Size int64  `json:"size"`
+}
+
+// contextFile is a local context file queued for upload
+type contextFile struct {
+	localPath string
+	size      int64
+}
+
+// collectContextFiles checks the given context files exist and that their
+// combined size fits agent.max_context_bytes, warning as it gets close.
+// Unreadable files are an error unless ignoreMissing is set, in which case
+// they are reported and skipped. Nothing is read or uploaded yet.
+func collectContextFiles(files []string, ignoreMissing bool) ([]contextFile, error) {
+	var collected []contextFile
+	var total int64
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err == nil && info.IsDir() {
+			err = fmt.Errorf("is a directory")
+		}
+		if err != nil {
+			if !ignoreMissing {
+				return nil, fmt.Errorf("failed to read context file %s: %w (use --ignore-missing-context to skip)", file, err)
+			}
+			fmt.Printf("%s Skipping context file %s: %v\n", ui.Yellow("Warning:"), file, err)
+			continue
+		}
+		collected = append(collected, contextFile{localPath: file, size: info.Size()})
+		total += info.Size()
+	}
+	if len(collected) == 0 {
+		return nil, nil
+	}
+
+	maxBytes := viper.GetInt64("agent.max_context_bytes")
+	if maxBytes > 0 && total > maxBytes {
+		return nil, fmt.Errorf("context files total %s, over the %s limit; pass fewer or smaller files or raise agent.max_context_bytes",
+			formatBytes(total), formatBytes(maxBytes))
+	}
+
+	fmt.Printf("%s Context: %d file(s), %s total\n",
+		ui.Green(""), len(collected), formatBytes(total))
+	if maxBytes > 0 && float64(total) >= contextWarnRatio*float64(maxBytes) {
+		fmt.Printf("%s Context is at %.0f%% of the %s limit (agent.max_context_bytes)\n",
+			ui.Yellow("Warning:"), 100*float64(total)/float64(maxBytes), formatBytes(maxBytes))
+	}
+	return collected, nil
+}
+
+// uploadContextFiles uploads context files to the workspace scratch area and
+// returns references for the start request, reporting each file as a step
+// nested under status. Each file has a stable remote path, so large files
+// resume their chunked upload if agent start is retried.
+func uploadContextFiles(apiClient *client.APIClient, projectID string, files []contextFile, status *statusReporter) ([]ContextRef, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	fileStatus := status.Group(fmt.Sprintf("Uploading %d context file(s)", len(files)))
+	defer fileStatus.Stop()
+
+	refs := make([]ContextRef, 0, len(files))
+	for _, file := range files {
+		remotePath := contextRemotePath(file.localPath)
+		fileStatus.Start(file.localPath)
+		progress := fileStatus.Update
+
+		var err error
+		for attempt := 0; attempt <= chunkRetries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(time.Duration(attempt) * time.Second)
+			}
+			if err = uploadSingleFile(apiClient, projectID, file.localPath, remotePath, true, progress); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, fileStatus.Fail(fmt.Errorf("failed to upload context file %s: %w", file.localPath, err))
+		}
+		fileStatus.Done(fmt.Sprintf("%s (%s)", file.localPath, formatBytes(file.size)))
+
+		refs = append(refs, ContextRef{Name: file.localPath, Path: remotePath, Size: file.size})
+	}
+	return refs, nil
+}
+
+// contextRemotePath derives a stable scratch path from the file's absolute
+// path, keeping its name readable for the agent
+func contextRemotePath(localPath string) string {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		absPath = localPath
+	}
+	sum := sha256.Sum256([]byte(absPath))
+	return path.Join(agentContextDir, hex.EncodeToString(sum[:6]), filepath.Base(localPath))
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// Bounds of the --rating scale
+const (
+	minAgentRating = 1
+	maxAgentRating = 5
+)
+
+// AgentCorrection is a corrected version of a file the agent wrote
+type AgentCorrection struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// AgentFeedbackRequest is feedback on a finished agent run
+type AgentFeedbackRequest struct {
+	Rating      int               `json:"rating,omitempty"`
+	Comment     string            `json:"comment,omitempty"`
+	Corrections []AgentCorrection `json:"corrections,omitempty"`
+}
+
+// AgentFeedbackResponse acknowledges recorded feedback
+type AgentFeedbackResponse struct {
+	FeedbackID string `json:"feedback_id"`
+	AgentID    string `json:"agent_id"`
+}
+
+func sendAgentFeedback(agentID string, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	rating, _ := cmd.Flags().GetInt("rating")
+	comment, _ := cmd.Flags().GetString("comment")
+	corrections, _ := cmd.Flags().GetStringArray("correction")
+
+	comment = strings.TrimSpace(comment)
+	if cmd.Flags().Changed("rating") && (rating < minAgentRating || rating > maxAgentRating) {
+		return fmt.Errorf("--rating must be between %d and %d", minAgentRating, maxAgentRating)
+	}
+	if rating == 0 && comment == "" && len(corrections) == 0 {
+		return fmt.Errorf("nothing to send; give --rating, --comment or --correction")
+	}
+
+	request := AgentFeedbackRequest{Rating: rating, Comment: comment}
+	if request.Corrections, err = readAgentCorrections(corrections); err != nil {
+		return err
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	var agent AgentStatus
+	if err := apiClient.GET(fmt.Sprintf("/api/v1/sdk/agents/%s", agentID), &agent); err != nil {
+		return fmt.Errorf("failed to get agent: %w", err)
+	}
+	if !isTerminalAgentStatus(agent.Status) {
+		return fmt.Errorf("agent %s is still %s; send feedback once it has finished", agentID, agent.Status)
+	}
+
+	var response AgentFeedbackResponse
+	endpoint := fmt.Sprintf("/api/v1/sdk/agents/%s/feedback", agentID)
+	if err := apiClient.POST(endpoint, request, &response); err != nil {
+		return fmt.Errorf("failed to send feedback: %w", err)
+	}
+
+	if isJSONOutput() {
+		return printJSON(response)
+	}
+
+	fmt.Printf("%s Feedback recorded for agent %s\n", ui.Green("✅"), ui.Cyan(agentID))
+	if rating > 0 {
+		fmt.Printf("Rating: %s\n", ui.Yellow("%s%s",
+			strings.Repeat("★", rating), strings.Repeat("☆", maxAgentRating-rating)))
+	}
+	for _, correction := range request.Corrections {
+		fmt.Printf("Correction: %s\n", ui.Cyan(correction.Path))
+	}
+	return nil
+}
+
+// readAgentCorrections reads the files named by --correction values, each
+// PATH or PATH=LOCAL_FILE. Their combined size is held to
+// agent.max_context_bytes like context files.
+func readAgentCorrections(values []string) ([]AgentCorrection, error) {
+	var corrections []AgentCorrection
+	var total int64
+	seen := make(map[string]bool)
+	for _, value := range values {
+		remotePath, localPath, hasLocal := strings.Cut(value, "=")
+		remotePath = filepath.ToSlash(strings.TrimSpace(remotePath))
+		if !hasLocal {
+			localPath = remotePath
+		}
+		if remotePath == "" || strings.TrimSpace(localPath) == "" {
+			return nil, fmt.Errorf("invalid --correction %q; use PATH or PATH=LOCAL_FILE", value)
+		}
+		if seen[remotePath] {
+			return nil, fmt.Errorf("--correction given twice for %s", remotePath)
+		}
+		seen[remotePath] = true
+
+		content, err := os.ReadFile(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read correction for %s: %w", remotePath, err)
+		}
+		total += int64(len(content))
+		corrections = append(corrections, AgentCorrection{Path: remotePath, Content: string(content)})
+	}
+
+	maxBytes := viper.GetInt64("agent.max_context_bytes")
+	if maxBytes > 0 && total > maxBytes {
+		return nil, fmt.Errorf("corrections total %s, over the %s limit; pass fewer or smaller files or raise agent.max_context_bytes",
+			formatBytes(total), formatBytes(maxBytes))
+	}
+	return corrections, nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// agentMirrorDebounce is how long a file must go without further changes
+// before --mirror copies it, so a burst of edits is downloaded once
+const agentMirrorDebounce = 500 * time.Millisecond
+
+// agentMirror copies the files an agent changes into the local workspace
+// while its stream is watched. Changes are collected per path and applied
+// once the path has been quiet for agentMirrorDebounce. Mirrored versions are
+// recorded in the sync state like pulled files, so a later 'workspace sync'
+// doesn't mistake them for local edits.
+type agentMirror struct {
+	syncer   *workspaceSyncer
+	uploader *deltaUploader // only used for its sync state
+	opts     agentRenderOptions
+
+	mu      sync.Mutex
+	pending map[string]mirrorChange // remote path to its latest change
+	stop    chan struct{}
+	done    chan struct{}
+
+	mirrored, removed, failed int
+}
+
+// mirrorChange is the latest change seen for a path and when
+type mirrorChange struct {
+	change string
+	seen   time.Time
+}
+
+// newAgentMirror starts mirroring into the local workspace of projectID
+func newAgentMirror(cfg *config.Config, apiClient *client.APIClient, projectID string, opts agentRenderOptions) (*agentMirror, error) {
+	localRoot := cfg.GetWorkspacePath(projectID)
+	if err := os.MkdirAll(localRoot, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create local workspace: %w", err)
+	}
+
+	m := &agentMirror{
+		syncer: &workspaceSyncer{
+			cfg:       cfg,
+			apiClient: apiClient,
+			projectID: projectID,
+			localRoot: localRoot,
+		},
+		uploader: newDeltaUploader(apiClient, projectID, true),
+		opts:     opts,
+		pending:  make(map[string]mirrorChange),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go m.run()
+	return m, nil
+}
+
+// queue records a file_change or patch message for mirroring. Paths that
+// would land outside the workspace or match workspace.ignore_patterns,
+// themselves or through a parent directory, are skipped.
+func (m *agentMirror) queue(msg client.StreamMessage) {
+	remotePath := stringMetadata(msg.Metadata, "path")
+	relPath := strings.TrimPrefix(remotePath, "/")
+	if relPath == "" {
+		return
+	}
+	if !filepath.IsLocal(filepath.FromSlash(relPath)) {
+		m.warn("%s Not mirroring %s: outside the workspace\n", ui.Yellow("⚠️"), remotePath)
+		return
+	}
+	for dir := relPath; dir != "."; dir = path.Dir(dir) {
+		if m.syncer.excluded(dir) {
+			return
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending[remotePath] = mirrorChange{change: stringMetadata(msg.Metadata, "change"), seen: time.Now()}
+}
+
+// run applies settled changes until close is called, then applies the rest
+func (m *agentMirror) run() {
+	defer close(m.done)
+	ticker := time.NewTicker(agentMirrorDebounce / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.flush(false)
+		case <-m.stop:
+			m.flush(true)
+			return
+		}
+	}
+}
+
+// flush applies the pending changes that have settled, or all of them
+func (m *agentMirror) flush(all bool) {
+	m.mu.Lock()
+	due := make(map[string]string)
+	for remotePath, pending := range m.pending {
+		if all || time.Since(pending.seen) >= agentMirrorDebounce {
+			due[remotePath] = pending.change
+			delete(m.pending, remotePath)
+		}
+	}
+	m.mu.Unlock()
+
+	paths := make([]string, 0, len(due))
+	for remotePath := range due {
+		paths = append(paths, remotePath)
+	}
+	sort.Strings(paths)
+	for _, remotePath := range paths {
+		m.apply(remotePath, due[remotePath])
+	}
+}
+
+// apply downloads a changed file or removes a deleted one. Failures are
+// reported and counted; they don't stop the stream.
+func (m *agentMirror) apply(remotePath, change string) {
+	if change == "deleted" {
+		err := os.Remove(m.syncer.localPath(remotePath))
+		if err != nil && !os.IsNotExist(err) {
+			m.failed++
+			m.warn("%s Failed to remove mirrored %s: %v\n", ui.Yellow("⚠️"), remotePath, err)
+			return
+		}
+		m.uploader.state.forget(remotePath)
+		if err != nil {
+			// Never made it to the local copy
+			return
+		}
+		m.removed++
+		m.report("%s Removed %s locally\n", ui.Red("🗑️"), ui.Cyan(remotePath))
+		return
+	}
+
+	if err := m.syncer.pull(m.uploader, remotePath); err != nil {
+		m.failed++
+		m.warn("%s Failed to mirror %s: %v\n", ui.Yellow("⚠️"), remotePath, err)
+		return
+	}
+	m.mirrored++
+	m.report("%s Mirrored %s\n", ui.Green("📥"), ui.Cyan(remotePath))
+}
+
+// report prints a mirror status line alongside the stream. NDJSON output
+// keeps stdout for events, so nothing is printed there.
+func (m *agentMirror) report(format string, a ...interface{}) {
+	if !isNDJSONOutput() {
+		m.opts.printf(format, a...)
+	}
+}
+
+// warn prints a mirror problem, on stderr with NDJSON output
+func (m *agentMirror) warn(format string, a ...interface{}) {
+	if isNDJSONOutput() {
+		fmt.Fprintf(os.Stderr, format, a...)
+		return
+	}
+	m.opts.printf(format, a...)
+}
+
+// close applies whatever is still pending, saves the sync state and prints
+// a summary
+func (m *agentMirror) close() {
+	close(m.stop)
+	<-m.done
+
+	if err := m.uploader.state.save(); err != nil && IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save sync state: %v\n", err)
+	}
+	if isNDJSONOutput() || m.mirrored+m.removed+m.failed == 0 {
+		return
+	}
+
+	m.opts.printf("%s Mirrored %d file(s) and removed %d in %s\n",
+		ui.Cyan("📁"), m.mirrored, m.removed, ui.Green(m.syncer.localRoot))
+	if m.failed > 0 {
+		m.opts.printf("%s %d change(s) could not be mirrored; catch up with: %s\n", ui.Yellow("⚠️"), m.failed,
+			ui.Cyan("fleeks workspace sync "+m.syncer.projectID+" --direction pull"))
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+)
+
+// archiveUploadMinFiles is the number of files above which a recursive
+// upload is sent as one archive instead of one request per file
+const archiveUploadMinFiles = 200
+
+// ArchiveUploadResponse reports what the server extracted from an archive
+type ArchiveUploadResponse struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+}
+
+// archiveEntry is a local file bound for an archive upload
+type archiveEntry struct {
+	localPath string
+	name      string // path inside the archive, relative to the upload root
+	info      os.FileInfo
+	state     fileSyncState // the version sent, recorded once the upload succeeds
+}
+
+// collectArchiveEntries lists the files under localDir, leaving out anything
+// matched by workspace.ignore_patterns
+func collectArchiveEntries(cfg *config.Config, localDir string) ([]archiveEntry, int64, error) {
+	var entries []archiveEntry
+	var total int64
+	err := filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(localDir, path)
+		if err != nil || relPath == "." {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if cfg.ShouldIgnoreFile(relPath) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			entries = append(entries, archiveEntry{localPath: path, name: relPath, info: info})
+			total += info.Size()
+		}
+		return nil
+	})
+	return entries, total, err
+}
+
+// uploadArchive streams the files as a gzipped tar in a single multipart
+// request to the upload-archive endpoint, which extracts it under remoteDir.
+// Files are read as the request is sent, so nothing is staged on disk.
+func uploadArchive(apiClient *client.APIClient, projectID, remoteDir string, entries []archiveEntry, total int64, overwrite bool, uploader *deltaUploader, progress func(string)) (*ArchiveUploadResponse, error) {
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		writer.CloseWithError(writeArchiveForm(form, remoteDir, entries, total, overwrite, progress))
+	}()
+
+	var response ArchiveUploadResponse
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/upload-archive", projectID)
+	err := apiClient.POSTStream(endpoint, form.FormDataContentType(), reader, &response)
+	// Unblock the writer if the request ended before reading everything
+	reader.Close()
+	<-done
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the uploaded versions so later syncs can skip unchanged files
+	if uploader != nil {
+		for _, entry := range entries {
+			uploader.state.set(joinRemotePath(remoteDir, entry.name), entry.state)
+		}
+	}
+	return &response, nil
+}
+
+// writeArchiveForm writes the multipart form: the target path and overwrite
+// fields, then the archive itself
+func writeArchiveForm(form *multipart.Writer, remoteDir string, entries []archiveEntry, total int64, overwrite bool, progress func(string)) error {
+	if err := form.WriteField("path", remoteDir); err != nil {
+		return err
+	}
+	if err := form.WriteField("overwrite", strconv.FormatBool(overwrite)); err != nil {
+		return err
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="archive"; filename="upload.tar.gz"`)
+	header.Set("Content-Type", "application/gzip")
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(part)
+	archive := tar.NewWriter(gz)
+	var sent int64
+	for i := range entries {
+		entry := &entries[i]
+		content, err := os.ReadFile(entry.localPath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.localPath, err)
+		}
+		hdr, err := tar.FileInfoHeader(entry.info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = entry.name
+		hdr.Size = int64(len(content))
+		if err := archive.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := archive.Write(content); err != nil {
+			return err
+		}
+		entry.state = newFileSyncState(content, sha256Hex(content))
+
+		sent += int64(len(content))
+		if progress != nil {
+			progress(fmt.Sprintf("Uploading archive: %d/%d files (%s of %s)",
+				i+1, len(entries), formatBytes(sent), formatBytes(total)))
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	if progress != nil {
+		progress("Extracting archive on the server...")
+	}
+	return form.Close()
+}
+
+// joinRemotePath joins a remote directory and a slash-separated relative path
+func joinRemotePath(remoteDir, relPath string) string {
+	return filepath.ToSlash(filepath.Join(remoteDir, filepath.FromSlash(relPath)))
+}
+
+// archiveUnsupported reports whether the server has no upload-archive
+// endpoint, so the upload should fall back to one request per file
+func archiveUnsupported(err error) bool {
+	var apiErr *client.ErrorResponse
+	return errors.As(err, &apiErr) && (apiErr.Code == http.StatusNotFound || apiErr.Code == http.StatusNotImplemented)
+}
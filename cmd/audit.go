@@ -0,0 +1,247 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// defaultAuditLimit is how many events 'audit' shows without --limit
+const defaultAuditLimit = 100
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the audit log of account activity",
+	Long: `Show who did what: the audit events the platform records for workspace,
+agent, file and account operations, newest first. Each event has the actor,
+the action, the resource it applied to, when it happened and the client IP.
+
+Without --project, events across the whole account are shown.
+
+--since and --until accept RFC3339 timestamps, dates (2006-01-02) or
+durations relative to now (90m, 24h, 7d).
+
+Examples:
+  fleeks audit --project my-api --since 7d
+  fleeks audit --actor alice@example.com --action files.delete
+  fleeks audit --since 2024-01-01 --until 2024-02-01 --limit 0 --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listAuditEvents(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().StringP("project", "p", "", "Only show events for this project (default: the whole account)")
+	auditCmd.Flags().String("since", "", "Start of the window (RFC3339, date, or duration ago like 7d)")
+	auditCmd.Flags().String("until", "", "End of the window (RFC3339, date, or duration ago like 1d)")
+	auditCmd.Flags().String("actor", "", "Only show events by this user or API key")
+	auditCmd.Flags().String("action", "", "Only show this action (e.g. workspace.delete)")
+	auditCmd.Flags().Int("limit", defaultAuditLimit, "Maximum number of events to show (0 = all)")
+	auditCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
+}
+
+// AuditEvent is one recorded operation
+type AuditEvent struct {
+	ID        string                 `json:"id"`
+	Actor     string                 `json:"actor"`
+	Action    string                 `json:"action"`
+	Resource  string                 `json:"resource"`
+	ProjectID string                 `json:"project_id,omitempty"`
+	IP        string                 `json:"ip"`
+	Timestamp time.Time              `json:"timestamp"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// AuditEventPage is one page of audit events
+type AuditEventPage struct {
+	Events     []AuditEvent `json:"events"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+func listAuditEvents(cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	projectID, _ := cmd.Flags().GetString("project")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+	actor, _ := cmd.Flags().GetString("actor")
+	action, _ := cmd.Flags().GetString("action")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	if limit < 0 {
+		return fmt.Errorf("--limit cannot be negative")
+	}
+
+	now := time.Now()
+	params := url.Values{}
+	var since, until time.Time
+	if sinceFlag != "" {
+		if since, err = parseTimeFlag(sinceFlag, now); err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		params.Set("since", since.UTC().Format(time.RFC3339))
+	}
+	if untilFlag != "" {
+		if until, err = parseTimeFlag(untilFlag, now); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+		params.Set("until", until.UTC().Format(time.RFC3339))
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return fmt.Errorf("--since must be before --until")
+	}
+	if projectID != "" {
+		params.Set("project_id", projectID)
+	}
+	if actor != "" {
+		params.Set("actor", actor)
+	}
+	if action != "" {
+		params.Set("action", action)
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	events, err := fetchAuditEvents(apiClient, params, limit)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case isNDJSONOutput():
+		for _, event := range events {
+			if err := printNDJSON(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	case isJSONOutput():
+		return printJSON(events)
+	}
+
+	scope := "account"
+	if projectID != "" {
+		scope = projectID
+	}
+	if len(events) == 0 {
+		fmt.Printf("%s No audit events found for %s\n", ui.Yellow("📭"), ui.Cyan(scope))
+		return nil
+	}
+
+	table := newListTable("Time", "Actor", "Action", "Resource", "IP")
+	table.SetHeaderColor(
+		ui.TableColors(color.FgHiMagenta),
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiWhite),
+		ui.TableColors(color.FgHiBlue),
+	)
+	if err := table.SelectColumns(cmd, AuditEvent{}); err != nil {
+		return err
+	}
+	for _, event := range events {
+		table.AppendRecord([]string{
+			event.Timestamp.Local().Format("2006-01-02 15:04:05"),
+			event.Actor,
+			event.Action,
+			event.Resource,
+			event.IP,
+		}, event)
+	}
+
+	fmt.Printf("\n%s %s\n\n",
+		ui.New(color.Bold).Sprint("🔍 Audit Log:"),
+		ui.Cyan(scope))
+	table.Render()
+
+	if limit > 0 && len(events) == limit {
+		fmt.Printf("\n%s Showing the latest %d events; use --limit or narrow --since/--until for more\n",
+			ui.Muted("ℹ️"), limit)
+	}
+	return nil
+}
+
+// fetchAuditEvents follows the pages of /api/v1/sdk/audit until limit
+// events have been read (0 = all). Servers that don't page return every
+// event as a plain array.
+func fetchAuditEvents(apiClient *client.APIClient, params url.Values, limit int) ([]AuditEvent, error) {
+	events := []AuditEvent{}
+	cursor := ""
+	for {
+		query := url.Values{}
+		for key, values := range params {
+			query[key] = values
+		}
+		if limit > 0 {
+			query.Set("limit", fmt.Sprintf("%d", limit-len(events)))
+		}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		endpoint := "/api/v1/sdk/audit"
+		if len(query) > 0 {
+			endpoint += "?" + query.Encode()
+		}
+
+		var body json.RawMessage
+		if err := apiClient.GET(endpoint, &body); err != nil {
+			return nil, fmt.Errorf("failed to get audit events: %w", err)
+		}
+
+		var page AuditEventPage
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+			if err := json.Unmarshal(trimmed, &page.Events); err != nil {
+				return nil, fmt.Errorf("failed to parse audit events: %w", err)
+			}
+		} else if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse audit events: %w", err)
+		}
+
+		events = append(events, page.Events...)
+		if limit > 0 && len(events) >= limit {
+			return events[:limit], nil
+		}
+		if page.NextCursor == "" || page.NextCursor == cursor || len(page.Events) == 0 {
+			return events, nil
+		}
+		cursor = page.NextCursor
+	}
+}
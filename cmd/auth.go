@@ -17,17 +17,22 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
-	"golang.org/x/crypto/ssh/terminal"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
 
 	"github.com/fleeks-inc/fleeks-cli/internal/client"
 	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 // authCmd represents the auth command
@@ -66,6 +71,10 @@ Examples:
   
   # Switch between accounts
   fleeks auth switch
+
+  # List your organizations and switch the active one
+  fleeks auth orgs
+  fleeks auth use-org acme-corp
 `,
 }
 
@@ -77,7 +86,18 @@ var authLoginCmd = &cobra.Command{
 You can obtain your API key from the Fleeks Dashboard:
 https://dashboard.fleeks.dev/settings/api-keys
 
-The API key will be securely stored in your local configuration.`,
+The API key will be securely stored in your local configuration.
+
+With --sso, sign in through your organization's identity provider instead.
+Your browser opens to the Fleeks login page and the CLI receives the result
+on a local callback. Without a browser (for example over SSH, or with
+--no-browser) a code is printed to enter at a URL on any other device.
+
+Examples:
+  fleeks auth login
+  fleeks auth login --api-key sk_your_api_key_here
+  fleeks auth login --sso
+  fleeks auth login --sso --no-browser`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return loginUser(cmd)
 	},
@@ -104,7 +124,12 @@ Displays:
 - Authentication status
 - Current user information
 - API key status
-- Available scopes and permissions`,
+- Available scopes and permissions
+
+Exits non-zero when no API key is configured, the key is rejected or it
+can't be verified, so it can gate CI jobs. Use --output json for a machine-readable result:
+
+  fleeks auth status --output json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return showAuthStatus(cmd)
 	},
@@ -113,22 +138,54 @@ Displays:
 var authWhoamiCmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Show current user",
-	Long:  `Show information about the currently authenticated user.`,
+	Long: `Show information about the currently authenticated user.
+
+Use --output json to get the user record in a script-friendly form.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return showCurrentUser(cmd)
 	},
 }
 
+var authOrgsCmd = &cobra.Command{
+	Use:   "orgs",
+	Short: "List your organizations",
+	Long: `List the organizations you belong to.
+
+The active organization, which scopes subsequent API calls, is marked
+with an asterisk. Change it with 'fleeks auth use-org'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listOrganizations(cmd)
+	},
+}
+
+var authUseOrgCmd = &cobra.Command{
+	Use:   "use-org [org-id]",
+	Short: "Switch the active organization",
+	Long: `Set the organization applied to subsequent API calls.
+
+The organization can be given by ID or slug and must be one you belong to.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return useOrganization(args[0], cmd)
+	},
+}
+
 func init() {
 	// Add subcommands
 	authCmd.AddCommand(authLoginCmd)
 	authCmd.AddCommand(authLogoutCmd)
 	authCmd.AddCommand(authStatusCmd)
 	authCmd.AddCommand(authWhoamiCmd)
+	authCmd.AddCommand(authOrgsCmd)
+	authCmd.AddCommand(authUseOrgCmd)
 
 	// Login command flags
 	authLoginCmd.Flags().StringP("api-key", "k", "", "API key for authentication")
 	authLoginCmd.Flags().StringP("base-url", "u", "", "Custom API base URL")
+	authLoginCmd.Flags().Bool("sso", false, "Sign in through your identity provider in the browser")
+	authLoginCmd.Flags().Bool("no-browser", false, "With --sso, sign in with a device code instead of opening a browser")
+	authLoginCmd.MarkFlagsMutuallyExclusive("sso", "api-key")
+	markFlagRequires(authLoginCmd, "no-browser", "sso")
 }
 
 // AuthResponse represents authentication response
@@ -153,6 +210,16 @@ type UserInfo struct {
 	LastLogin    string   `json:"last_login"`
 }
 
+// Organization represents an organization the user belongs to
+type Organization struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Slug   string `json:"slug,omitempty"`
+	Role   string `json:"role"`
+	Plan   string `json:"plan,omitempty"`
+	Active bool   `json:"active"`
+}
+
 func loginUser(cmd *cobra.Command) error {
 	// Load configuration
 	cfg, err := config.Load()
@@ -162,7 +229,12 @@ func loginUser(cmd *cobra.Command) error {
 
 	// Get API key from flag or prompt
 	apiKey, _ := cmd.Flags().GetString("api-key")
-	baseURL, _ := cmd.Flags().GetString("base-url")
+	sso, _ := cmd.Flags().GetBool("sso")
+	applyLoginBaseURL(cmd, cfg)
+
+	if sso {
+		return loginUserSSO(cmd, cfg)
+	}
 
 	if apiKey == "" {
 		// Prompt for API key
@@ -186,17 +258,12 @@ func loginUser(cmd *cobra.Command) error {
 		}
 	}
 
-	// Set custom base URL if provided
-	if baseURL != "" {
-		cfg.API.BaseURL = baseURL
-	}
-
 	// Create API client
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(apiKey)
 
 	// Validate API key by making a test request
-	fmt.Printf("%s Validating API key...\n", color.CyanString("ðŸ”"))
+	fmt.Printf("%s Validating API key...\n", ui.Cyan("ðŸ”"))
 
 	if err := apiClient.HealthCheck(); err != nil {
 		return fmt.Errorf("API key validation failed: %w", err)
@@ -213,26 +280,72 @@ func loginUser(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to store API key: %w", err)
 	}
 
-	// Success
+	printLoginSuccess(&userInfo)
+	return nil
+}
+
+// loginUserSSO signs in through the identity provider and stores the issued
+// tokens in place of an API key
+func loginUserSSO(cmd *cobra.Command, cfg *config.Config) error {
+	noBrowser, _ := cmd.Flags().GetBool("no-browser")
+
+	apiClient := client.NewAPIClient()
+	tokens, err := ssoLogin(apiClient, apiClient.BaseURL(), noBrowser)
+	if err != nil {
+		return err
+	}
+	apiClient.SetAPIKey(tokens.AccessToken)
+
+	// Get user info to confirm authentication
+	var userInfo UserInfo
+	if err := apiClient.GET("/api/v1/auth/me", &userInfo); err != nil {
+		return fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	if err := cfg.SetAPIKey(tokens.AccessToken); err != nil {
+		return fmt.Errorf("failed to store access token: %w", err)
+	}
+	var expiry string
+	if tokens.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second).UTC().Format(time.RFC3339)
+	}
+	if err := cfg.SetRefreshToken(tokens.RefreshToken, expiry); err != nil {
+		return fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	printLoginSuccess(&userInfo)
+	return nil
+}
+
+// applyLoginBaseURL points the login at --base-url, if given. The URL is
+// set in viper so the API client uses it and it is saved with the
+// credentials, which are only valid for that API.
+func applyLoginBaseURL(cmd *cobra.Command, cfg *config.Config) {
+	if baseURL, _ := cmd.Flags().GetString("base-url"); baseURL != "" {
+		cfg.API.BaseURL = baseURL
+		viper.Set("api.base_url", baseURL)
+	}
+}
+
+// printLoginSuccess greets the signed-in user and suggests next steps
+func printLoginSuccess(userInfo *UserInfo) {
 	fmt.Printf("\n%s %s\n",
-		color.GreenString("âœ… Authentication successful!"),
-		color.CyanString("Welcome to Fleeks"))
+		ui.Green("âœ… Authentication successful!"),
+		ui.Cyan("Welcome to Fleeks"))
 
-	fmt.Printf("User:         %s (%s)\n", color.YellowString(userInfo.Name), userInfo.Email)
-	fmt.Printf("Organization: %s\n", color.BlueString(userInfo.Organization))
-	fmt.Printf("Plan:         %s\n", color.MagentaString(userInfo.Plan))
+	fmt.Printf("User:         %s (%s)\n", ui.Yellow(userInfo.Name), userInfo.Email)
+	fmt.Printf("Organization: %s\n", ui.Blue(userInfo.Organization))
+	fmt.Printf("Plan:         %s\n", ui.Magenta(userInfo.Plan))
 
 	if !userInfo.Verified {
 		fmt.Printf("\n%s Please verify your email address to access all features.\n",
-			color.YellowString("âš ï¸"))
+			ui.Yellow("âš ï¸"))
 	}
 
 	// Show next steps
-	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("ðŸš€ Next steps:"))
-	fmt.Printf("  %s\n", color.CyanString("fleeks workspace create my-project --template python"))
-	fmt.Printf("  %s\n", color.CyanString("fleeks agent start --project my-project --task \"Build authentication system\""))
-
-	return nil
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("ðŸš€ Next steps:"))
+	fmt.Printf("  %s\n", ui.Cyan("fleeks workspace create my-project --template python"))
+	fmt.Printf("  %s\n", ui.Cyan("fleeks agent start --project my-project --task \"Build authentication system\""))
 }
 
 func logoutUser(cmd *cobra.Command) error {
@@ -243,7 +356,7 @@ func logoutUser(cmd *cobra.Command) error {
 	}
 
 	if cfg.GetAPIKey() == "" {
-		fmt.Printf("%s You are not logged in.\n", color.YellowString("â„¹ï¸"))
+		fmt.Printf("%s You are not logged in.\n", ui.Yellow("â„¹ï¸"))
 		return nil
 	}
 
@@ -270,10 +383,71 @@ func logoutUser(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	fmt.Printf("%s Logged out successfully.\n", color.GreenString("ðŸ‘‹"))
+	fmt.Printf("%s Logged out successfully.\n", ui.Green("ðŸ‘‹"))
 	return nil
 }
 
+// AuthStatus is the result of 'auth status', also emitted with --output json
+type AuthStatus struct {
+	Authenticated bool   `json:"authenticated"`
+	APIKeyValid   bool   `json:"api_key_valid"`
+	User          string `json:"user"`
+	Org           string `json:"org"`
+	Plan          string `json:"plan"`
+	Verified      bool   `json:"verified"`
+	APIURL        string `json:"api_url"`
+	Error         string `json:"error,omitempty"`
+
+	userInfo *UserInfo
+	// rejected is set when the API refused the key, as opposed to the key
+	// not being checked because the API couldn't be reached
+	rejected bool
+}
+
+// checkAuthStatus verifies the configured API key by loading the user it
+// belongs to; /health alone doesn't look at the key. Failures are recorded
+// in the status rather than returned; the only error is the --raw stop,
+// when a response was printed in place of the status.
+func checkAuthStatus(cfg *config.Config) (*AuthStatus, error) {
+	status := &AuthStatus{APIURL: cfg.API.BaseURL}
+	if cfg.GetAPIKey() == "" {
+		status.Error = "API key not configured"
+		return status, nil
+	}
+
+	// Create API client and test connection
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	if err := apiClient.HealthCheck(); err != nil {
+		if errors.Is(err, client.ErrRawResponse) {
+			return nil, err
+		}
+		status.Error = err.Error()
+		return status, nil
+	}
+
+	var userInfo UserInfo
+	if err := apiClient.GET("/api/v1/auth/me", &userInfo); err != nil {
+		if errors.Is(err, client.ErrRawResponse) {
+			return nil, err
+		}
+		var apiErr *client.ErrorResponse
+		status.rejected = errors.As(err, &apiErr) &&
+			(apiErr.Code == http.StatusUnauthorized || apiErr.Code == http.StatusForbidden)
+		status.Error = fmt.Sprintf("user info unavailable: %v", err)
+		return status, nil
+	}
+	status.APIKeyValid = true
+	status.Authenticated = true
+	status.userInfo = &userInfo
+	status.User = userInfo.Email
+	status.Org = userInfo.Organization
+	status.Plan = userInfo.Plan
+	status.Verified = userInfo.Verified
+	return status, nil
+}
+
 func showAuthStatus(cmd *cobra.Command) error {
 	// Load configuration
 	cfg, err := config.Load()
@@ -281,53 +455,71 @@ func showAuthStatus(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	fmt.Printf("\n%s\n\n", color.New(color.Bold).Sprint("ðŸ” Authentication Status"))
+	status, err := checkAuthStatus(cfg)
+	if err != nil {
+		return err
+	}
+
+	silenceUsage(cmd)
+	var result error
+	switch {
+	case cfg.GetAPIKey() == "":
+		result = fmt.Errorf("not authenticated")
+	case status.rejected:
+		result = fmt.Errorf("API key is invalid")
+	case !status.APIKeyValid:
+		result = fmt.Errorf("could not verify the API key: %s", status.Error)
+	}
+
+	if isJSONOutput() {
+		if err := printJSON(status); err != nil {
+			return err
+		}
+		return result
+	}
+
+	fmt.Printf("\n%s\n\n", ui.New(color.Bold).Sprint("ðŸ” Authentication Status"))
 
 	if cfg.GetAPIKey() == "" {
-		fmt.Printf("Status:       %s\n", color.RedString("Not authenticated"))
-		fmt.Printf("API Key:      %s\n", color.New(color.FgHiBlack).Sprint("Not configured"))
+		fmt.Printf("Status:       %s\n", ui.Red("Not authenticated"))
+		fmt.Printf("API Key:      %s\n", ui.New(color.FgHiBlack).Sprint("Not configured"))
 		fmt.Printf("\n%s Run 'fleeks auth login' to authenticate.\n",
-			color.YellowString("ðŸ’¡"))
-		return nil
+			ui.Yellow("ðŸ’¡"))
+		return result
 	}
 
-	// Create API client and test connection
-	apiClient := client.NewAPIClient()
-	apiClient.SetAPIKey(cfg.GetAPIKey())
+	if !status.APIKeyValid && !status.rejected {
+		fmt.Printf("Status:       %s\n", ui.Yellow("Unknown"))
+		fmt.Printf("API Key:      %s\n", ui.Yellow("Not verified"))
+		fmt.Printf("Error:        %s\n", ui.Red(status.Error))
+		return result
+	}
 
-	// Test API connection
-	if err := apiClient.HealthCheck(); err != nil {
-		fmt.Printf("Status:       %s\n", color.RedString("Authentication failed"))
-		fmt.Printf("API Key:      %s\n", color.RedString("Invalid"))
-		fmt.Printf("Error:        %s\n", color.RedString(err.Error()))
+	if !status.APIKeyValid {
+		fmt.Printf("Status:       %s\n", ui.Red("Authentication failed"))
+		fmt.Printf("API Key:      %s\n", ui.Red("Invalid"))
+		fmt.Printf("Error:        %s\n", ui.Red(status.Error))
 		fmt.Printf("\n%s Run 'fleeks auth login' to re-authenticate.\n",
-			color.YellowString("ðŸ’¡"))
-		return nil
+			ui.Yellow("ðŸ’¡"))
+		return result
 	}
 
-	// Get user info
-	var userInfo UserInfo
-	if err := apiClient.GET("/api/v1/auth/me", &userInfo); err != nil {
-		fmt.Printf("Status:       %s\n", color.YellowString("Partial"))
-		fmt.Printf("API Key:      %s\n", color.GreenString("Valid"))
-		fmt.Printf("User Info:    %s\n", color.RedString("Unavailable"))
-		return nil
-	}
+	userInfo := status.userInfo
 
 	// Display full status
-	fmt.Printf("Status:       %s\n", color.GreenString("Authenticated"))
-	fmt.Printf("API Key:      %s\n", color.GreenString("Valid"))
-	fmt.Printf("User:         %s (%s)\n", color.YellowString(userInfo.Name), userInfo.Email)
-	fmt.Printf("Organization: %s\n", color.BlueString(userInfo.Organization))
-	fmt.Printf("Plan:         %s\n", color.MagentaString(userInfo.Plan))
+	fmt.Printf("Status:       %s\n", ui.Green("Authenticated"))
+	fmt.Printf("API Key:      %s\n", ui.Green("Valid"))
+	fmt.Printf("User:         %s (%s)\n", ui.Yellow(userInfo.Name), userInfo.Email)
+	fmt.Printf("Organization: %s\n", ui.Blue(userInfo.Organization))
+	fmt.Printf("Plan:         %s\n", ui.Magenta(userInfo.Plan))
 	fmt.Printf("Verified:     %s\n", getBoolColor(userInfo.Verified))
-	fmt.Printf("API URL:      %s\n", color.CyanString(cfg.API.BaseURL))
+	fmt.Printf("API URL:      %s\n", ui.Cyan(status.APIURL))
 
 	// Scopes
 	if len(userInfo.Scopes) > 0 {
-		fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("ðŸ”‘ Available Scopes:"))
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("ðŸ”‘ Available Scopes:"))
 		for _, scope := range userInfo.Scopes {
-			fmt.Printf("  â€¢ %s\n", color.GreenString(scope))
+			fmt.Printf("  â€¢ %s\n", ui.Green(scope))
 		}
 	}
 
@@ -355,34 +547,141 @@ func showCurrentUser(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get user info: %w", err)
 	}
 
+	if isJSONOutput() {
+		return printJSON(userInfo)
+	}
+
 	// Display user information
-	fmt.Printf("\n%s\n\n", color.New(color.Bold).Sprint("ðŸ‘¤ Current User"))
+	fmt.Printf("\n%s\n\n", ui.New(color.Bold).Sprint("ðŸ‘¤ Current User"))
 
-	fmt.Printf("%-15s %s\n", "ID:", color.CyanString(userInfo.ID))
-	fmt.Printf("%-15s %s\n", "Name:", color.YellowString(userInfo.Name))
+	fmt.Printf("%-15s %s\n", "ID:", ui.Cyan(userInfo.ID))
+	fmt.Printf("%-15s %s\n", "Name:", ui.Yellow(userInfo.Name))
 	fmt.Printf("%-15s %s\n", "Email:", userInfo.Email)
 	if userInfo.Organization != "" {
-		fmt.Printf("%-15s %s\n", "Organization:", color.BlueString(userInfo.Organization))
+		fmt.Printf("%-15s %s\n", "Organization:", ui.Blue(userInfo.Organization))
 	}
-	fmt.Printf("%-15s %s\n", "Plan:", color.MagentaString(userInfo.Plan))
+	fmt.Printf("%-15s %s\n", "Plan:", ui.Magenta(userInfo.Plan))
 	fmt.Printf("%-15s %s\n", "Verified:", getBoolColor(userInfo.Verified))
-	fmt.Printf("%-15s %s\n", "Created:", color.MagentaString(userInfo.CreatedAt))
-	fmt.Printf("%-15s %s\n", "Last Login:", color.MagentaString(userInfo.LastLogin))
+	fmt.Printf("%-15s %s\n", "Created:", ui.Magenta(userInfo.CreatedAt))
+	fmt.Printf("%-15s %s\n", "Last Login:", ui.Magenta(userInfo.LastLogin))
+
+	return nil
+}
+
+// fetchOrganizations returns the user's organizations with Active set on the
+// one currently selected locally, falling back to the server's default.
+func fetchOrganizations(apiClient *client.APIClient, cfg *config.Config) ([]Organization, error) {
+	var orgs []Organization
+	if err := apiClient.GET("/api/v1/auth/organizations", &orgs); err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	if selected := cfg.Auth.Organization; selected != "" {
+		for i := range orgs {
+			orgs[i].Active = orgs[i].ID == selected || orgs[i].Slug == selected
+		}
+	}
+	return orgs, nil
+}
+
+func listOrganizations(cmd *cobra.Command) error {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("not authenticated. Run 'fleeks auth login' first")
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	orgs, err := fetchOrganizations(apiClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	if isJSONOutput() {
+		return printJSON(orgs)
+	}
+
+	if len(orgs) == 0 {
+		fmt.Printf("%s You don't belong to any organizations.\n", ui.Yellow("ℹ️"))
+		return nil
+	}
+
+	fmt.Printf("\n%s\n\n", ui.New(color.Bold).Sprint("🏢 Organizations"))
+
+	for _, org := range orgs {
+		marker := " "
+		name := org.Name
+		if org.Active {
+			marker = ui.Green("*")
+			name = ui.Green(org.Name)
+		}
+		fmt.Printf("%s %-30s %-24s %s\n",
+			marker, name, ui.Cyan(org.ID), ui.Magenta(org.Role))
+	}
+
+	fmt.Printf("\n%s Switch with: %s\n",
+		ui.Yellow("💡"), ui.Cyan("fleeks auth use-org <org-id>"))
+	return nil
+}
+
+func useOrganization(orgID string, cmd *cobra.Command) error {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("not authenticated. Run 'fleeks auth login' first")
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	orgs, err := fetchOrganizations(apiClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	var match *Organization
+	for i := range orgs {
+		if orgs[i].ID == orgID || orgs[i].Slug == orgID {
+			match = &orgs[i]
+			break
+		}
+	}
+	if match == nil {
+		return fmt.Errorf("organization %q not found. Run 'fleeks auth orgs' to see available organizations", orgID)
+	}
+
+	if err := cfg.SetOrganization(match.ID); err != nil {
+		return fmt.Errorf("failed to save organization: %w", err)
+	}
 
+	fmt.Printf("%s Active organization: %s (%s)\n",
+		ui.Green("✅"), ui.Yellow(match.Name), ui.Cyan(match.ID))
 	return nil
 }
 
 func getBoolColor(value bool) string {
 	if value {
-		return color.GreenString("Yes")
+		return ui.Green("Yes")
 	}
-	return color.RedString("No")
+	return ui.Red("No")
 }
 
 // Helper function to securely read password from terminal
 func readPassword(prompt string) (string, error) {
 	fmt.Print(prompt)
-	password, err := terminal.ReadPassword(int(syscall.Stdin))
+	password, err := term.ReadPassword(int(syscall.Stdin))
 	fmt.Println()
 	if err != nil {
 		return "", err
@@ -0,0 +1,265 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// defaultAutoscaleTarget is the utilization percentage 'container scale
+// --auto' aims for without --target-utilization
+const defaultAutoscaleTarget = 70
+
+var containerAutoscaleCmd = &cobra.Command{
+	Use:   "autoscale",
+	Short: "Manage container autoscaling",
+	Long: `Inspect the autoscale policy of a container. Set a policy with
+'fleeks container scale [project-id] --auto'.`,
+}
+
+var containerAutoscaleStatusCmd = &cobra.Command{
+	Use:   "status [project-id]",
+	Short: "Show the autoscale policy and recent scaling actions",
+	Long: `Show the container's autoscale policy, its current allocation and the
+scaling actions taken recently.
+
+Examples:
+  fleeks container autoscale status my-project
+  fleeks container autoscale status my-project --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showAutoscaleStatus(args[0], cmd)
+	},
+}
+
+func init() {
+	containerCmd.AddCommand(containerAutoscaleCmd)
+	containerAutoscaleCmd.AddCommand(containerAutoscaleStatusCmd)
+
+	containerAutoscaleStatusCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
+
+	// Accept --project in place of the [project-id] argument
+	acceptProjectFlag(containerAutoscaleStatusCmd)
+}
+
+// AutoscalePolicy bounds the resources autoscaling may allocate and the
+// utilization it aims to keep
+type AutoscalePolicy struct {
+	MinCPU            string     `json:"min_cpu,omitempty"`
+	MaxCPU            string     `json:"max_cpu,omitempty"`
+	MinMemory         string     `json:"min_memory,omitempty"`
+	MaxMemory         string     `json:"max_memory,omitempty"`
+	TargetUtilization int        `json:"target_utilization"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// ScalingAction is one resource change made by autoscaling
+type ScalingAction struct {
+	Timestamp time.Time `json:"timestamp"`
+	Resource  string    `json:"resource"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Reason    string    `json:"reason"`
+}
+
+// AutoscaleStatus is a container's autoscale policy, or nil without one,
+// with its current allocation and recent actions
+type AutoscaleStatus struct {
+	Policy        *AutoscalePolicy `json:"policy"`
+	CurrentCPU    string           `json:"current_cpu,omitempty"`
+	CurrentMemory string           `json:"current_memory,omitempty"`
+	Actions       []ScalingAction  `json:"actions"`
+}
+
+// setAutoscalePolicy registers the policy described by the --auto flags of
+// 'container scale'
+func setAutoscalePolicy(apiClient *client.APIClient, projectID string, cmd *cobra.Command) error {
+	policy := AutoscalePolicy{}
+	policy.MinCPU, _ = cmd.Flags().GetString("min-cpu")
+	policy.MaxCPU, _ = cmd.Flags().GetString("max-cpu")
+	policy.MinMemory, _ = cmd.Flags().GetString("min-memory")
+	policy.MaxMemory, _ = cmd.Flags().GetString("max-memory")
+	policy.TargetUtilization, _ = cmd.Flags().GetInt("target-utilization")
+
+	if err := validateAutoscalePolicy(policy); err != nil {
+		return err
+	}
+
+	status := newStatusReporter()
+	defer status.Stop()
+	status.Start("Registering autoscale policy")
+
+	var registered AutoscalePolicy
+	endpoint := fmt.Sprintf("/api/v1/sdk/containers/%s/autoscale", projectID)
+	if err := apiClient.POST(endpoint, policy, &registered); err != nil {
+		return status.Fail(fmt.Errorf("failed to set autoscale policy: %w", err))
+	}
+	status.Done("Autoscale policy registered")
+
+	// Older servers acknowledge without echoing the policy
+	if registered.TargetUtilization == 0 {
+		registered = policy
+	}
+	if isJSONOutput() {
+		return printJSON(registered)
+	}
+
+	fmt.Printf("%s Container %s now autoscales\n", ui.Green("📈"), ui.Cyan(projectID))
+	printAutoscalePolicy(registered)
+	return nil
+}
+
+// validateAutoscalePolicy checks that each resource has both bounds or
+// neither, that at least one resource is bounded, and that the bounds and
+// target are in range
+func validateAutoscalePolicy(policy AutoscalePolicy) error {
+	if (policy.MinCPU == "") != (policy.MaxCPU == "") {
+		return fmt.Errorf("--min-cpu and --max-cpu must be given together")
+	}
+	if (policy.MinMemory == "") != (policy.MaxMemory == "") {
+		return fmt.Errorf("--min-memory and --max-memory must be given together")
+	}
+	if policy.MinCPU == "" && policy.MinMemory == "" {
+		return fmt.Errorf("--auto needs --min-cpu/--max-cpu, --min-memory/--max-memory, or both")
+	}
+	if policy.TargetUtilization < 1 || policy.TargetUtilization > 100 {
+		return fmt.Errorf("--target-utilization must be between 1 and 100")
+	}
+
+	if policy.MinCPU != "" {
+		minCPU, err := strconv.ParseFloat(policy.MinCPU, 64)
+		if err != nil || minCPU <= 0 {
+			return fmt.Errorf("invalid --min-cpu %q (expected e.g. 0.5, 1, 2)", policy.MinCPU)
+		}
+		maxCPU, err := strconv.ParseFloat(policy.MaxCPU, 64)
+		if err != nil || maxCPU <= 0 {
+			return fmt.Errorf("invalid --max-cpu %q (expected e.g. 0.5, 1, 2)", policy.MaxCPU)
+		}
+		if minCPU > maxCPU {
+			return fmt.Errorf("--min-cpu %s is above --max-cpu %s", policy.MinCPU, policy.MaxCPU)
+		}
+	}
+
+	if policy.MinMemory != "" {
+		minMemory, err := parseSize(policy.MinMemory)
+		if err != nil || minMemory == 0 {
+			return fmt.Errorf("invalid --min-memory %q (expected e.g. 512M, 1G)", policy.MinMemory)
+		}
+		maxMemory, err := parseSize(policy.MaxMemory)
+		if err != nil || maxMemory == 0 {
+			return fmt.Errorf("invalid --max-memory %q (expected e.g. 512M, 1G)", policy.MaxMemory)
+		}
+		if minMemory > maxMemory {
+			return fmt.Errorf("--min-memory %s is above --max-memory %s", policy.MinMemory, policy.MaxMemory)
+		}
+	}
+	return nil
+}
+
+func printAutoscalePolicy(policy AutoscalePolicy) {
+	if policy.MinCPU != "" {
+		fmt.Printf("CPU:    %s - %s\n", ui.Yellow(policy.MinCPU), ui.Yellow(policy.MaxCPU))
+	}
+	if policy.MinMemory != "" {
+		fmt.Printf("Memory: %s - %s\n", ui.Blue(policy.MinMemory), ui.Blue(policy.MaxMemory))
+	}
+	fmt.Printf("Target: %s utilization\n", ui.Magenta("%d%%", policy.TargetUtilization))
+}
+
+func showAutoscaleStatus(projectID string, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	var status AutoscaleStatus
+	endpoint := fmt.Sprintf("/api/v1/sdk/containers/%s/autoscale", projectID)
+	if err := apiClient.GET(endpoint, &status); err != nil {
+		return fmt.Errorf("failed to get autoscale status: %w", err)
+	}
+
+	if isJSONOutput() {
+		return printJSON(status)
+	}
+
+	fmt.Printf("\n%s %s\n\n", ui.New(color.Bold).Sprint("📈 Autoscaling:"), ui.Cyan(projectID))
+	if status.Policy == nil {
+		fmt.Printf("%s No autoscale policy. Set one with: %s\n", ui.Yellow("📭"),
+			ui.Cyan("fleeks container scale "+projectID+" --auto --min-cpu 0.5 --max-cpu 2"))
+		return nil
+	}
+
+	printAutoscalePolicy(*status.Policy)
+	var current []string
+	if status.CurrentCPU != "" {
+		current = append(current, "CPU "+status.CurrentCPU)
+	}
+	if status.CurrentMemory != "" {
+		current = append(current, "memory "+status.CurrentMemory)
+	}
+	if len(current) > 0 {
+		fmt.Printf("Now:    %s\n", strings.Join(current, ", "))
+	}
+
+	if len(status.Actions) == 0 {
+		fmt.Printf("\n%s No scaling actions yet\n", ui.Muted("ℹ️"))
+		return nil
+	}
+
+	table := newListTable("Time", "Resource", "From", "To", "Reason")
+	table.SetHeaderColor(
+		ui.TableColors(color.FgHiMagenta),
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiGreen),
+		ui.TableColors(color.FgHiWhite),
+	)
+	if err := table.SelectColumns(cmd, ScalingAction{}); err != nil {
+		return err
+	}
+	for _, action := range status.Actions {
+		table.AppendRecord([]string{
+			action.Timestamp.Local().Format("2006-01-02 15:04:05"),
+			action.Resource,
+			action.From,
+			action.To,
+			action.Reason,
+		}, action)
+	}
+
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("Recent scaling actions:"))
+	table.Render()
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// keyDependencies are the modules listed by 'fleeks version --verbose'
+var keyDependencies = []string{
+	"github.com/spf13/cobra",
+	"github.com/spf13/viper",
+	"github.com/go-resty/resty/v2",
+	"github.com/gorilla/websocket",
+	"github.com/fsnotify/fsnotify",
+}
+
+// BuildInfo describes the running binary. Fields set through ldflags take
+// precedence; the rest come from the information the Go toolchain embeds.
+type BuildInfo struct {
+	Version      string            `json:"version"`
+	GitCommit    string            `json:"git_commit"`
+	BuildTime    string            `json:"build_time"`
+	GoVersion    string            `json:"go_version"`
+	Platform     string            `json:"platform"`
+	Module       string            `json:"module,omitempty"`
+	VCSRevision  string            `json:"vcs_revision,omitempty"`
+	VCSTime      string            `json:"vcs_time,omitempty"`
+	VCSModified  bool              `json:"vcs_modified"`
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+// readBuildInfo combines the ldflags variables with debug.ReadBuildInfo, so
+// a binary built without ldflags still identifies its commit
+func readBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	embedded, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.Module = embedded.Main.Path
+	if info.Version == "dev" && embedded.Main.Version != "" && embedded.Main.Version != "(devel)" {
+		info.Version = embedded.Main.Version
+	}
+	for _, setting := range embedded.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.VCSRevision = setting.Value
+		case "vcs.time":
+			info.VCSTime = setting.Value
+		case "vcs.modified":
+			info.VCSModified = setting.Value == "true"
+		}
+	}
+	if info.GitCommit == "unknown" && info.VCSRevision != "" {
+		info.GitCommit = info.VCSRevision
+		if len(info.GitCommit) > 12 {
+			info.GitCommit = info.GitCommit[:12]
+		}
+		if info.VCSModified {
+			info.GitCommit += "-dirty"
+		}
+	}
+	if info.BuildTime == "unknown" && info.VCSTime != "" {
+		info.BuildTime = info.VCSTime + " (commit time)"
+	}
+
+	info.Dependencies = make(map[string]string)
+	for _, dep := range embedded.Deps {
+		for _, key := range keyDependencies {
+			if dep.Path == key {
+				version := dep.Version
+				if dep.Replace != nil {
+					version = fmt.Sprintf("%s => %s %s", version, dep.Replace.Path, dep.Replace.Version)
+				}
+				info.Dependencies[dep.Path] = version
+			}
+		}
+	}
+	return info
+}
+
+func showVersion() error {
+	info := readBuildInfo()
+	if isJSONOutput() {
+		return printJSON(info)
+	}
+
+	fmt.Printf("🚀 Fleeks CLI\n")
+	fmt.Printf("Version:    %s\n", info.Version)
+	fmt.Printf("Git Commit: %s\n", info.GitCommit)
+	fmt.Printf("Built:      %s\n", info.BuildTime)
+	fmt.Printf("Platform:   Universal Multi-Agent Development\n")
+
+	if IsVerbose() {
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("Build details:"))
+		fmt.Printf("Go:         %s (%s)\n", info.GoVersion, info.Platform)
+		if info.Module != "" {
+			fmt.Printf("Module:     %s\n", info.Module)
+		}
+		if info.VCSRevision != "" {
+			fmt.Printf("Revision:   %s\n", info.VCSRevision)
+			fmt.Printf("Committed:  %s\n", info.VCSTime)
+			fmt.Printf("Modified:   %t\n", info.VCSModified)
+		} else {
+			fmt.Printf("Revision:   %s\n", ui.Muted("not embedded (built outside a git checkout or with -buildvcs=false)"))
+		}
+		if len(info.Dependencies) > 0 {
+			fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("Dependencies:"))
+			for _, key := range keyDependencies {
+				if version, ok := info.Dependencies[key]; ok {
+					fmt.Printf("  %-32s %s\n", strings.TrimPrefix(key, "github.com/"), version)
+				}
+			}
+		}
+		return nil
+	}
+
+	fmt.Printf("\n🌟 Revolutionary Features: Multi-agent workflows, Hybrid local-cloud, Real-time streaming\n")
+	return nil
+}
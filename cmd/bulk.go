@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// BulkItemResult is the outcome of one item of a bulk operation
+type BulkItemResult struct {
+	Item  string `json:"item"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// BulkReport is the JSON form of a BulkResult
+type BulkReport struct {
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Items     []BulkItemResult `json:"items"`
+}
+
+// BulkResult records the outcome of every item of a bulk operation, such as
+// a recursive transfer or starting agents across projects, so one failure
+// doesn't abort the rest. Once every item has been attempted, PrintSummary
+// reports the counts and failures and Err makes the command exit non-zero
+// if anything failed. It is safe for concurrent use.
+type BulkResult struct {
+	noun string // what the items are, e.g. "file"
+	verb string // the operation, e.g. "upload"
+
+	mu    sync.Mutex
+	items []BulkItemResult
+}
+
+// newBulkResult starts recording a bulk operation, e.g.
+// newBulkResult("file", "download")
+func newBulkResult(noun, verb string) *BulkResult {
+	return &BulkResult{noun: noun, verb: verb}
+}
+
+// Record stores the outcome of one item; a nil err means it succeeded
+func (r *BulkResult) Record(item string, err error) {
+	result := BulkItemResult{Item: item, OK: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	r.mu.Lock()
+	r.items = append(r.items, result)
+	r.mu.Unlock()
+}
+
+// Total returns the number of items recorded
+func (r *BulkResult) Total() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.items)
+}
+
+// Failures returns the failed items sorted by name
+func (r *BulkResult) Failures() []BulkItemResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var failures []BulkItemResult
+	for _, item := range r.items {
+		if !item.OK {
+			failures = append(failures, item)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Item < failures[j].Item })
+	return failures
+}
+
+// Succeeded returns the number of items that succeeded
+func (r *BulkResult) Succeeded() int {
+	return r.Total() - len(r.Failures())
+}
+
+// Report returns every item's outcome, in the order they were recorded
+func (r *BulkResult) Report() BulkReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := BulkReport{Items: append([]BulkItemResult{}, r.items...)}
+	for _, item := range r.items {
+		if item.OK {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	return report
+}
+
+// PrintSummary prints "N succeeded, M failed" and, if anything failed, a
+// table of the failures with their errors. With --output json it prints
+// the Report instead, and with ndjson one line per item. Commands with a
+// JSON result of their own embed the Report in it rather than calling this.
+func (r *BulkResult) PrintSummary() {
+	if r.Total() == 0 {
+		return
+	}
+	if isJSONOutput() {
+		printJSON(r.Report())
+		return
+	}
+	if isNDJSONOutput() {
+		for _, item := range r.Report().Items {
+			if printNDJSON(item) != nil {
+				return
+			}
+		}
+		return
+	}
+	failures := r.Failures()
+	if len(failures) == 0 {
+		fmt.Printf("%s %d succeeded, 0 failed\n", ui.Green("✅"), r.Total())
+		return
+	}
+
+	fmt.Printf("\n%s %d succeeded, %s\n", ui.Yellow("⚠️"), r.Total()-len(failures),
+		ui.Red(fmt.Sprintf("%d failed", len(failures))))
+	table := newListTable(strings.ToUpper(r.noun[:1])+r.noun[1:], "Error")
+	for _, failure := range failures {
+		table.Append([]string{failure.Item, failure.Error})
+	}
+	table.Render()
+}
+
+// Err returns an error naming how many items failed, or nil if none did.
+// The failures have already been listed, so cmd's usage isn't repeated.
+func (r *BulkResult) Err(cmd *cobra.Command) error {
+	failed := len(r.Failures())
+	if failed == 0 {
+		return nil
+	}
+	if cmd != nil {
+		cmd.SilenceUsage = true
+	}
+	return fmt.Errorf("%d of %d %s(s) failed to %s", failed, r.Total(), r.noun, r.verb)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/state"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// How long list responses are reused when the server doesn't say otherwise
+// with Cache-Control. Lists that change with what the user does here are
+// kept briefly; catalogs that only change with a server release for longer.
+const (
+	workspaceListCacheTTL = time.Minute
+	templateCacheTTL      = 10 * time.Minute
+	capabilityCacheTTL    = 10 * time.Minute
+)
+
+// listCacheEntry is a cached response body and how long it may be reused
+type listCacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Expires   time.Time       `json:"expires"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// listCacheStore holds cached list responses, keyed by the client
+// fingerprint (API, organization and key) and the endpoint
+var listCacheStore = state.Open("cache")
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage locally cached lists",
+	Long: `Workspace lists, templates and agent capabilities are cached on disk for a
+short time so repeated commands and shell completion don't wait on the API.
+Cache-Control headers from the server take precedence over the built-in
+lifetimes, and --no-cache on any command fetches fresh data.
+
+The cache lives in ~/.fleeks/state/cache.json.`,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove every cached list",
+	Long: `Remove every cached list so the next command fetches it from the API.
+
+Examples:
+  fleeks cache clear`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return clearListCache()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func clearListCache() error {
+	removed, err := listCacheStore.Clear()
+	if err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	// Older versions kept the template list in a file of its own
+	legacy := filepath.Join(config.GetStateDir(), "cache", "templates.json")
+	if err := os.Remove(legacy); err == nil {
+		removed++
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	if isJSONOutput() {
+		return printJSON(map[string]interface{}{"removed": removed})
+	}
+	fmt.Printf("%s Cleared %d cached list(s)\n", ui.Green("✅"), removed)
+	return nil
+}
+
+// cachedGET decodes the response of a GET into result, reusing a cached
+// response that hasn't expired. refresh and --no-cache skip the lookup but
+// still store the fresh response. Mock and raw responses are never cached:
+// canned data must not leak into real runs, and --raw is for seeing exactly
+// what the server returns now.
+func cachedGET(apiClient *client.APIClient, endpoint string, ttl time.Duration, refresh bool, result interface{}) error {
+	_, err := cachedGETAge(apiClient, endpoint, ttl, refresh, result)
+	return err
+}
+
+// cachedGETAge is cachedGET for callers that show the result to people: it
+// also returns how old a cached response is, or 0 when it was just fetched,
+// so output that may be stale can say so.
+func cachedGETAge(apiClient *client.APIClient, endpoint string, ttl time.Duration, refresh bool, result interface{}) (time.Duration, error) {
+	if config.MockAPIsEnabled() || rawOutput {
+		return 0, apiClient.GET(endpoint, result)
+	}
+
+	scope := apiClient.Fingerprint()
+	if !refresh && !noCache {
+		var entry listCacheEntry
+		if ok, err := listCacheStore.Get(scope, endpoint, &entry); ok && err == nil &&
+			time.Now().Before(entry.Expires) && json.Unmarshal(entry.Body, result) == nil {
+			age := time.Since(entry.FetchedAt)
+			if IsVerbose() {
+				fmt.Fprintf(os.Stderr, "Using cached %s (fetched %s ago)\n", endpoint, age.Round(time.Second))
+			}
+			return age, nil
+		}
+	}
+
+	body, header, err := apiClient.GETWithHeaders(endpoint)
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Caching is best effort; a read-only home directory shouldn't break anything
+	now := time.Now()
+	if lifetime := cacheLifetime(header, ttl); lifetime > 0 {
+		listCacheStore.Set(scope, endpoint, listCacheEntry{
+			FetchedAt: now,
+			Expires:   now.Add(lifetime),
+			Body:      body,
+		})
+	} else {
+		listCacheStore.Delete(scope, endpoint)
+	}
+	return 0, nil
+}
+
+// cacheLifetime is how long a response may be reused: no-store and no-cache
+// forbid it, max-age overrides ttl, and anything else keeps ttl
+func cacheLifetime(header http.Header, ttl time.Duration) time.Duration {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			return 0
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+				ttl = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return ttl
+}
+
+// invalidateListCache drops the cached responses for endpoints after a
+// command changed what they list
+func invalidateListCache(apiClient *client.APIClient, endpoints ...string) {
+	scope := apiClient.Fingerprint()
+	listCacheStore.Update(func(tx *state.Tx) error {
+		for _, endpoint := range endpoints {
+			tx.Delete(scope, endpoint)
+		}
+		return nil
+	})
+}
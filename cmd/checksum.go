@@ -0,0 +1,185 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// checksumBatchSize caps how many paths are sent per hashes request
+const checksumBatchSize = 500
+
+// Checksum comparison results
+const (
+	checksumUnchanged = "unchanged"
+	checksumModified  = "modified"
+	checksumAdded     = "added" // exists locally but not remotely
+)
+
+// FileHashesRequest asks for the hashes of several remote files at once
+type FileHashesRequest struct {
+	Paths []string `json:"paths"`
+}
+
+// FileHashesResponse lists the remote files that exist; missing paths are
+// left out
+type FileHashesResponse struct {
+	Files []FileHashResponse `json:"files"`
+}
+
+// ChecksumEntry compares one local file with its remote counterpart
+type ChecksumEntry struct {
+	LocalPath    string `json:"local_path"`
+	RemotePath   string `json:"remote_path"`
+	Status       string `json:"status"`
+	LocalSHA256  string `json:"local_sha256"`
+	RemoteSHA256 string `json:"remote_sha256,omitempty"`
+}
+
+// ChecksumReport is the result of 'files upload --checksum-only'
+type ChecksumReport struct {
+	Files     []ChecksumEntry `json:"files"`
+	Differing int             `json:"differing"`
+}
+
+// compareChecksums hashes localPath (a file, or every file under a
+// directory) and compares the hashes with the remote files under remotePath
+// without transferring any content.
+func compareChecksums(apiClient *client.APIClient, projectID, localPath, remotePath string) (*ChecksumReport, error) {
+	var entries []ChecksumEntry
+	add := func(path, remote string) error {
+		entry, err := hashLocalFile(path, remote)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	}
+
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("local file not found: %w", err)
+	}
+	if !info.IsDir() {
+		if err := add(localPath, remotePath); err != nil {
+			return nil, err
+		}
+	} else {
+		// Mirror the remote paths uploadDirectory would use
+		err := filepath.Walk(localPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			relPath, err := filepath.Rel(localPath, path)
+			if err != nil {
+				return err
+			}
+			remote := strings.ReplaceAll(filepath.Join(remotePath, relPath), "\\", "/")
+			return add(path, remote)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return compareEntries(apiClient, projectID, entries)
+}
+
+// hashLocalFile starts a checksum entry for a local file
+func hashLocalFile(localPath, remotePath string) (ChecksumEntry, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return ChecksumEntry{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer file.Close()
+
+	sum, err := hashReader(file)
+	if err != nil {
+		return ChecksumEntry{}, fmt.Errorf("failed to hash %s: %w", localPath, err)
+	}
+	return ChecksumEntry{LocalPath: localPath, RemotePath: remotePath, LocalSHA256: sum}, nil
+}
+
+// compareEntries fetches the remote hashes for already-hashed local files
+// and fills in each entry's status
+func compareEntries(apiClient *client.APIClient, projectID string, entries []ChecksumEntry) (*ChecksumReport, error) {
+	remoteHashes := make(map[string]string, len(entries))
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/hashes", projectID)
+	for start := 0; start < len(entries); start += checksumBatchSize {
+		end := min(start+checksumBatchSize, len(entries))
+		request := FileHashesRequest{Paths: make([]string, 0, end-start)}
+		for _, entry := range entries[start:end] {
+			request.Paths = append(request.Paths, entry.RemotePath)
+		}
+
+		var response FileHashesResponse
+		if err := apiClient.POST(endpoint, request, &response); err != nil {
+			return nil, fmt.Errorf("failed to get remote hashes: %w", err)
+		}
+		for _, file := range response.Files {
+			remoteHashes[file.Path] = file.SHA256
+		}
+	}
+
+	report := &ChecksumReport{Files: entries}
+	for i := range report.Files {
+		entry := &report.Files[i]
+		remote, ok := remoteHashes[entry.RemotePath]
+		entry.RemoteSHA256 = remote
+		switch {
+		case !ok:
+			entry.Status = checksumAdded
+		case remote == entry.LocalSHA256:
+			entry.Status = checksumUnchanged
+		default:
+			entry.Status = checksumModified
+		}
+		if entry.Status != checksumUnchanged {
+			report.Differing++
+		}
+	}
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].RemotePath < report.Files[j].RemotePath
+	})
+	return report, nil
+}
+
+// printChecksumReport lists the files that differ
+func printChecksumReport(report *ChecksumReport) {
+	for _, entry := range report.Files {
+		switch entry.Status {
+		case checksumModified:
+			fmt.Printf("%s %s\n", ui.Yellow("%-9s", "MODIFIED"), ui.Cyan(entry.RemotePath))
+		case checksumAdded:
+			fmt.Printf("%s %s\n", ui.Green("%-9s", "NEW"), ui.Cyan(entry.RemotePath))
+		}
+	}
+
+	if report.Differing == 0 {
+		fmt.Printf("%s All %d file(s) match the workspace\n", ui.Green("✅"), len(report.Files))
+		return
+	}
+	fmt.Printf("\n%s %d of %d file(s) differ from the workspace\n",
+		ui.Yellow("📊"), report.Differing, len(report.Files))
+}
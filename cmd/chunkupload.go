@@ -0,0 +1,261 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+)
+
+const (
+	// chunkedUploadThreshold is the file size above which uploads are chunked
+	chunkedUploadThreshold = 8 * 1024 * 1024
+	// uploadChunkSize is the size of each uploaded chunk
+	uploadChunkSize = 4 * 1024 * 1024
+	// chunkRetries is how many times a single chunk is retried before giving up
+	chunkRetries = 3
+)
+
+// UploadSessionRequest opens a chunked upload session
+type UploadSessionRequest struct {
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+	ChunkSize int    `json:"chunk_size"`
+	Overwrite bool   `json:"overwrite"`
+}
+
+// UploadSessionResponse describes a chunked upload session on the server
+type UploadSessionResponse struct {
+	UploadID string `json:"upload_id"`
+	Offset   int64  `json:"offset"` // bytes acknowledged so far
+}
+
+// UploadChunkRequest sends one chunk of a chunked upload
+type UploadChunkRequest struct {
+	UploadID string `json:"upload_id"`
+	Path     string `json:"path"`
+	Offset   int64  `json:"offset"`
+	Content  string `json:"content"` // base64 encoded
+	Final    bool   `json:"final"`
+}
+
+// UploadChunkResponse acknowledges a chunk
+type UploadChunkResponse struct {
+	Offset int64 `json:"offset"`
+}
+
+// uploadSession is the locally persisted state of an in-progress chunked
+// upload, kept in ~/.fleeks/uploads so an interrupted upload can resume.
+type uploadSession struct {
+	UploadID   string    `json:"upload_id"`
+	ProjectID  string    `json:"project_id"`
+	LocalPath  string    `json:"local_path"`
+	RemotePath string    `json:"remote_path"`
+	Size       int64     `json:"size"`
+	ModTime    time.Time `json:"mod_time"`
+	SHA256     string    `json:"sha256"`
+	Offset     int64     `json:"offset"`
+}
+
+// uploadSessionPath returns where the session for this transfer is stored
+func uploadSessionPath(projectID, localPath, remotePath string) string {
+	key := sha256.Sum256([]byte(projectID + "\x00" + localPath + "\x00" + remotePath))
+	return filepath.Join(config.GetStateDir(), "uploads", hex.EncodeToString(key[:8])+".json")
+}
+
+func loadUploadSession(path string) *uploadSession {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var session uploadSession
+	if json.Unmarshal(content, &session) != nil {
+		return nil
+	}
+	return &session
+}
+
+func (s *uploadSession) save(path string) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, content, 0644)
+}
+
+// uploadChunked uploads a large file in chunks, resuming a previous session
+// for the same file when one exists and the file hasn't changed since.
+// progress, if non-nil, receives a short status line after each chunk.
+func uploadChunked(apiClient *client.APIClient, projectID, localPath, remotePath string, overwrite bool, progress func(string)) error {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		absPath = localPath
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	sessionPath := uploadSessionPath(projectID, absPath, remotePath)
+	session := loadUploadSession(sessionPath)
+
+	// A changed file invalidates any earlier session
+	if session != nil && (session.Size != info.Size() || !session.ModTime.Equal(info.ModTime())) {
+		session = nil
+	}
+
+	if session != nil {
+		// The server's offset is authoritative; the session may have expired
+		var remote UploadSessionResponse
+		endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/upload/session/%s", projectID, session.UploadID)
+		if err := apiClient.GET(endpoint, &remote); err != nil {
+			var apiErr *client.ErrorResponse
+			if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+				return fmt.Errorf("failed to resume upload: %w", err)
+			}
+			session = nil
+		} else {
+			session.Offset = remote.Offset
+			if progress != nil {
+				progress(fmt.Sprintf("Resuming upload at %s of %s",
+					formatFileSize(session.Offset), formatFileSize(session.Size)))
+			}
+		}
+	}
+
+	if session == nil {
+		sum, err := hashReader(file)
+		if err != nil {
+			return fmt.Errorf("failed to hash file: %w", err)
+		}
+
+		request := UploadSessionRequest{
+			Path:      remotePath,
+			Size:      info.Size(),
+			SHA256:    sum,
+			ChunkSize: uploadChunkSize,
+			Overwrite: overwrite,
+		}
+		var response UploadSessionResponse
+		endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/upload/session", projectID)
+		if err := apiClient.POST(endpoint, request, &response); err != nil {
+			return fmt.Errorf("failed to start upload session: %w", err)
+		}
+
+		session = &uploadSession{
+			UploadID:   response.UploadID,
+			ProjectID:  projectID,
+			LocalPath:  absPath,
+			RemotePath: remotePath,
+			Size:       info.Size(),
+			ModTime:    info.ModTime(),
+			SHA256:     sum,
+			Offset:     response.Offset,
+		}
+	}
+
+	if err := session.save(sessionPath); err != nil && IsVerbose() {
+		fmt.Fprintf(os.Stderr, "could not save upload session: %v\n", err)
+	}
+
+	totalChunks := (session.Size + uploadChunkSize - 1) / uploadChunkSize
+	buffer := make([]byte, uploadChunkSize)
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/upload/chunk", projectID)
+
+	for session.Offset < session.Size {
+		n, err := file.ReadAt(buffer, session.Offset)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+
+		request := UploadChunkRequest{
+			UploadID: session.UploadID,
+			Path:     remotePath,
+			Offset:   session.Offset,
+			Content:  base64.StdEncoding.EncodeToString(buffer[:n]),
+			Final:    session.Offset+int64(n) >= session.Size,
+		}
+
+		var response UploadChunkResponse
+		var chunkErr error
+		for attempt := 0; attempt <= chunkRetries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(time.Duration(attempt) * time.Second)
+			}
+			if chunkErr = apiClient.POST(endpoint, request, &response); chunkErr == nil {
+				break
+			}
+		}
+		if chunkErr != nil {
+			// Keep the session so the next run picks up from here
+			return fmt.Errorf("chunk at offset %d failed (run the upload again to resume): %w", session.Offset, chunkErr)
+		}
+
+		// Trust the server's acknowledgement, falling back to what we sent
+		next := response.Offset
+		if next <= session.Offset {
+			next = session.Offset + int64(n)
+		}
+		session.Offset = next
+		session.save(sessionPath)
+
+		if progress != nil {
+			chunk := (session.Offset + uploadChunkSize - 1) / uploadChunkSize
+			progress(fmt.Sprintf("Uploading %s: chunk %d/%d (%s of %s)",
+				filepath.Base(localPath), chunk, totalChunks,
+				formatFileSize(session.Offset), formatFileSize(session.Size)))
+		}
+	}
+
+	os.Remove(sessionPath)
+	return nil
+}
+
+// hashReader returns the SHA-256 of everything readable from r, then rewinds it
+func hashReader(r io.ReadSeeker) (string, error) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
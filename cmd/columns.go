@@ -0,0 +1,208 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// columnsFlagUsage is the help text for --columns on list commands
+const columnsFlagUsage = "Comma-separated columns to show, in order (table headers or JSON field names, e.g. name,size)"
+
+// tableColumn is a column picked with --columns: one of the table's own
+// columns, or a field of the listed records that the table doesn't show
+type tableColumn struct {
+	header string
+	index  int   // the table's column, or -1 for a record field
+	field  []int // record field index, when index is -1
+}
+
+// columnKey normalizes a column name so that "Agent ID", "agent-id" and
+// "agent_id" all name the same column
+func columnKey(name string) string {
+	var key strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			key.WriteRune(r)
+		}
+	}
+	return key.String()
+}
+
+// columnName is how a header is listed among the available columns
+func columnName(header string) string {
+	return strings.ReplaceAll(strings.ToLower(header), " ", "_")
+}
+
+// recordFields maps the JSON field names of a struct type to their index
+func recordFields(recordType reflect.Type) ([]string, map[string][]int) {
+	var names []string
+	fields := make(map[string][]int)
+	for recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	if recordType.Kind() != reflect.Struct {
+		return names, fields
+	}
+	for _, field := range reflect.VisibleFields(recordType) {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "-" || field.Anonymous {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := fields[columnKey(name)]; !ok {
+			names = append(names, name)
+			fields[columnKey(name)] = field.Index
+		}
+	}
+	return names, fields
+}
+
+// SelectColumns limits and orders the table to the columns named by the
+// command's --columns flag. A name matches one of the table's headers or a
+// JSON field of record, the type being listed, so fields the table leaves
+// out can be shown too. Rows for record fields must be added with
+// AppendRecord.
+func (t *listTable) SelectColumns(cmd *cobra.Command, record interface{}) error {
+	names, _ := cmd.Flags().GetStringSlice("columns")
+	if len(names) == 0 {
+		return nil
+	}
+
+	fieldNames, fields := recordFields(reflect.TypeOf(record))
+	available := make([]string, 0, len(t.headers)+len(fieldNames))
+	headerKeys := make(map[string]int)
+	for i, header := range t.headers {
+		headerKeys[columnKey(header)] = i
+		available = append(available, columnName(header))
+	}
+	for _, name := range fieldNames {
+		if _, ok := headerKeys[columnKey(name)]; !ok {
+			available = append(available, name)
+		}
+	}
+
+	var columns []tableColumn
+	for _, name := range names {
+		key := columnKey(name)
+		if i, ok := headerKeys[key]; ok {
+			columns = append(columns, tableColumn{header: t.headers[i], index: i})
+		} else if index, ok := fields[key]; ok {
+			columns = append(columns, tableColumn{header: strings.TrimSpace(name), index: -1, field: index})
+		} else {
+			return fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(available, ", "))
+		}
+	}
+	t.columns = columns
+	return nil
+}
+
+// AppendRecord adds a row along with the record it was built from
+func (t *listTable) AppendRecord(row []string, record interface{}) {
+	t.Append(row)
+	for len(t.records) < len(t.rows)-1 {
+		t.records = append(t.records, nil)
+	}
+	t.records = append(t.records, record)
+}
+
+// applyColumns rearranges the table into the columns chosen with
+// SelectColumns, just before rendering
+func (t *listTable) applyColumns() {
+	if t.columns == nil {
+		return
+	}
+
+	headers := make([]string, len(t.columns))
+	var headerColors []tablewriter.Colors
+	if len(t.headerColors) > 0 {
+		headerColors = make([]tablewriter.Colors, len(t.columns))
+	}
+	for i, column := range t.columns {
+		headers[i] = column.header
+		if headerColors != nil && column.index >= 0 && column.index < len(t.headerColors) {
+			headerColors[i] = t.headerColors[column.index]
+		}
+	}
+
+	rows := make([][]string, len(t.rows))
+	for r, row := range t.rows {
+		var record interface{}
+		if r < len(t.records) {
+			record = t.records[r]
+		}
+		rows[r] = t.selectCells(row, record)
+	}
+	if t.footer != nil {
+		t.footer = t.selectCells(t.footer, nil)
+	}
+
+	t.headers, t.headerColors, t.rows, t.columns = headers, headerColors, rows, nil
+}
+
+func (t *listTable) selectCells(row []string, record interface{}) []string {
+	cells := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		switch {
+		case column.index >= 0 && column.index < len(row):
+			cells[i] = row[column.index]
+		case column.index < 0 && record != nil:
+			cells[i] = formatRecordField(record, column.field)
+		}
+	}
+	return cells
+}
+
+// formatRecordField renders a record field for a table cell
+func formatRecordField(record interface{}, index []int) string {
+	value := reflect.ValueOf(record)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	field, err := value.FieldByIndexErr(index)
+	if err != nil {
+		return ""
+	}
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "-"
+		}
+		field = field.Elem()
+	}
+
+	switch v := field.Interface().(type) {
+	case time.Time:
+		if v.IsZero() {
+			return "-"
+		}
+		return v.Format("2006-01-02 15:04")
+	case []string:
+		return strings.Join(v, ", ")
+	}
+	return fmt.Sprint(field.Interface())
+}
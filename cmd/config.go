@@ -0,0 +1,505 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "⚙️  Configuration management",
+	Long: `
+⚙️  Configuration Management
+
+Inspect and validate the Fleeks CLI configuration stored in
+~/.fleeksconfig.yaml together with environment-specific settings.
+
+Examples:
+  # Check the configuration for mistakes
+  fleeks config validate
+
+  # Change a setting; values are checked against the key's type
+  fleeks config set api.timeout 45s
+
+  # List every setting the CLI reads, with its type and current value
+  fleeks config keys
+
+  # Show every effective setting and where it came from
+  fleeks config view --effective
+
+  # Share team settings, then apply them on another machine
+  fleeks config export fleeks.team.yaml
+  fleeks config import fleeks.team.yaml
+`,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration",
+	Long: `Validate the configuration file and environment settings.
+
+Checks:
+- The config file is well-formed YAML
+- Durations parse (e.g. api.timeout, streaming.reconnect_delay)
+- URLs are well-formed and use the expected scheme
+- The selected environment is valid
+- The environment file loads
+
+Exits with a non-zero status if any problem is found.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return validateConfig(cmd)
+	},
+}
+
+var configViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Show configuration values",
+	Long: `Show the settings stored in the config file.
+
+With --effective, show the fully merged configuration instead: built-in
+defaults, the config file, FLEEKS_* environment variables and the
+.env.{environment} file, each value annotated with the layer it came from.
+These are the values the rest of the CLI actually reads.
+
+Secrets such as API keys and tokens are masked unless --show-secrets is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return viewConfig(cmd)
+	},
+}
+
+var configExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export shareable settings to a YAML file",
+	Long: `Write the settings from the config file to a YAML file that can be shared
+with a team, such as the API base URL, environment, agent templates and
+workspace defaults.
+
+Secrets (API keys, tokens and anything else that looks like a credential) are
+never exported. Environment references such as ${FLEEKS_API_URL} are kept
+as written. Without a file, or with "-", the settings are written to stdout.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := "-"
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return exportConfig(target)
+	},
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Merge shared settings into the local config",
+	Long: `Merge a settings file written by 'fleeks config export' into the local
+config. Settings in the file replace the local values for the same keys;
+every other local setting, including your credentials, is kept.
+
+Secrets in the file are ignored, so an imported file can never replace your
+API key. Use --dry-run to see what would change first.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importConfig(cmd, args[0])
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a configuration value",
+	Long: `Write a value to the config file.
+
+The value is checked against the key's type before anything is written:
+durations such as api.timeout must parse (e.g. 30s, 1m), booleans must be
+true or false, URLs must use the expected scheme and enums must be one of
+their listed values. Lists are given comma-separated.
+
+Run 'fleeks config keys' to see every known key and its type. Unknown keys
+are rejected unless --force is given. Credentials are set with
+'fleeks auth login' instead.
+
+Keys under defaults.{command} set the default of a command's flag, used
+whenever the flag isn't given on the command line. The command and flag
+must exist and the value must suit the flag.
+
+Examples:
+  fleeks config set api.timeout 45s
+  fleeks config set api.tls_verify false
+  fleeks config set workspace.ignore_patterns "node_modules,*.log"
+  fleeks config set defaults.container.logs.tail 200`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setConfigValue(cmd, args[0], args[1])
+	},
+}
+
+var configKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "List known configuration keys",
+	Long: `List every configuration key the CLI reads, with its type, the values
+it accepts and its current effective value and source.
+
+Secrets are never listed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listConfigKeys()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+
+	// Add subcommands
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configViewCmd)
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configKeysCmd)
+
+	// View command flags
+	configViewCmd.Flags().Bool("effective", false, "Show merged values from all sources, annotated with their source")
+	configViewCmd.Flags().Bool("show-secrets", false, "Show secret values instead of masking them")
+
+	// Import command flags
+	configImportCmd.Flags().Bool("dry-run", false, "Show the changes without writing them")
+
+	// Set command flags
+	configSetCmd.Flags().Bool("force", false, "Write a key the CLI doesn't know, as a string")
+}
+
+func setConfigValue(cmd *cobra.Command, key, raw string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	key = strings.ToLower(strings.TrimSpace(key))
+
+	if config.IsSecretKey(key) {
+		return fmt.Errorf("%s is a credential; use 'fleeks auth login' to set it", key)
+	}
+	if strings.HasPrefix(key, flagDefaultsSection+".") {
+		if err := validateFlagDefault(key, raw); err != nil && !force {
+			return err
+		}
+	} else if _, known := config.LookupKey(key); !known && !force {
+		return fmt.Errorf("unknown config key %q (run 'fleeks config keys' to list them, or use --force to set it anyway)", key)
+	}
+
+	if _, err := config.Load(); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	old := viper.Get(key)
+	value, err := config.SetValue(key, raw)
+	if err != nil {
+		return err
+	}
+
+	if isJSONOutput() {
+		return printJSON(config.SettingChange{Key: key, Old: old, New: value})
+	}
+
+	fmt.Printf("%s Set %s = %v\n", ui.Green("✅"), ui.Cyan(key), formatConfigValue(value))
+	switch config.SettingSource(key) {
+	case config.SourceEnvVar:
+		fmt.Printf("%s %s is set and overrides the config file\n", ui.Yellow("⚠️"), config.EnvVarName(key))
+	case config.SourceEnvFile:
+		fmt.Printf("%s The .env.%s file sets %s and overrides the config file\n", ui.Yellow("⚠️"), GetEnvironment(), key)
+	}
+	return nil
+}
+
+// configKeyEntry is a known key together with its current value
+type configKeyEntry struct {
+	config.KeyInfo
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+func listConfigKeys() error {
+	if _, err := config.Load(); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	// Missing .env files are normal outside development; show what we can
+	if _, err := config.LoadEnvironment(); err != nil && IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Environment file not loaded: %v\n", err)
+	}
+
+	var entries []configKeyEntry
+	for _, info := range config.KnownKeys() {
+		entries = append(entries, configKeyEntry{
+			KeyInfo: info,
+			Value:   viper.Get(info.Key),
+			Source:  config.SettingSource(info.Key),
+		})
+	}
+
+	if isJSONOutput() {
+		return printJSON(entries)
+	}
+
+	table := newListTable("Key", "Type", "Value", "Source")
+	table.SetHeaderColor(
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiMagenta),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiGreen),
+	)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, entry := range entries {
+		keyType := string(entry.Type)
+		switch {
+		case len(entry.Values) > 0:
+			keyType += " (" + strings.Join(entry.Values, "|") + ")"
+		case len(entry.Schemes) > 0:
+			keyType += " (" + strings.Join(entry.Schemes, "|") + ")"
+		}
+		table.Append([]string{entry.Key, keyType, formatConfigValue(entry.Value), entry.Source})
+	}
+
+	table.Render()
+	return nil
+}
+
+// formatConfigValue renders a setting for display, joining lists
+func formatConfigValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case []string:
+		return strings.Join(v, ",")
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+func exportConfig(target string) error {
+	if _, err := config.Load(); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	settings, err := config.ShareableSettings()
+	if err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	content = append([]byte("# Shared Fleeks CLI settings; apply with: fleeks config import <file>\n"), content...)
+
+	if target == "-" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(target, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	fmt.Printf("%s Exported settings to %s (secrets omitted)\n", ui.Green("✅"), ui.Cyan(target))
+	return nil
+}
+
+func importConfig(cmd *cobra.Command, source string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if _, err := config.Load(); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	settings, skipped, err := config.ReadSettingsFile(source)
+	if err != nil {
+		return err
+	}
+	changes, err := config.ImportSettings(settings, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to import settings: %w", err)
+	}
+
+	if isJSONOutput() {
+		return printJSON(map[string]interface{}{
+			"changes": changes,
+			"skipped": skipped,
+			"dry_run": dryRun,
+		})
+	}
+
+	for _, key := range skipped {
+		fmt.Printf("%s Ignoring secret %s from %s\n", ui.Yellow("⚠️"), key, source)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("%s Config already matches %s\n", ui.Green("✅"), source)
+		return nil
+	}
+
+	for _, change := range changes {
+		old := fmt.Sprintf("%v", change.Old)
+		if change.Old == nil {
+			old = "(unset)"
+		}
+		fmt.Printf("  %s %s → %v\n", ui.Yellow(change.Key+":"), old, change.New)
+	}
+	if dryRun {
+		fmt.Printf("\nWould change %d setting(s) (dry run, nothing written)\n", len(changes))
+		return nil
+	}
+	fmt.Printf("\n%s Imported %d setting(s) from %s\n", ui.Green("✅"), len(changes), ui.Cyan(source))
+	return nil
+}
+
+func viewConfig(cmd *cobra.Command) error {
+	effective, _ := cmd.Flags().GetBool("effective")
+	showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+
+	if _, err := config.Load(); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if effective {
+		// Missing .env files are normal outside development; show what we can
+		if _, err := config.LoadEnvironment(); err != nil && IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Environment file not loaded: %v\n", err)
+		}
+	}
+
+	var settings []config.Setting
+	for _, setting := range config.EffectiveSettings() {
+		if !effective && setting.Source != config.SourceConfig {
+			continue
+		}
+		if setting.Secret && !showSecrets {
+			setting.Value = maskSecret(setting.Value)
+		}
+		settings = append(settings, setting)
+	}
+
+	if isJSONOutput() {
+		return printJSON(settings)
+	}
+
+	configPath := viper.ConfigFileUsed()
+	if configPath == "" {
+		configPath = config.GetConfigPath()
+	}
+	title := "⚙️  Configuration:"
+	if effective {
+		title = "⚙️  Effective Configuration:"
+	}
+	fmt.Printf("\n%s %s\n\n", ui.New(color.Bold).Sprint(title), ui.Cyan(configPath))
+
+	if len(settings) == 0 {
+		fmt.Println("No settings in the config file. Use --effective to include defaults.")
+		return nil
+	}
+
+	table := newListTable("Setting", "Value", "Source")
+	table.SetHeaderColor(
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiGreen),
+	)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, setting := range settings {
+		table.Append([]string{setting.Key, fmt.Sprintf("%v", setting.Value), setting.Source})
+	}
+
+	table.Render()
+	return nil
+}
+
+// maskSecret hides a secret value, keeping only whether it is set
+func maskSecret(value interface{}) interface{} {
+	if s, ok := value.(string); ok && s == "" {
+		return ""
+	}
+	if value == nil {
+		return nil
+	}
+	return "********"
+}
+
+func validateConfig(cmd *cobra.Command) error {
+	configPath := viper.ConfigFileUsed()
+	if configPath == "" {
+		configPath = config.GetConfigPath()
+	}
+
+	fmt.Printf("\n%s %s\n\n",
+		ui.New(color.Bold).Sprint("🔍 Validating configuration:"),
+		ui.Cyan(configPath))
+
+	var problems []config.ValidationError
+
+	// A malformed file makes every other check meaningless
+	if err := config.ValidateFile(configPath); err != nil {
+		problems = append(problems, config.ValidationError{Key: "config file", Message: err.Error()})
+		return reportConfigProblems(problems)
+	}
+
+	if _, err := config.Load(); err != nil {
+		problems = append(problems, config.ValidationError{Key: "config file", Message: err.Error()})
+		return reportConfigProblems(problems)
+	}
+
+	env := config.Environment(GetEnvironment())
+	if env != "" && !env.IsValid() {
+		problems = append(problems, config.ValidationError{
+			Key:     "environment",
+			Message: fmt.Sprintf("unknown environment %q (expected development, staging or production)", env),
+		})
+	} else if _, err := config.LoadEnvironment(); err != nil {
+		problems = append(problems, config.ValidationError{Key: "environment", Message: err.Error()})
+	}
+
+	problems = append(problems, config.Validate()...)
+	return reportConfigProblems(problems)
+}
+
+func reportConfigProblems(problems []config.ValidationError) error {
+	if len(problems) == 0 {
+		fmt.Printf("%s Configuration is valid\n", ui.Green("✅"))
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("%s %s %s\n",
+			ui.Red("❌"),
+			ui.Yellow(problem.Key+":"),
+			problem.Message)
+	}
+	fmt.Println()
+
+	return fmt.Errorf("configuration has %d problem(s)", len(problems))
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
@@ -27,10 +29,12 @@ import (
 
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
 	"github.com/fleeks-inc/fleeks-cli/internal/client"
 	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 // containerCmd represents the container command
@@ -64,6 +68,9 @@ Examples:
   # Get container information
   fleeks container info my-api
   
+  # Dump the raw container JSON for debugging
+  fleeks container inspect my-api
+
   # Monitor real-time stats
   fleeks container stats my-api --watch
   
@@ -72,6 +79,9 @@ Examples:
   
   # Stream container logs
   fleeks container logs my-api --follow --tail 100
+
+  # Stream container logs as newline-delimited JSON
+  fleeks container logs my-api --follow --output ndjson
   
   # Scale container resources
   fleeks container scale my-api --cpu 2 --memory 4G
@@ -93,6 +103,17 @@ var containerInfoCmd = &cobra.Command{
 	},
 }
 
+var containerInspectCmd = &cobra.Command{
+	Use:   "inspect [project-id]",
+	Short: "Print the raw container JSON",
+	Long: `Print the complete container response from the server as pretty-printed
+JSON, including fields that 'container info' doesn't display.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return inspectContainer(args[0])
+	},
+}
+
 var containerStatsCmd = &cobra.Command{
 	Use:   "stats [project-id]",
 	Short: "Show container resource statistics",
@@ -103,7 +124,10 @@ Shows:
 - Memory usage and limits  
 - Disk I/O and usage
 - Network I/O
-- Process count`,
+- Process count
+
+Use --all for a combined table of every container in a multi-container
+workspace, with totals; combine with --watch for a live overview.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return getContainerStats(args[0], cmd)
@@ -119,7 +143,36 @@ Supports:
 - Real-time log streaming
 - Historical log retrieval
 - Log filtering and search
-- Multiple output formats`,
+- Multiple output formats
+- Writing logs to a local file with size-based rotation
+
+Examples:
+  # Capture a long session to disk, rotating at 100MB and keeping 5 old files
+  fleeks container logs my-project --follow --out app.log --rotate-size 100M --rotate-keep 5
+
+  # Write to the file only
+  fleeks container logs my-project --follow --out app.log --out-only
+
+  # Check back later and see only what is new
+  fleeks container logs my-project --since-last
+
+  # After a crash and restart, see how the previous instance ended
+  fleeks container logs my-project --previous --tail 200
+
+--since-last remembers when you last looked (in ~/.fleeks/state) and
+shows only logs written after that; the first run shows the usual --tail.
+
+--previous shows the logs of the container instance that ran before the
+last restart, like kubectl logs --previous. It combines with --tail and
+--since, which is how to debug a crash loop whose current instance has only
+logged its startup.
+
+When followed log lines carry a level, stream or source, they are shown with
+a timestamp and the level colorized (errors red, warnings yellow); plain lines
+are printed as-is. With --output json each line is written as a JSON object
+for log processors:
+
+  {"timestamp":"...","level":"error","message":"boom","stream":"stderr"}`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return getContainerLogs(args[0], cmd)
@@ -135,11 +188,24 @@ Features:
 - Interactive and non-interactive execution
 - Environment variable support
 - Working directory specification
-- Output streaming`,
-	Args: cobra.MinimumNArgs(2),
+- Output streaming
+
+Use --command-file to read the exact command from a file (or "-" for stdin),
+preserving quotes and newlines in multiline scripts.
+
+By default fleeks exits with the command's exit code. Wrappers that want to
+capture the output and the code together can pass --no-exit: fleeks then
+exits 0 and reports the code instead, on stderr or as exit_code in the
+--output json result:
+
+  fleeks container exec my-project "npm test" --no-exit --output json`,
+	Args: commandArgs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		projectID := args[0]
-		command := strings.Join(args[1:], " ")
+		command, err := resolveCommandArg(cmd, args[1:])
+		if err != nil {
+			return err
+		}
 		return execInContainer(projectID, command, cmd)
 	},
 }
@@ -149,7 +215,17 @@ var containerScaleCmd = &cobra.Command{
 	Short: "Scale container resources",
 	Long: `Scale container CPU and memory resources.
 
-This allows dynamic resource allocation based on workload requirements.`,
+This allows dynamic resource allocation based on workload requirements.
+
+With --auto, register an autoscale policy instead of fixed limits: the
+platform moves CPU and memory between the --min and --max bounds to keep
+utilization near --target-utilization. Check it with
+'fleeks container autoscale status'.
+
+Examples:
+  fleeks container scale my-project --cpu 2 --memory 4G
+  fleeks container scale my-project --auto --min-cpu 0.5 --max-cpu 4 --min-memory 512M --max-memory 8G
+  fleeks container scale my-project --auto --min-cpu 1 --max-cpu 2 --target-utilization 60`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return scaleContainer(args[0], cmd)
@@ -159,6 +235,7 @@ This allows dynamic resource allocation based on workload requirements.`,
 func init() {
 	// Add subcommands
 	containerCmd.AddCommand(containerInfoCmd)
+	containerCmd.AddCommand(containerInspectCmd)
 	containerCmd.AddCommand(containerStatsCmd)
 	containerCmd.AddCommand(containerLogsCmd)
 	containerCmd.AddCommand(containerExecCmd)
@@ -167,22 +244,60 @@ func init() {
 	// Stats command flags
 	containerStatsCmd.Flags().BoolP("watch", "w", false, "Watch stats in real-time")
 	containerStatsCmd.Flags().IntP("interval", "i", 5, "Update interval in seconds")
+	markFlagRequires(containerStatsCmd, "interval", "watch")
+	containerStatsCmd.Flags().BoolP("all", "a", false, "Show stats for every container in the project")
 
 	// Logs command flags
 	containerLogsCmd.Flags().BoolP("follow", "f", false, "Follow log output")
 	containerLogsCmd.Flags().IntP("tail", "t", 50, "Number of lines to show from the end")
 	containerLogsCmd.Flags().StringP("since", "s", "", "Show logs since timestamp (e.g. 2023-01-01T00:00:00Z)")
 	containerLogsCmd.Flags().StringP("filter", "", "", "Filter logs by pattern")
+	containerLogsCmd.Flags().Bool("since-last", false, "Show only logs written since the last --since-last run")
+	containerLogsCmd.Flags().String("out", "", "Also write logs to this local file")
+	containerLogsCmd.Flags().Bool("out-only", false, "Write logs only to the --out file, not stdout")
+	containerLogsCmd.Flags().String("rotate-size", "", "Rotate the --out file when it reaches this size (e.g. 100M)")
+	containerLogsCmd.Flags().Int("rotate-keep", 5, "Number of rotated --out files to keep")
+	containerLogsCmd.Flags().Bool("previous", false, "Show logs from the previous container instance (before the last restart)")
+	containerLogsCmd.MarkFlagsMutuallyExclusive("since-last", "since")
+	containerLogsCmd.MarkFlagsMutuallyExclusive("since-last", "follow")
+	containerLogsCmd.MarkFlagsMutuallyExclusive("previous", "follow")
+	containerLogsCmd.MarkFlagsMutuallyExclusive("previous", "since-last")
+	markFlagRequires(containerLogsCmd, "out-only", "out")
+	markFlagRequires(containerLogsCmd, "rotate-size", "out")
+	markFlagRequires(containerLogsCmd, "rotate-keep", "rotate-size")
 
 	// Exec command flags
 	containerExecCmd.Flags().BoolP("interactive", "i", false, "Interactive mode")
 	containerExecCmd.Flags().BoolP("tty", "t", false, "Allocate a pseudo-TTY")
 	containerExecCmd.Flags().StringP("workdir", "w", "", "Working directory")
-	containerExecCmd.Flags().StringSliceP("env", "e", []string{}, "Environment variables")
+	containerExecCmd.Flags().StringSlice("env", []string{}, "Environment variables (KEY=VALUE)")
+	containerExecCmd.Flags().Bool("no-exit", false, "Report the command's exit code instead of exiting with it")
+	containerExecCmd.Flags().String("command-file", "", "Read the command verbatim from a file (\"-\" for stdin)")
 
 	// Scale command flags
 	containerScaleCmd.Flags().StringP("cpu", "", "", "CPU allocation (e.g. 1, 2, 0.5)")
 	containerScaleCmd.Flags().StringP("memory", "", "", "Memory allocation (e.g. 1G, 512M, 2048M)")
+	containerScaleCmd.Flags().Bool("auto", false, "Register an autoscale policy instead of fixed resources")
+	containerScaleCmd.Flags().String("min-cpu", "", "Lowest CPU allocation autoscaling may set")
+	containerScaleCmd.Flags().String("max-cpu", "", "Highest CPU allocation autoscaling may set")
+	containerScaleCmd.Flags().String("min-memory", "", "Lowest memory allocation autoscaling may set (e.g. 512M)")
+	containerScaleCmd.Flags().String("max-memory", "", "Highest memory allocation autoscaling may set (e.g. 8G)")
+	containerScaleCmd.Flags().Int("target-utilization", defaultAutoscaleTarget, "Utilization percentage autoscaling aims to keep")
+	containerScaleCmd.MarkFlagsMutuallyExclusive("auto", "cpu")
+	containerScaleCmd.MarkFlagsMutuallyExclusive("auto", "memory")
+	for _, flag := range []string{"min-cpu", "max-cpu", "min-memory", "max-memory", "target-utilization"} {
+		markFlagRequires(containerScaleCmd, flag, "auto")
+	}
+
+	// Accept --project in place of the [project-id] argument
+	acceptProjectFlag(
+		containerInfoCmd,
+		containerInspectCmd,
+		containerStatsCmd,
+		containerLogsCmd,
+		containerExecCmd,
+		containerScaleCmd,
+	)
 }
 
 // ContainerInfo represents container information
@@ -268,6 +383,37 @@ type ExecResponse struct {
 	Error    string `json:"error,omitempty"`
 }
 
+// inspectContainer prints the container endpoint's body as-is, so fields the
+// ContainerInfo struct doesn't know about are still visible
+func inspectContainer(projectID string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	body, err := apiClient.GETRaw(fmt.Sprintf("/api/v1/sdk/containers/%s", projectID))
+	if err != nil {
+		return fmt.Errorf("failed to get container info: %w", err)
+	}
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, body, "", "  "); err != nil {
+		// Not JSON; show it untouched rather than hiding it
+		os.Stdout.Write(body)
+		return nil
+	}
+	pretty.WriteByte('\n')
+	_, err = pretty.WriteTo(os.Stdout)
+	return err
+}
+
 func getContainerInfo(projectID string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -291,31 +437,31 @@ func getContainerInfo(projectID string, cmd *cobra.Command) error {
 
 	// Display container information
 	fmt.Printf("\n%s %s\n\n",
-		color.New(color.Bold).Sprint("🐳 Container Information:"),
-		color.CyanString(projectID))
+		ui.New(color.Bold).Sprint("🐳 Container Information:"),
+		ui.Cyan(projectID))
 
-	fmt.Printf("%-15s %s\n", "Container ID:", color.BlueString(container.ContainerID))
-	fmt.Printf("%-15s %s\n", "Project ID:", color.CyanString(container.ProjectID))
+	fmt.Printf("%-15s %s\n", "Container ID:", ui.Blue(container.ContainerID))
+	fmt.Printf("%-15s %s\n", "Project ID:", ui.Cyan(container.ProjectID))
 	fmt.Printf("%-15s %s\n", "Status:", getStatusColor(container.Status))
-	fmt.Printf("%-15s %s\n", "Template:", color.YellowString(container.Template))
+	fmt.Printf("%-15s %s\n", "Template:", ui.Yellow(container.Template))
 	fmt.Printf("%-15s %s\n", "Image:", container.Image)
 	fmt.Printf("%-15s %s\n", "Platform:", container.Platform)
-	fmt.Printf("%-15s %s\n", "Created:", color.MagentaString(container.Created.Format("2006-01-02 15:04:05")))
-	fmt.Printf("%-15s %s\n", "Started:", color.MagentaString(container.Started.Format("2006-01-02 15:04:05")))
+	fmt.Printf("%-15s %s\n", "Created:", ui.Magenta(container.Created.Format("2006-01-02 15:04:05")))
+	fmt.Printf("%-15s %s\n", "Started:", ui.Magenta(container.Started.Format("2006-01-02 15:04:05")))
 
 	// Languages
 	if len(container.Languages) > 0 {
-		fmt.Printf("%-15s %s\n", "Languages:", color.GreenString(strings.Join(container.Languages, ", ")))
+		fmt.Printf("%-15s %s\n", "Languages:", ui.Green(strings.Join(container.Languages, ", ")))
 	}
 
 	// Resources
-	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("📊 Resources:"))
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("📊 Resources:"))
 	fmt.Printf("%-15s %s (limit: %s)\n", "CPU:", container.Resources.CPU, container.Resources.CPULimit)
 	fmt.Printf("%-15s %s (limit: %s)\n", "Memory:", container.Resources.Memory, container.Resources.MemLimit)
 	fmt.Printf("%-15s %s (limit: %s)\n", "Disk:", container.Resources.Disk, container.Resources.DiskLimit)
 
 	// Network
-	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("🌐 Network:"))
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("🌐 Network:"))
 	fmt.Printf("%-15s %s\n", "IP Address:", container.Network.IPAddress)
 	fmt.Printf("%-15s %s\n", "Network:", container.Network.Network)
 	if len(container.Network.Ports) > 0 {
@@ -326,11 +472,11 @@ func getContainerInfo(projectID string, cmd *cobra.Command) error {
 	}
 
 	// Health
-	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("❤️  Health:"))
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("❤️  Health:"))
 	fmt.Printf("%-15s %s\n", "Status:", getHealthColor(container.Health.Status))
-	fmt.Printf("%-15s %s\n", "Last Check:", color.MagentaString(container.Health.LastCheck.Format("2006-01-02 15:04:05")))
+	fmt.Printf("%-15s %s\n", "Last Check:", ui.Magenta(container.Health.LastCheck.Format("2006-01-02 15:04:05")))
 	if container.Health.FailCount > 0 {
-		fmt.Printf("%-15s %s\n", "Fail Count:", color.RedString(fmt.Sprintf("%d", container.Health.FailCount)))
+		fmt.Printf("%-15s %s\n", "Fail Count:", ui.Red(fmt.Sprintf("%d", container.Health.FailCount)))
 	}
 	if container.Health.Description != "" {
 		fmt.Printf("%-15s %s\n", "Description:", container.Health.Description)
@@ -338,7 +484,7 @@ func getContainerInfo(projectID string, cmd *cobra.Command) error {
 
 	// Mounts
 	if len(container.Mounts) > 0 {
-		fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("💾 Mounts:"))
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("💾 Mounts:"))
 		for _, mount := range container.Mounts {
 			access := "rw"
 			if mount.ReadOnly {
@@ -364,26 +510,41 @@ func getContainerStats(projectID string, cmd *cobra.Command) error {
 
 	watch, _ := cmd.Flags().GetBool("watch")
 	interval, _ := cmd.Flags().GetInt("interval")
+	all, _ := cmd.Flags().GetBool("all")
 
 	// Create API client
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
-	if !watch {
-		// One-time stats
+	// show fetches one round of stats and prints it
+	show := func() error {
+		if all {
+			var stats []ContainerStats
+			endpoint := fmt.Sprintf("/api/v1/sdk/containers/%s/stats?all=true", projectID)
+			if err := apiClient.GET(endpoint, &stats); err != nil {
+				return fmt.Errorf("failed to get container stats: %w", err)
+			}
+			displayStatsTable(stats)
+			return nil
+		}
+
 		var stats ContainerStats
 		endpoint := fmt.Sprintf("/api/v1/sdk/containers/%s/stats", projectID)
 		if err := apiClient.GET(endpoint, &stats); err != nil {
 			return fmt.Errorf("failed to get container stats: %w", err)
 		}
-
 		displayStats(stats)
 		return nil
 	}
 
+	if !watch {
+		// One-time stats
+		return show()
+	}
+
 	// Watch mode - real-time stats
 	fmt.Printf("%s Monitoring container %s (Press Ctrl+C to stop)\n\n",
-		color.CyanString("📊"), color.YellowString(projectID))
+		ui.Cyan("📊"), ui.Yellow(projectID))
 
 	// Handle graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -394,7 +555,7 @@ func getContainerStats(projectID string, cmd *cobra.Command) error {
 	go func() {
 		<-c
 		fmt.Printf("\n%s Stopping stats monitoring...\n",
-			color.YellowString("🛑"))
+			ui.Yellow("🛑"))
 		cancel()
 	}()
 
@@ -406,38 +567,82 @@ func getContainerStats(projectID string, cmd *cobra.Command) error {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
-			var stats ContainerStats
-			endpoint := fmt.Sprintf("/api/v1/sdk/containers/%s/stats", projectID)
-			if err := apiClient.GET(endpoint, &stats); err != nil {
-				fmt.Printf("Error getting stats: %v\n", err)
-				continue
-			}
-
 			// Clear screen and display stats
 			fmt.Print("\033[2J\033[H")
 			fmt.Printf("%s Container Stats - %s\n\n",
-				color.New(color.Bold).Sprint("📊"),
-				color.CyanString(projectID))
-			displayStats(stats)
+				ui.New(color.Bold).Sprint("📊"),
+				ui.Cyan(projectID))
+			if err := show(); err != nil {
+				fmt.Printf("Error getting stats: %v\n", err)
+			}
 		}
 	}
 }
 
+// displayStatsTable prints one row per container plus a totals footer,
+// similar to docker stats
+func displayStatsTable(stats []ContainerStats) {
+	if len(stats) == 0 {
+		fmt.Printf("%s No containers found.\n", ui.Yellow("📭"))
+		return
+	}
+
+	table := newListTable("Container", "CPU", "Memory", "Mem %", "Disk R/W", "Net RX/TX", "Procs")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	var total ContainerStats
+	for _, s := range stats {
+		containerID := s.ContainerID
+		if len(containerID) > 12 {
+			containerID = containerID[:12]
+		}
+		table.Append([]string{
+			containerID,
+			fmt.Sprintf("%.1f%%", s.CPU),
+			formatBytes(s.Memory),
+			fmt.Sprintf("%.1f%%", s.MemoryPercent),
+			formatBytes(s.DiskRead) + " / " + formatBytes(s.DiskWrite),
+			formatBytes(s.NetRx) + " / " + formatBytes(s.NetTx),
+			fmt.Sprintf("%d", s.Processes),
+		})
+
+		total.CPU += s.CPU
+		total.Memory += s.Memory
+		total.DiskRead += s.DiskRead
+		total.DiskWrite += s.DiskWrite
+		total.NetRx += s.NetRx
+		total.NetTx += s.NetTx
+		total.Processes += s.Processes
+	}
+
+	table.SetFooter([]string{
+		fmt.Sprintf("Total (%d)", len(stats)),
+		fmt.Sprintf("%.1f%%", total.CPU),
+		formatBytes(total.Memory),
+		"",
+		formatBytes(total.DiskRead) + " / " + formatBytes(total.DiskWrite),
+		formatBytes(total.NetRx) + " / " + formatBytes(total.NetTx),
+		fmt.Sprintf("%d", total.Processes),
+	})
+
+	table.Render()
+}
+
 func displayStats(stats ContainerStats) {
 	timestamp := stats.Timestamp.Format("15:04:05")
 
-	fmt.Printf("%-15s %s\n", "Timestamp:", color.MagentaString(timestamp))
-	fmt.Printf("%-15s %s\n", "CPU Usage:", color.GreenString(fmt.Sprintf("%.1f%%", stats.CPU)))
+	fmt.Printf("%-15s %s\n", "Timestamp:", ui.Magenta(timestamp))
+	fmt.Printf("%-15s %s\n", "CPU Usage:", ui.Green(fmt.Sprintf("%.1f%%", stats.CPU)))
 	fmt.Printf("%-15s %s (%s)\n", "Memory:",
 		formatBytes(stats.Memory),
-		color.BlueString(fmt.Sprintf("%.1f%%", stats.MemoryPercent)))
-	fmt.Printf("%-15s %s\n", "Processes:", color.YellowString(fmt.Sprintf("%d", stats.Processes)))
+		ui.Blue(fmt.Sprintf("%.1f%%", stats.MemoryPercent)))
+	fmt.Printf("%-15s %s\n", "Processes:", ui.Yellow(fmt.Sprintf("%d", stats.Processes)))
 
-	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("💾 Disk I/O:"))
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("💾 Disk I/O:"))
 	fmt.Printf("%-15s %s\n", "Read:", formatBytes(stats.DiskRead))
 	fmt.Printf("%-15s %s\n", "Write:", formatBytes(stats.DiskWrite))
 
-	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("🌐 Network I/O:"))
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("🌐 Network I/O:"))
 	fmt.Printf("%-15s %s\n", "RX:", formatBytes(stats.NetRx))
 	fmt.Printf("%-15s %s\n", "TX:", formatBytes(stats.NetTx))
 }
@@ -456,11 +661,66 @@ func getContainerLogs(projectID string, cmd *cobra.Command) error {
 	tail, _ := cmd.Flags().GetInt("tail")
 	since, _ := cmd.Flags().GetString("since")
 	filter, _ := cmd.Flags().GetString("filter")
+	outPath, _ := cmd.Flags().GetString("out")
+	outOnly, _ := cmd.Flags().GetBool("out-only")
+	rotateSize, _ := cmd.Flags().GetString("rotate-size")
+	rotateKeep, _ := cmd.Flags().GetInt("rotate-keep")
+	sinceLast, _ := cmd.Flags().GetBool("since-last")
+	previous, _ := cmd.Flags().GetBool("previous")
+
+	outPath, err = expandPath(outPath)
+	if err != nil {
+		return err
+	}
+
+	// Optional log file sink
+	var outFile *rotatingFile
+	if outPath != "" {
+		maxSize, err := parseSize(rotateSize)
+		if err != nil {
+			return fmt.Errorf("invalid --rotate-size: %w", err)
+		}
+		outFile, err = openRotatingFile(outPath, maxSize, rotateKeep)
+		if err != nil {
+			return err
+		}
+		defer outFile.Close()
+	}
+
+	// writeStyled sends a log line to the --out file as plain text and to
+	// stdout as styled
+	writeStyled := func(plain, styled string) error {
+		if outFile != nil {
+			if _, err := outFile.Write([]byte(plain + "\n")); err != nil {
+				return fmt.Errorf("failed to write log file: %w", err)
+			}
+		}
+		if !outOnly {
+			fmt.Println(styled)
+		}
+		return nil
+	}
+
+	// writeLine sends a log line to stdout and/or the --out file
+	writeLine := func(line string) error {
+		return writeStyled(line, line)
+	}
 
 	// Create API client
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
+	// With --since-last, pick up where the previous run stopped. The new
+	// cursor is taken before the request so nothing written during it is
+	// skipped next time.
+	readAt := time.Now().UTC()
+	if sinceLast {
+		if cursor, ok := loadLogCursor(projectID, "container-logs"); ok {
+			since = cursor.Timestamp.Format(time.RFC3339)
+			tail = 0
+		}
+	}
+
 	// Build query parameters
 	params := make([]string, 0)
 	if tail > 0 {
@@ -472,6 +732,9 @@ func getContainerLogs(projectID string, cmd *cobra.Command) error {
 	if filter != "" {
 		params = append(params, "filter="+filter)
 	}
+	if previous {
+		params = append(params, "previous=true")
+	}
 
 	endpoint := fmt.Sprintf("/api/v1/sdk/containers/%s/logs", projectID)
 	if len(params) > 0 {
@@ -485,15 +748,36 @@ func getContainerLogs(projectID string, cmd *cobra.Command) error {
 			return fmt.Errorf("failed to get container logs: %w", err)
 		}
 
+		if sinceLast {
+			if err := saveLogCursor(projectID, "container-logs", logCursor{Timestamp: readAt}); err != nil && IsVerbose() {
+				fmt.Fprintf(os.Stderr, "%s Could not save log cursor: %v\n", ui.Yellow("⚠️"), err)
+			}
+			if len(logs) == 0 && since != "" && !IsQuiet() {
+				fmt.Printf("%s No new logs since %s\n", ui.Yellow("📜"), since)
+				return nil
+			}
+		}
+
+		if !outOnly {
+			defer startPager().Close()
+		}
 		for _, line := range logs {
-			fmt.Println(line)
+			if err := writeLine(line); err != nil {
+				return err
+			}
 		}
 		return nil
 	}
 
 	// Follow mode - stream logs
-	fmt.Printf("%s Following logs for %s (Press Ctrl+C to stop)\n\n",
-		color.CyanString("📜"), color.YellowString(projectID))
+	if !isNDJSONOutput() && !isJSONOutput() {
+		fmt.Printf("%s Following logs for %s (Press Ctrl+C to stop)\n",
+			ui.Cyan("📜"), ui.Yellow(projectID))
+		if outFile != nil {
+			fmt.Printf("%s Writing logs to %s\n", ui.Cyan("💾"), ui.Yellow(outPath))
+		}
+		fmt.Println()
+	}
 
 	// Create stream reader for logs
 	streamPath := fmt.Sprintf("/ws/containers/%s/logs", projectID)
@@ -502,6 +786,7 @@ func getContainerLogs(projectID string, cmd *cobra.Command) error {
 		return fmt.Errorf("failed to connect to log stream: %w", err)
 	}
 	defer stream.Close()
+	defer reportDroppedMessages(stream)
 
 	// Handle graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -523,7 +808,41 @@ func getContainerLogs(projectID string, cmd *cobra.Command) error {
 			if !ok {
 				return nil
 			}
-			fmt.Println(msg.Content)
+			if isNDJSONOutput() {
+				if outFile != nil {
+					if _, err := outFile.Write([]byte(msg.Content + "\n")); err != nil {
+						return fmt.Errorf("failed to write log file: %w", err)
+					}
+				}
+				if outOnly {
+					continue
+				}
+				if err := printStreamEvent("container", msg); err != nil {
+					return err
+				}
+				continue
+			}
+
+			record, structured := containerLogRecord(msg)
+			if isJSONOutput() {
+				line, err := json.Marshal(record)
+				if err != nil {
+					return fmt.Errorf("failed to encode log line: %w", err)
+				}
+				if err := writeLine(string(line)); err != nil {
+					return err
+				}
+				continue
+			}
+			if !structured {
+				if err := writeLine(msg.Content); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := writeStyled(formatLogRecord(record, false), formatLogRecord(record, true)); err != nil {
+				return err
+			}
 		case err, ok := <-stream.Errors():
 			if !ok {
 				return nil
@@ -533,6 +852,87 @@ func getContainerLogs(projectID string, cmd *cobra.Command) error {
 	}
 }
 
+// ContainerLogRecord is one followed container log line, written as a line
+// of JSON under --output json
+type ContainerLogRecord struct {
+	Timestamp string `json:"timestamp,omitempty"`
+	Level     string `json:"level,omitempty"`
+	Message   string `json:"message"`
+	Stream    string `json:"stream,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+// containerLogRecord extracts the structured fields of a log frame. It
+// reports false when the server sent no level, stream or source, in which
+// case the frame is plain text.
+func containerLogRecord(msg client.StreamMessage) (ContainerLogRecord, bool) {
+	field := func(key string) string {
+		if value, ok := msg.Metadata[key].(string); ok {
+			return value
+		}
+		return ""
+	}
+
+	record := ContainerLogRecord{
+		Timestamp: field("timestamp"),
+		Level:     strings.ToLower(field("level")),
+		Message:   msg.Content,
+		Stream:    field("stream"),
+		Source:    field("source"),
+	}
+	if record.Timestamp == "" && !msg.Timestamp.IsZero() {
+		record.Timestamp = msg.Timestamp.Format(time.RFC3339Nano)
+	}
+	structured := record.Level != "" || record.Stream != "" || record.Source != ""
+	return record, structured
+}
+
+// formatLogRecord renders a structured log line, colorizing the level when
+// styled is set
+func formatLogRecord(record ContainerLogRecord, styled bool) string {
+	var parts []string
+	if record.Timestamp != "" {
+		timestamp := record.Timestamp
+		if t, err := time.Parse(time.RFC3339Nano, record.Timestamp); err == nil {
+			timestamp = t.Local().Format("15:04:05.000")
+		}
+		if styled {
+			timestamp = ui.Muted(timestamp)
+		}
+		parts = append(parts, timestamp)
+	}
+	if record.Level != "" {
+		level := fmt.Sprintf("%-5s", strings.ToUpper(record.Level))
+		if styled {
+			level = logLevelColor(record.Level)(level)
+		}
+		parts = append(parts, level)
+	}
+	if record.Source != "" {
+		parts = append(parts, "["+record.Source+"]")
+	}
+
+	message := record.Message
+	if styled && record.Stream == "stderr" && record.Level == "" {
+		message = ui.Red(message)
+	}
+	return strings.Join(append(parts, message), " ")
+}
+
+// logLevelColor picks the color for a log level
+func logLevelColor(level string) func(format string, a ...interface{}) string {
+	switch level {
+	case "error", "err", "fatal", "critical", "panic":
+		return ui.Red
+	case "warn", "warning":
+		return ui.Yellow
+	case "debug", "trace":
+		return ui.Muted
+	default:
+		return fmt.Sprintf
+	}
+}
+
 func execInContainer(projectID, command string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -548,6 +948,7 @@ func execInContainer(projectID, command string, cmd *cobra.Command) error {
 	tty, _ := cmd.Flags().GetBool("tty")
 	workdir, _ := cmd.Flags().GetString("workdir")
 	envVars, _ := cmd.Flags().GetStringSlice("env")
+	noExit, _ := cmd.Flags().GetBool("no-exit")
 
 	// Parse environment variables
 	environment := make(map[string]string)
@@ -573,7 +974,7 @@ func execInContainer(projectID, command string, cmd *cobra.Command) error {
 
 	// Start spinner for non-interactive commands
 	var s *spinner.Spinner
-	if !interactive {
+	if !interactive && !isJSONOutput() {
 		s = spinner.New(spinner.CharSets[14], 100*time.Millisecond)
 		s.Suffix = " Executing command..."
 		s.Start()
@@ -595,16 +996,26 @@ func execInContainer(projectID, command string, cmd *cobra.Command) error {
 	}
 
 	// Display output
-	if response.Output != "" {
-		fmt.Print(response.Output)
-	}
+	if isJSONOutput() {
+		if err := printJSON(response); err != nil {
+			return err
+		}
+	} else {
+		if response.Output != "" {
+			fmt.Print(response.Output)
+		}
+
+		if response.Error != "" {
+			fmt.Fprintf(os.Stderr, "%s\n", ui.Red(response.Error))
+		}
 
-	if response.Error != "" {
-		fmt.Fprintf(os.Stderr, "%s\n", color.RedString(response.Error))
+		if noExit && response.ExitCode != 0 {
+			fmt.Fprintf(os.Stderr, "%s Command exited with code %d\n", ui.Red("❌"), response.ExitCode)
+		}
 	}
 
-	// Exit with same code as the command
-	if response.ExitCode != 0 {
+	// Exit with same code as the command, unless the caller handles it
+	if response.ExitCode != 0 && !noExit {
 		os.Exit(response.ExitCode)
 	}
 
@@ -623,15 +1034,20 @@ func scaleContainer(projectID string, cmd *cobra.Command) error {
 
 	cpu, _ := cmd.Flags().GetString("cpu")
 	memory, _ := cmd.Flags().GetString("memory")
+	auto, _ := cmd.Flags().GetBool("auto")
 
-	if cpu == "" && memory == "" {
-		return fmt.Errorf("at least one of --cpu or --memory must be specified")
+	if !auto && cpu == "" && memory == "" {
+		return fmt.Errorf("at least one of --cpu or --memory must be specified (or --auto)")
 	}
 
 	// Create API client
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
+	if auto {
+		return setAutoscalePolicy(apiClient, projectID, cmd)
+	}
+
 	// Prepare scale request
 	scaleRequest := make(map[string]string)
 	if cpu != "" {
@@ -657,13 +1073,13 @@ func scaleContainer(projectID string, cmd *cobra.Command) error {
 	s.Stop()
 
 	fmt.Printf("%s Container %s scaled successfully\n",
-		color.GreenString("📈"), color.CyanString(projectID))
+		ui.Green("📈"), ui.Cyan(projectID))
 
 	if cpu != "" {
-		fmt.Printf("CPU:    %s\n", color.YellowString(cpu))
+		fmt.Printf("CPU:    %s\n", ui.Yellow(cpu))
 	}
 	if memory != "" {
-		fmt.Printf("Memory: %s\n", color.BlueString(memory))
+		fmt.Printf("Memory: %s\n", ui.Blue(memory))
 	}
 
 	return nil
@@ -672,13 +1088,13 @@ func scaleContainer(projectID string, cmd *cobra.Command) error {
 func getHealthColor(status string) string {
 	switch status {
 	case "healthy":
-		return color.GreenString(status)
+		return ui.Green(status)
 	case "unhealthy":
-		return color.RedString(status)
+		return ui.Red(status)
 	case "starting":
-		return color.YellowString(status)
+		return ui.Yellow(status)
 	default:
-		return color.WhiteString(status)
+		return ui.White(status)
 	}
 }
 
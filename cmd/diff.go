@@ -0,0 +1,114 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+const (
+	// diffContextLines is how many unchanged lines surround a change
+	diffContextLines = 3
+	// maxDiffLines caps how much of a diff is printed inline
+	maxDiffLines = 40
+)
+
+// lineDiff produces a compact unified-style diff between two versions of a
+// file. It trims the common head and tail and reports the middle as one
+// replaced hunk, which is cheap and good enough for watching live edits.
+func lineDiff(before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	a := splitLines(before)
+	b := splitLines(after)
+
+	head := 0
+	for head < len(a) && head < len(b) && a[head] == b[head] {
+		head++
+	}
+	tail := 0
+	for tail < len(a)-head && tail < len(b)-head && a[len(a)-1-tail] == b[len(b)-1-tail] {
+		tail++
+	}
+
+	start := head - diffContextLines
+	if start < 0 {
+		start = 0
+	}
+	aEnd := len(a) - tail
+	bEnd := len(b) - tail
+	trailing := tail
+	if trailing > diffContextLines {
+		trailing = diffContextLines
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n",
+		start+1, aEnd+trailing-start, start+1, bEnd+trailing-start)
+	for _, line := range a[start:head] {
+		out.WriteString(" " + line + "\n")
+	}
+	for _, line := range a[head:aEnd] {
+		out.WriteString("-" + line + "\n")
+	}
+	for _, line := range b[head:bEnd] {
+		out.WriteString("+" + line + "\n")
+	}
+	for _, line := range a[aEnd : aEnd+trailing] {
+		out.WriteString(" " + line + "\n")
+	}
+	return out.String()
+}
+
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// printColorDiff prints a unified diff with the usual colors, indented, and
+// truncated after maxLines lines (0 means no limit).
+func printColorDiff(patch string, indent string, maxLines int) {
+	lines := splitLines(patch)
+	for i, line := range lines {
+		if maxLines > 0 && i == maxLines {
+			fmt.Printf("%s%s\n", indent,
+				ui.New(color.FgHiBlack).Sprintf("... %d more lines", len(lines)-maxLines))
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			line = ui.New(color.Bold).Sprint(line)
+		case strings.HasPrefix(line, "@@"):
+			line = ui.Cyan(line)
+		case strings.HasPrefix(line, "+"):
+			line = ui.Green(line)
+		case strings.HasPrefix(line, "-"):
+			line = ui.Red(line)
+		}
+		fmt.Printf("%s%s\n", indent, line)
+	}
+}
@@ -18,14 +18,22 @@ package cmd
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
-	"github.com/olekukonko/tablewriter"
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
 	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 // envCmd represents the env command
@@ -51,6 +59,10 @@ Examples:
   
   # List all environment settings
   fleeks env list
+
+  # Make staging the default environment (or pick one interactively)
+  fleeks env use staging
+  fleeks env use
   
   # Test environment connectivity
   fleeks env test
@@ -79,6 +91,24 @@ Shows both default values and any overrides from environment files.`,
 	},
 }
 
+var envUseCmd = &cobra.Command{
+	Use:   "use [environment]",
+	Short: "Set the default environment",
+	Long: `Save the default environment in the config file, so it applies to every
+command without --environment.
+
+Without an argument in a terminal, pick the environment from a list showing
+each one's API endpoint.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return useEnvironment(name)
+	},
+}
+
 var envTestCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Test environment connectivity",
@@ -88,7 +118,16 @@ Checks:
 - Main API endpoint health
 - WebSocket connectivity
 - LSP service availability
-- MCP service availability`,
+- MCP service availability
+
+The checks run in parallel, each bounded by --timeout, so one unreachable
+service can't hold up the rest.
+
+Exits with a non-zero status if any service is unreachable. Use
+--output json for a machine-readable report in CI pipelines:
+
+  fleeks env test --output json`,
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return testEnvironmentConnectivity(cmd)
 	},
@@ -99,6 +138,10 @@ func init() {
 	envCmd.AddCommand(envInfoCmd)
 	envCmd.AddCommand(envListCmd)
 	envCmd.AddCommand(envTestCmd)
+	envCmd.AddCommand(envUseCmd)
+
+	// Test command flags
+	envTestCmd.Flags().Duration("timeout", defaultServiceCheckTimeout, "Timeout for each service check")
 }
 
 func showEnvironmentInfo(cmd *cobra.Command) error {
@@ -109,57 +152,130 @@ func showEnvironmentInfo(cmd *cobra.Command) error {
 	}
 
 	fmt.Printf("\n%s %s\n\n",
-		color.New(color.Bold).Sprint("ðŸŒ Environment Information:"),
-		color.CyanString(string(envConfig.Current)))
+		ui.New(color.Bold).Sprint("ðŸŒ Environment Information:"),
+		ui.Cyan(string(envConfig.Current)))
 
 	// Get environment info
 	info := envConfig.GetEnvironmentInfo()
 
 	// Display basic info
-	fmt.Printf("%-20s %s\n", "Environment:", color.GreenString(fmt.Sprintf("%v", info["environment"])))
-	fmt.Printf("%-20s %s\n", "Config File:", color.YellowString(fmt.Sprintf("%v", info["env_file"])))
+	fmt.Printf("%-20s %s\n", "Environment:", ui.Green(fmt.Sprintf("%v", info["environment"])))
+	fmt.Printf("%-20s %s\n", "Config File:", ui.Yellow(fmt.Sprintf("%v", info["env_file"])))
 	fmt.Printf("%-20s %s\n", "Development Mode:", formatBoolValue(info["dev_mode"]))
 	fmt.Printf("%-20s %s\n", "Debug Enabled:", formatBoolValue(info["debug_enabled"]))
 	fmt.Printf("%-20s %s\n", "TLS Verify:", formatBoolValue(info["tls_verify"]))
 
-	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("ðŸ”— Service Endpoints:"))
-	fmt.Printf("%-20s %s\n", "Main API:", color.BlueString(fmt.Sprintf("%v", info["api_base_url"])))
-	fmt.Printf("%-20s %s\n", "WebSocket:", color.BlueString(fmt.Sprintf("%v", info["ws_base_url"])))
-	fmt.Printf("%-20s %s\n", "LSP Service:", color.BlueString(fmt.Sprintf("%v", info["lsp_service"])))
-	fmt.Printf("%-20s %s\n", "MCP Service:", color.BlueString(fmt.Sprintf("%v", info["mcp_service"])))
+	fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("ðŸ”— Service Endpoints:"))
+	fmt.Printf("%-20s %s\n", "Main API:", ui.Blue(fmt.Sprintf("%v", info["api_base_url"])))
+	fmt.Printf("%-20s %s\n", "WebSocket:", ui.Blue(fmt.Sprintf("%v", info["ws_base_url"])))
+	fmt.Printf("%-20s %s\n", "LSP Service:", ui.Blue(fmt.Sprintf("%v", info["lsp_service"])))
+	fmt.Printf("%-20s %s\n", "MCP Service:", ui.Blue(fmt.Sprintf("%v", info["mcp_service"])))
+
+	return nil
+}
+
+// environmentChoice is one entry in the 'env use' picker
+type environmentChoice struct {
+	Name    string
+	BaseURL string
+	Note    string
+}
+
+func useEnvironment(name string) error {
+	if _, err := config.Load(); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	current := config.Environment(viper.GetString("environment"))
+
+	if name == "" {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("environment name is required (development, staging or production)")
+		}
+		selected, err := pickEnvironment(current)
+		if err != nil {
+			return err
+		}
+		name = selected
+	}
+
+	env := config.Environment(name)
+	if !env.IsValid() && env != current {
+		return fmt.Errorf("unknown environment %q (expected development, staging or production)", name)
+	}
+
+	if err := config.SaveEnvironment(env); err != nil {
+		return fmt.Errorf("failed to save environment: %w", err)
+	}
 
+	fmt.Printf("%s Default environment set to %s\n", ui.Green("✓"), ui.Cyan(name))
+	if config.SettingSource("api.base_url") == config.SourceConfig {
+		fmt.Printf("  %s api.base_url in the config file still overrides the environment's endpoint (%s)\n",
+			ui.Yellow("Note:"), viper.GetString("api.base_url"))
+	}
 	return nil
 }
 
+// pickEnvironment lets the user choose an environment, starting on the
+// current one. A custom environment already in the config is offered too.
+func pickEnvironment(current config.Environment) (string, error) {
+	var choices []environmentChoice
+	for _, env := range []config.Environment{config.Development, config.Staging, config.Production} {
+		choices = append(choices, environmentChoice{Name: string(env), BaseURL: env.DefaultAPIBaseURL()})
+	}
+	if current != "" && !current.IsValid() {
+		choices = append(choices, environmentChoice{Name: string(current), BaseURL: viper.GetString("api.base_url"), Note: "custom"})
+	}
+
+	cursor := 0
+	for i, choice := range choices {
+		if choice.Name == string(current) {
+			cursor = i
+			if choice.Note == "" {
+				choices[i].Note = "current"
+			} else {
+				choices[i].Note += ", current"
+			}
+		}
+	}
+
+	prompt := promptui.Select{
+		Label:     "Environment",
+		Items:     choices,
+		CursorPos: cursor,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "▸ {{ .Name | cyan }} {{ .BaseURL | faint }}{{ if .Note }} {{ printf \"(%s)\" .Note | yellow }}{{ end }}",
+			Inactive: "  {{ .Name }} {{ .BaseURL | faint }}{{ if .Note }} {{ printf \"(%s)\" .Note | faint }}{{ end }}",
+			Selected: "Environment: {{ .Name | green }}",
+		},
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("environment selection cancelled")
+	}
+	return choices[index].Name, nil
+}
+
 func listEnvironmentSettings(cmd *cobra.Command) error {
 	fmt.Printf("\n%s\n\n",
-		color.New(color.Bold).Sprint("âš™ï¸  Environment Settings"))
+		ui.New(color.Bold).Sprint("âš™ï¸  Environment Settings"))
 
 	// Create table
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Setting", "Value", "Source"})
+	table := newListTable("Setting", "Value", "Source")
 	table.SetHeaderColor(
-		tablewriter.Colors{tablewriter.FgHiCyanColor},
-		tablewriter.Colors{tablewriter.FgHiYellowColor},
-		tablewriter.Colors{tablewriter.FgHiGreenColor},
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiGreen),
 	)
 
 	// Get all settings
 	settings := getAllSettings()
 
 	for key, value := range settings {
-		source := "default"
-		if viper.IsSet(key) {
-			source = "config"
-		}
-		if os.Getenv(getEnvKey(key)) != "" {
-			source = "environment"
-		}
-
 		table.Append([]string{
 			key,
 			fmt.Sprintf("%v", value),
-			source,
+			config.SettingSource(key),
 		})
 	}
 
@@ -167,46 +283,102 @@ func listEnvironmentSettings(cmd *cobra.Command) error {
 	return nil
 }
 
+// ServiceCheck is the result of probing a single service
+type ServiceCheck struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	Reachable bool   `json:"reachable"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// EnvTestResult is the JSON report produced by 'env test'
+type EnvTestResult struct {
+	Environment string         `json:"environment"`
+	OK          bool           `json:"ok"`
+	Services    []ServiceCheck `json:"services"`
+}
+
+// defaultServiceCheckTimeout bounds each connectivity probe
+const defaultServiceCheckTimeout = 5 * time.Second
+
 func testEnvironmentConnectivity(cmd *cobra.Command) error {
-	fmt.Printf("\n%s\n\n",
-		color.New(color.Bold).Sprint("ðŸ” Testing Environment Connectivity"))
+	// Load environment configuration
+	envConfig, err := config.LoadEnvironment()
+	if err != nil {
+		return fmt.Errorf("failed to load environment: %w", err)
+	}
 
-	// Test main API
-	fmt.Printf("%-30s ", "Main API:")
 	apiURL := viper.GetString("api.base_url")
-	if testEndpoint(apiURL + "/health") {
-		fmt.Printf("%s %s\n", color.GreenString("âœ… Connected"), color.New(color.FgHiBlack).Sprint(apiURL))
-	} else {
-		fmt.Printf("%s %s\n", color.RedString("âŒ Failed"), color.New(color.FgHiBlack).Sprint(apiURL))
+	lspURL := viper.GetString("services.lsp_url")
+	mcpURL := viper.GetString("services.mcp_url")
+	wsURL := viper.GetString("websocket.base_url")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
 	}
 
-	// Test LSP service
-	fmt.Printf("%-30s ", "LSP Service:")
-	lspURL := viper.GetString("services.lsp_url")
-	if testEndpoint(lspURL + "/health") {
-		fmt.Printf("%s %s\n", color.GreenString("âœ… Connected"), color.New(color.FgHiBlack).Sprint(lspURL))
-	} else {
-		fmt.Printf("%s %s\n", color.RedString("âŒ Failed"), color.New(color.FgHiBlack).Sprint(lspURL))
+	// Probe through an API client so --insecure and --header apply, as they
+	// would to real requests. No API key is sent to the services.
+	apiClient := client.NewAPIClient()
+	apiClient.SetTimeout(timeout)
+
+	// Run every probe at once and report when the slowest has finished
+	probes := []func() ServiceCheck{
+		func() ServiceCheck { return checkHTTPService(apiClient, "Main API", apiURL) },
+		func() ServiceCheck { return checkHTTPService(apiClient, "LSP Service", lspURL) },
+		func() ServiceCheck { return checkHTTPService(apiClient, "MCP Service", mcpURL) },
+		func() ServiceCheck { return checkWebSocketService("WebSocket", wsURL, timeout) },
 	}
+	checks := make([]ServiceCheck, len(probes))
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Add(1)
+		go func(i int, probe func() ServiceCheck) {
+			defer wg.Done()
+			checks[i] = probe()
+		}(i, probe)
+	}
+	wg.Wait()
 
-	// Test MCP service
-	fmt.Printf("%-30s ", "MCP Service:")
-	mcpURL := viper.GetString("services.mcp_url")
-	if testEndpoint(mcpURL + "/health") {
-		fmt.Printf("%s %s\n", color.GreenString("âœ… Connected"), color.New(color.FgHiBlack).Sprint(mcpURL))
-	} else {
-		fmt.Printf("%s %s\n", color.RedString("âŒ Failed"), color.New(color.FgHiBlack).Sprint(mcpURL))
+	result := EnvTestResult{
+		Environment: string(envConfig.Current),
+		OK:          true,
+		Services:    checks,
+	}
+	failed := 0
+	for _, check := range checks {
+		if !check.Reachable {
+			result.OK = false
+			failed++
+		}
 	}
 
-	// Test WebSocket (basic connection test)
-	fmt.Printf("%-30s ", "WebSocket:")
-	wsURL := viper.GetString("websocket.base_url")
-	if testWebSocketEndpoint(wsURL) {
-		fmt.Printf("%s %s\n", color.GreenString("âœ… Connected"), color.New(color.FgHiBlack).Sprint(wsURL))
+	if isJSONOutput() {
+		if err := printJSON(result); err != nil {
+			return err
+		}
 	} else {
-		fmt.Printf("%s %s\n", color.RedString("âŒ Failed"), color.New(color.FgHiBlack).Sprint(wsURL))
+		fmt.Printf("\n%s\n\n",
+			ui.New(color.Bold).Sprint("🔍 Testing Environment Connectivity"))
+
+		for _, check := range checks {
+			fmt.Printf("%-30s ", check.Name+":")
+			if check.Reachable {
+				fmt.Printf("%s %s %s\n", ui.Green("✅ Connected"),
+					ui.New(color.FgHiBlack).Sprint(check.URL),
+					ui.Magenta(fmt.Sprintf("(%dms)", check.LatencyMs)))
+			} else {
+				fmt.Printf("%s %s %s\n", ui.Red("❌ Failed"),
+					ui.New(color.FgHiBlack).Sprint(check.URL),
+					ui.Red(fmt.Sprintf("%s (%dms)", check.Error, check.LatencyMs)))
+			}
+		}
 	}
 
+	if !result.OK {
+		return fmt.Errorf("%d of %d services unreachable", failed, len(checks))
+	}
 	return nil
 }
 
@@ -214,11 +386,11 @@ func testEnvironmentConnectivity(cmd *cobra.Command) error {
 func formatBoolValue(value interface{}) string {
 	if b, ok := value.(bool); ok {
 		if b {
-			return color.GreenString("enabled")
+			return ui.Green("enabled")
 		}
-		return color.RedString("disabled")
+		return ui.Red("disabled")
 	}
-	return color.New(color.FgHiBlack).Sprint(fmt.Sprintf("%v", value))
+	return ui.New(color.FgHiBlack).Sprint(fmt.Sprintf("%v", value))
 }
 
 func getAllSettings() map[string]interface{} {
@@ -241,21 +413,63 @@ func getAllSettings() map[string]interface{} {
 	}
 }
 
-func getEnvKey(configKey string) string {
-	// Convert config key to environment variable name
-	envKey := "FLEEKS_" + configKey
-	// Replace dots with underscores and convert to uppercase
-	return envKey
-}
+// checkHTTPService probes baseURL/health with apiClient and records
+// reachability and latency. Any HTTP response below 500 counts as reachable.
+func checkHTTPService(apiClient *client.APIClient, name, baseURL string) ServiceCheck {
+	check := ServiceCheck{Name: name, URL: baseURL}
+	if baseURL == "" {
+		check.Error = "not configured"
+		return check
+	}
 
-func testEndpoint(url string) bool {
-	// This is a simplified test - in a real implementation,
-	// you would make an actual HTTP request
-	return url != ""
+	start := time.Now()
+	status, err := apiClient.Probe(strings.TrimRight(baseURL, "/") + "/health")
+	check.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	if status >= 500 {
+		check.Error = fmt.Sprintf("health check returned status %d", status)
+		return check
+	}
+	check.Reachable = true
+	return check
 }
 
-func testWebSocketEndpoint(url string) bool {
-	// This is a simplified test - in a real implementation,
-	// you would attempt a WebSocket connection
-	return url != ""
+// checkWebSocketService verifies that the WebSocket host accepts TCP connections
+func checkWebSocketService(name, wsURL string, timeout time.Duration) ServiceCheck {
+	check := ServiceCheck{Name: name, URL: wsURL}
+	if wsURL == "" {
+		check.Error = "not configured"
+		return check
+	}
+
+	u, err := url.Parse(wsURL)
+	if err != nil || u.Host == "" {
+		check.Error = fmt.Sprintf("invalid URL %q", wsURL)
+		return check
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "wss" {
+			port = "443"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", host, timeout)
+	check.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	conn.Close()
+
+	check.Reachable = true
+	return check
 }
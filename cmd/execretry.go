@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// maxExecRetryDelay caps the doubling wait between 'terminal exec --retry'
+// attempts
+const maxExecRetryDelay = time.Minute
+
+// execRetryPolicy decides whether and when 'terminal exec' runs a command
+// again after it exits with a non-zero code
+type execRetryPolicy struct {
+	retries int          // extra attempts after the first
+	codes   map[int]bool // exit codes worth retrying; empty means any non-zero
+	delay   time.Duration
+	showAll bool
+}
+
+// retryable reports whether an attempt that exited with code should be
+// run again. -1 means the exit code is unknown, which is never retried.
+func (p execRetryPolicy) retryable(code int) bool {
+	if code <= 0 {
+		return false
+	}
+	return len(p.codes) == 0 || p.codes[code]
+}
+
+// backoff is the wait before the given retry (1 for the first), doubling
+// from the configured delay up to maxExecRetryDelay
+func (p execRetryPolicy) backoff(retry int) time.Duration {
+	wait := p.delay
+	for i := 1; i < retry && wait < maxExecRetryDelay; i++ {
+		wait *= 2
+	}
+	return min(wait, maxExecRetryDelay)
+}
+
+// describeCodes lists the retryable exit codes for messages
+func (p execRetryPolicy) describeCodes() string {
+	if len(p.codes) == 0 {
+		return "any non-zero exit code"
+	}
+	codes := make([]int, 0, len(p.codes))
+	for code := range p.codes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	names := make([]string, len(codes))
+	for i, code := range codes {
+		names[i] = strconv.Itoa(code)
+	}
+	return "exit code " + strings.Join(names, ", ")
+}
+
+// runWithRetries runs attempt until it exits zero, with a code the policy
+// doesn't retry, or the retries are used up, waiting with backoff in
+// between. Each attempt writes its output to the writer it is given. Unless
+// showAll is set, the output of an attempt that may still be retried is held
+// back and dropped if it is, so only the final attempt's output is shown;
+// the last possible attempt streams straight through. A final non-zero exit
+// is returned as an error so scripts can tell the retries didn't help.
+func runWithRetries(policy execRetryPolicy, attempt func(out io.Writer) (int, error)) error {
+	total := policy.retries + 1
+	for n := 1; ; n++ {
+		last := n == total
+		if policy.showAll && total > 1 {
+			fmt.Printf("%s Attempt %d/%d\n", ui.Cyan("🔁"), n, total)
+		}
+
+		var held bytes.Buffer
+		var out io.Writer = os.Stdout
+		if !policy.showAll && !last {
+			out = &held
+		}
+
+		code, err := attempt(out)
+		if err != nil {
+			// Nothing more will run, so show whatever the attempt printed
+			held.WriteTo(os.Stdout)
+			return err
+		}
+
+		if last || !policy.retryable(code) {
+			held.WriteTo(os.Stdout)
+			if code > 0 {
+				return fmt.Errorf("command failed with exit code %d after %d attempt(s)", code, n)
+			}
+			return nil
+		}
+
+		wait := policy.backoff(n)
+		fmt.Printf("%s Attempt %d/%d failed with exit code %d, retrying in %s\n",
+			ui.Yellow("🔁"), n, total, code, wait)
+		time.Sleep(wait)
+	}
+}
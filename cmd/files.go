@@ -17,12 +17,18 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -32,6 +38,7 @@ import (
 
 	"github.com/fleeks-inc/fleeks-cli/internal/client"
 	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 // filesCmd represents the files command
@@ -70,6 +77,9 @@ Examples:
   
   # Download file from workspace
   fleeks files download my-project /workspace/config.json ./config.json
+
+  # Download a whole directory with 8 concurrent transfers
+  fleeks files download my-project /workspace/src ./src --recursive -j 8
   
   # Create new file in workspace
   fleeks files create my-project /workspace/README.md "# My Project"
@@ -84,7 +94,24 @@ var filesListCmd = &cobra.Command{
 	Short: "List files in workspace",
 	Long: `List all files in a workspace with detailed information.
 
-Shows file metadata including size, modification time, and type.`,
+Shows file metadata including size, modification time, and type.
+
+Files are fetched from the server in pages. Recursive listings are printed
+as each page arrives, so listing a large tree starts right away and doesn't
+hold the whole tree in memory; use --output ndjson to get one JSON object
+per file for scripts.
+
+--sort orders the listing by name, size (largest first), modified (newest
+first) or type; --reverse flips it and --dirs-first lists directories
+before files. Sorting needs every page, so sorted recursive listings are
+printed once the whole tree has been fetched.
+
+Examples:
+  # The largest files under src/
+  fleeks files list my-project --path /src --recursive --sort size
+
+  # Directories first, then the most recently changed files
+  fleeks files list my-project --dirs-first --sort modified`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return listFiles(args[0], cmd)
@@ -100,7 +127,38 @@ Supports:
 - Single file upload
 - Directory upload (recursive)
 - Progress tracking
-- Conflict handling`,
+- Conflict handling
+- Incremental uploads: unchanged files are skipped and large changed
+  files are sent as block deltas when the server supports it
+
+Use --full to always send complete file contents.
+
+Directories with 200 or more files are sent as a single compressed archive
+that the server extracts, instead of one request per file; --archive does
+this for smaller directories too. Archive uploads leave out files matched by
+workspace.ignore_patterns and always send complete contents.
+
+Use --checksum-only to report which files differ from the workspace without
+uploading anything. It exits non-zero if any file differs, which makes it a
+drift check for CI:
+
+  fleeks files upload my-project ./src /src -r --checksum-only
+
+Use --dry-run to see the transfer plan before a large upload: every file
+that would be sent, whether it is new or overwrites a different remote
+copy, and the total count and size. Without --overwrite, files that differ
+from an existing remote copy are listed as conflicts and not counted as
+changes. Nothing is changed. With --fail-on-noop
+it exits non-zero when the upload would change nothing:
+
+  fleeks files upload my-project ./build /app -r --dry-run
+
+Use --watch to keep pushing the local path after the initial upload: changed
+files are re-uploaded (replacing the remote copy) once no further changes
+arrive for --debounce, until Ctrl+C. Local deletions are not mirrored; use
+'workspace sync --delete' for that.
+
+  fleeks files upload my-project ./dist /app/dist -r --watch`,
 	Args: cobra.ExactArgs(3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return uploadFile(args[0], args[1], args[2], cmd)
@@ -128,7 +186,16 @@ var filesCreateCmd = &cobra.Command{
 	Short: "Create new file in workspace",
 	Long: `Create a new file in the cloud workspace with specified content.
 
-The content can be provided as a string or read from stdin.`,
+The content can be provided as a string or read from stdin.
+
+With --template the content is rendered from a named file template instead.
+Placeholders such as {{name}} are filled from the file path (path, dir,
+filename, name, ext) and from --var key=value pairs; missing values are an
+error rather than a prompt, so this is safe to script.
+
+Examples:
+  fleeks files create my-api src/Button.tsx --template react-component
+  fleeks files create my-api src/Button.tsx --template react-component --var style=css`,
 	Args: cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		projectID := args[0]
@@ -153,6 +220,23 @@ Use with caution as this operation cannot be undone.`,
 	},
 }
 
+var filesChmodCmd = &cobra.Command{
+	Use:   "chmod [project-id] [mode] [path]",
+	Short: "Change file permissions in workspace",
+	Long: `Change the permissions of a file or directory in the cloud workspace.
+
+The mode is octal, as with chmod(1). For example, to make an uploaded
+script executable:
+
+  fleeks files chmod my-project 755 /scripts/deploy.sh
+
+Use --recursive to apply the mode to everything under a directory.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return chmodFile(args[0], args[1], args[2], cmd)
+	},
+}
+
 var filesWatchCmd = &cobra.Command{
 	Use:   "watch [project-id]",
 	Short: "Watch for file changes",
@@ -161,7 +245,17 @@ var filesWatchCmd = &cobra.Command{
 Shows:
 - File creation, modification, and deletion
 - Who made the changes (user or agent)
-- Timestamps and change details`,
+- Timestamps and change details
+
+Large operations such as a git checkout or npm install can produce thousands
+of events; use --summary to print one aggregate line per --window instead:
+  fleeks files watch my-api --summary --window 2s
+
+Use --exec to run a local command whenever matching files change, e.g. to
+rebuild or test while the agent edits code. Changes are debounced, and
+{path} in the command is replaced by each changed path (shell-quoted):
+  fleeks files watch my-api --exec "make test"
+  fleeks files watch my-api --match "*.go" --exec "gofmt -l {path}"`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return watchFiles(args[0], cmd)
@@ -175,28 +269,76 @@ func init() {
 	filesCmd.AddCommand(filesDownloadCmd)
 	filesCmd.AddCommand(filesCreateCmd)
 	filesCmd.AddCommand(filesDeleteCmd)
+	filesCmd.AddCommand(filesChmodCmd)
 	filesCmd.AddCommand(filesWatchCmd)
 
 	// List command flags
 	filesListCmd.Flags().StringP("path", "p", "/", "Path to list (default: root)")
 	filesListCmd.Flags().BoolP("recursive", "r", false, "List files recursively")
 	filesListCmd.Flags().StringP("filter", "f", "", "Filter files by pattern")
+	filesListCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
+	filesListCmd.Flags().Int("page-size", defaultFilePageSize, "Files fetched per request")
+	addFileSortFlags(filesListCmd)
 
 	// Upload command flags
 	filesUploadCmd.Flags().BoolP("recursive", "r", false, "Upload directory recursively")
 	filesUploadCmd.Flags().BoolP("overwrite", "o", false, "Overwrite existing files")
+	filesUploadCmd.Flags().Bool("full", false, "Always upload full file contents (skip hash checks and deltas)")
+	filesUploadCmd.Flags().Bool("checksum-only", false, "Only report which files differ from the workspace; upload nothing")
+	filesUploadCmd.Flags().BoolP("watch", "w", false, "Keep watching the local path and re-upload files as they change")
+	filesUploadCmd.Flags().Duration("debounce", 500*time.Millisecond, "Quiet period before changed files are uploaded with --watch")
+	filesUploadCmd.Flags().Bool("archive", false, "Send a directory as one compressed archive (automatic from 200 files)")
+	filesUploadCmd.Flags().Bool("dry-run", false, "List the files that would be uploaded and their total size; upload nothing")
+	filesUploadCmd.Flags().Bool("fail-on-noop", false, "With --dry-run, exit non-zero when nothing would be uploaded")
+	filesUploadCmd.MarkFlagsMutuallyExclusive("archive", "checksum-only")
+	filesUploadCmd.MarkFlagsMutuallyExclusive("dry-run", "checksum-only")
+	filesUploadCmd.MarkFlagsMutuallyExclusive("dry-run", "watch")
+	filesUploadCmd.MarkFlagsMutuallyExclusive("watch", "checksum-only")
+	filesUploadCmd.MarkFlagsMutuallyExclusive("full", "checksum-only")
+	markFlagRequires(filesUploadCmd, "fail-on-noop", "dry-run")
+	markFlagRequires(filesUploadCmd, "archive", "recursive")
+	markFlagRequires(filesUploadCmd, "debounce", "watch")
 
 	// Download command flags
 	filesDownloadCmd.Flags().BoolP("recursive", "r", false, "Download directory recursively")
 	filesDownloadCmd.Flags().BoolP("overwrite", "o", false, "Overwrite existing local files")
+	filesDownloadCmd.Flags().IntP("parallel", "j", 4, "Number of concurrent downloads for recursive downloads")
+	markFlagRequires(filesDownloadCmd, "parallel", "recursive")
 
 	// Create command flags
 	filesCreateCmd.Flags().BoolP("stdin", "s", false, "Read content from stdin")
 	filesCreateCmd.Flags().StringP("template", "t", "", "Use file template")
+	filesCreateCmd.Flags().StringArray("var", []string{}, "Template variable as key=value (repeatable)")
+	filesCreateCmd.MarkFlagsMutuallyExclusive("template", "stdin")
+	markFlagRequires(filesCreateCmd, "var", "template")
 
 	// Delete command flags
 	filesDeleteCmd.Flags().BoolP("force", "f", false, "Force delete without confirmation")
 	filesDeleteCmd.Flags().BoolP("recursive", "r", false, "Delete directory recursively")
+
+	// Chmod command flags
+	filesChmodCmd.Flags().BoolP("recursive", "r", false, "Change permissions recursively")
+
+	// Watch command flags
+	filesWatchCmd.Flags().Bool("summary", false, "Print periodic aggregate counts instead of one line per event")
+	filesWatchCmd.Flags().Duration("window", 2*time.Second, "Aggregation window for --summary")
+	filesWatchCmd.Flags().String("exec", "", "Local command to run when files change ({path} is replaced by the changed path)")
+	filesWatchCmd.Flags().String("match", "", "Only trigger --exec for paths matching this glob (e.g. \"*.go\")")
+	filesWatchCmd.Flags().Duration("debounce", 500*time.Millisecond, "Quiet period before --exec runs")
+	markFlagRequires(filesWatchCmd, "window", "summary")
+	markFlagRequires(filesWatchCmd, "match", "exec")
+	markFlagRequires(filesWatchCmd, "debounce", "exec")
+
+	// Accept --project in place of the [project-id] argument
+	acceptProjectFlag(
+		filesListCmd,
+		filesUploadCmd,
+		filesDownloadCmd,
+		filesCreateCmd,
+		filesDeleteCmd,
+		filesChmodCmd,
+		filesWatchCmd,
+	)
 }
 
 // FileInfo represents file information
@@ -229,6 +371,20 @@ type FileDownloadResponse struct {
 	Size     int64  `json:"size"`
 }
 
+// FileChmodRequest represents a permission change request
+type FileChmodRequest struct {
+	Path      string `json:"path"`
+	Mode      string `json:"mode"` // octal, e.g. "0755"
+	Recursive bool   `json:"recursive"`
+}
+
+// FileChmodResponse reports the outcome of a permission change
+type FileChmodResponse struct {
+	Path         string `json:"path"`
+	Permissions  string `json:"permissions"`
+	FilesChanged int    `json:"files_changed"`
+}
+
 // FileChangeEvent represents file change event
 type FileChangeEvent struct {
 	Type      string    `json:"type"` // "created", "modified", "deleted"
@@ -239,6 +395,71 @@ type FileChangeEvent struct {
 	Details   string    `json:"details,omitempty"`
 }
 
+// defaultFilePageSize is how many files 'files list' requests at a time
+const defaultFilePageSize = 500
+
+// FileListPage is one page of a file listing. NextCursor is empty on the
+// last page.
+type FileListPage struct {
+	Files      []FileInfo `json:"files"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+// listFilePages requests a file listing page by page, calling fn with each
+// page as it arrives. Servers that don't page return every file as a plain
+// array, which is passed to fn as a single page.
+func listFilePages(apiClient *client.APIClient, endpoint string, pageSize int, fn func(files []FileInfo) error) error {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	cursor := ""
+	for {
+		pageEndpoint := fmt.Sprintf("%s%spage_size=%d", endpoint, separator, pageSize)
+		if cursor != "" {
+			pageEndpoint += "&cursor=" + url.QueryEscape(cursor)
+		}
+
+		var body json.RawMessage
+		if err := apiClient.GET(pageEndpoint, &body); err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+
+		var page FileListPage
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+			if err := json.Unmarshal(trimmed, &page.Files); err != nil {
+				return fmt.Errorf("failed to parse file listing: %w", err)
+			}
+		} else if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("failed to parse file listing: %w", err)
+		}
+
+		if err := fn(page.Files); err != nil {
+			return err
+		}
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// fileRow is the table row for a file
+func fileRow(file FileInfo) []string {
+	size := formatFileSize(file.Size)
+	if file.Type == "directory" {
+		size = "-"
+	}
+	return []string{
+		file.Name,
+		file.Type,
+		size,
+		file.ModifiedAt.Format("2006-01-02 15:04"),
+		file.Permissions,
+	}
+}
+
 func listFiles(projectID string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -253,6 +474,15 @@ func listFiles(projectID string, cmd *cobra.Command) error {
 	path, _ := cmd.Flags().GetString("path")
 	recursive, _ := cmd.Flags().GetBool("recursive")
 	filter, _ := cmd.Flags().GetString("filter")
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+
+	if pageSize <= 0 {
+		return fmt.Errorf("--page-size must be greater than zero")
+	}
+	sortOpts, err := getFileSortOptions(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Create API client
 	apiClient := client.NewAPIClient()
@@ -275,52 +505,116 @@ func listFiles(projectID string, cmd *cobra.Command) error {
 		endpoint += "?" + strings.Join(params, "&")
 	}
 
-	// Get files
-	var files []FileInfo
-	if err := apiClient.GET(endpoint, &files); err != nil {
-		return fmt.Errorf("failed to list files: %w", err)
+	headers := []string{"Name", "Type", "Size", "Modified", "Permissions"}
+	headerColors := []tablewriter.Colors{
+		{tablewriter.FgHiCyanColor},
+		{tablewriter.FgHiYellowColor},
+		{tablewriter.FgHiGreenColor},
+		{tablewriter.FgHiMagentaColor},
+		{tablewriter.FgHiWhiteColor},
+	}
+	printTitle := func() {
+		fmt.Printf("\n%s %s:%s\n\n",
+			ui.New(color.Bold).Sprint("📁 Files in"),
+			ui.Cyan(projectID),
+			ui.Yellow(path))
 	}
 
-	if len(files) == 0 {
-		fmt.Printf("%s No files found in %s\n",
-			color.YellowString("📁"), color.CyanString(path))
-		return nil
+	// fetchFiles hands each page to handle as it arrives, or everything at
+	// once when the listing has to be sorted first
+	fetchFiles := func(handle func([]FileInfo) error) error {
+		if !sortOpts.active() {
+			return listFilePages(apiClient, endpoint, pageSize, handle)
+		}
+		var files []FileInfo
+		err := listFilePages(apiClient, endpoint, pageSize, func(page []FileInfo) error {
+			files = append(files, page...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		sortFiles(files, sortOpts)
+		return handle(files)
 	}
 
-	// Create table
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Name", "Type", "Size", "Modified", "Permissions"})
-	table.SetHeaderColor(
-		tablewriter.Colors{tablewriter.FgHiCyanColor},
-		tablewriter.Colors{tablewriter.FgHiYellowColor},
-		tablewriter.Colors{tablewriter.FgHiGreenColor},
-		tablewriter.Colors{tablewriter.FgHiMagentaColor},
-		tablewriter.Colors{tablewriter.FgHiWhiteColor},
-	)
+	total := 0
+	switch {
+	case isNDJSONOutput():
+		// One object per file, written as each page arrives
+		return fetchFiles(func(files []FileInfo) error {
+			for _, file := range files {
+				if err := printNDJSON(file); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 
-	for _, file := range files {
-		size := formatFileSize(file.Size)
-		if file.Type == "directory" {
-			size = "-"
+	case isJSONOutput():
+		files := []FileInfo{}
+		err := fetchFiles(func(page []FileInfo) error {
+			files = append(files, page...)
+			return nil
+		})
+		if err != nil {
+			return err
 		}
+		return printJSON(files)
+
+	case recursive:
+		// A recursive listing can be huge: print each page as it arrives
+		// instead of building one table
+		table := newStreamTable(cmd, FileInfo{}, headers...)
+		err := fetchFiles(func(files []FileInfo) error {
+			if len(files) == 0 {
+				return nil
+			}
+			if table.Rows() == 0 {
+				printTitle()
+			}
+			rows := make([][]string, len(files))
+			records := make([]interface{}, len(files))
+			for i, file := range files {
+				rows[i] = fileRow(file)
+				records[i] = file
+			}
+			return table.WritePage(rows, records)
+		})
+		if err != nil {
+			return err
+		}
+		total = table.Rows()
 
-		table.Append([]string{
-			file.Name,
-			file.Type,
-			size,
-			file.ModifiedAt.Format("2006-01-02 15:04"),
-			file.Permissions,
+	default:
+		table := newListTable(headers...)
+		table.SetHeaderColor(headerColors...)
+		if err := table.SelectColumns(cmd, FileInfo{}); err != nil {
+			return err
+		}
+		err := fetchFiles(func(files []FileInfo) error {
+			for _, file := range files {
+				table.AppendRecord(fileRow(file), file)
+			}
+			total += len(files)
+			return nil
 		})
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			printTitle()
+			table.Render()
+		}
 	}
 
-	fmt.Printf("\n%s %s:%s\n\n",
-		color.New(color.Bold).Sprint("📁 Files in"),
-		color.CyanString(projectID),
-		color.YellowString(path))
-
-	table.Render()
+	if total == 0 {
+		fmt.Printf("%s No files found in %s\n",
+			ui.Yellow("📁"), ui.Cyan(path))
+		return nil
+	}
 
-	fmt.Printf("\nTotal: %s files\n", color.GreenString(fmt.Sprintf("%d", len(files))))
+	fmt.Printf("\nTotal: %s files\n", ui.Green(fmt.Sprintf("%d", total)))
 	return nil
 }
 
@@ -334,6 +628,11 @@ func uploadFile(projectID, localPath, remotePath string, cmd *cobra.Command) err
 		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
 	}
 
+	localPath, err = expandPath(localPath)
+	if err != nil {
+		return err
+	}
+
 	// Check if local file exists
 	fileInfo, err := os.Stat(localPath)
 	if err != nil {
@@ -342,6 +641,13 @@ func uploadFile(projectID, localPath, remotePath string, cmd *cobra.Command) err
 
 	recursive, _ := cmd.Flags().GetBool("recursive")
 	overwrite, _ := cmd.Flags().GetBool("overwrite")
+	full, _ := cmd.Flags().GetBool("full")
+	checksumOnly, _ := cmd.Flags().GetBool("checksum-only")
+	watch, _ := cmd.Flags().GetBool("watch")
+	debounce, _ := cmd.Flags().GetDuration("debounce")
+	archive, _ := cmd.Flags().GetBool("archive")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	failOnNoop, _ := cmd.Flags().GetBool("fail-on-noop")
 
 	if fileInfo.IsDir() && !recursive {
 		return fmt.Errorf("use --recursive flag to upload directories")
@@ -351,35 +657,133 @@ func uploadFile(projectID, localPath, remotePath string, cmd *cobra.Command) err
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
+	if checksumOnly {
+		report, err := compareChecksums(apiClient, projectID, localPath, remotePath)
+		if err != nil {
+			return err
+		}
+		if isJSONOutput() {
+			if err := printJSON(report); err != nil {
+				return err
+			}
+		} else {
+			printChecksumReport(report)
+		}
+		if report.Differing > 0 {
+			silenceUsage(cmd)
+			return fmt.Errorf("%d file(s) differ", report.Differing)
+		}
+		return nil
+	}
+
+	if dryRun {
+		plan, err := planUpload(cfg, apiClient, projectID, localPath, remotePath, fileInfo.IsDir(), full, archive, overwrite)
+		if err != nil {
+			return err
+		}
+		if isJSONOutput() {
+			if err := printJSON(plan); err != nil {
+				return err
+			}
+		} else {
+			plan.print()
+		}
+		if failOnNoop && plan.Changes() == 0 {
+			silenceUsage(cmd)
+			if plan.Conflicts > 0 {
+				return fmt.Errorf("nothing to upload without --overwrite")
+			}
+			return fmt.Errorf("nothing to upload; every file matches the workspace")
+		}
+		return nil
+	}
+
 	// Start spinner
 	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
 	s.Suffix = " Uploading file..."
 	s.Start()
 	defer s.Stop()
 
+	// Large files report chunk-level progress through the spinner
+	progress := func(status string) {
+		s.Lock()
+		s.Suffix = " " + status
+		s.Unlock()
+	}
+
+	var uploader *deltaUploader
+	if !full {
+		uploader = newDeltaUploader(apiClient, projectID, overwrite)
+		uploader.progress = progress
+	}
+
+	var archived *ArchiveUploadResponse
+	var result *BulkResult
 	if fileInfo.IsDir() {
-		// Directory upload (recursive)
-		err = uploadDirectory(apiClient, projectID, localPath, remotePath, overwrite)
+		// Directory upload (recursive), as one archive when there are many files
+		archived, err = uploadDirectoryArchive(cfg, apiClient, projectID, localPath, remotePath, overwrite, archive, uploader, progress)
+		if archived == nil && err == nil {
+			result = newBulkResult("file", "upload")
+			err = uploadDirectory(apiClient, projectID, localPath, remotePath, overwrite, uploader, progress, result)
+		}
+	} else if uploader != nil {
+		// Single file upload, skipping it if unchanged
+		err = uploader.upload(localPath, remotePath)
 	} else {
 		// Single file upload
-		err = uploadSingleFile(apiClient, projectID, localPath, remotePath, overwrite)
+		err = uploadSingleFile(apiClient, projectID, localPath, remotePath, overwrite, progress)
 	}
 
 	s.Stop()
 
+	// Keep whatever was recorded even if a later file failed
+	if uploader != nil {
+		if saveErr := uploader.state.save(); saveErr != nil && IsVerbose() {
+			fmt.Printf("%s Could not save sync state: %v\n", ui.Yellow("⚠️"), saveErr)
+		}
+	}
+
 	if err != nil {
 		return fmt.Errorf("upload failed: %w", err)
 	}
+	if result != nil && len(result.Failures()) > 0 {
+		result.PrintSummary()
+		return result.Err(cmd)
+	}
 
 	fmt.Printf("%s File uploaded successfully: %s → %s\n",
-		color.GreenString("📤"),
-		color.YellowString(localPath),
-		color.CyanString(remotePath))
+		ui.Green("📤"),
+		ui.Yellow(localPath),
+		ui.Cyan(remotePath))
+
+	if archived != nil {
+		fmt.Printf("%s %d file(s) sent in one archive (%s)\n", ui.Cyan("📊"), archived.Files, formatBytes(archived.Bytes))
+	} else if uploader != nil {
+		fmt.Printf("%s %s\n", ui.Cyan("📊"), uploader.Summary())
+	}
+	if result != nil {
+		result.PrintSummary()
+	}
+
+	if watch {
+		watcher, err := newUploadWatcher(apiClient, projectID, localPath, remotePath, fileInfo.IsDir(), full, debounce)
+		if err != nil {
+			return err
+		}
+		return watcher.run()
+	}
 
 	return nil
 }
 
-func uploadSingleFile(apiClient *client.APIClient, projectID, localPath, remotePath string, overwrite bool) error {
+// uploadSingleFile uploads one file in full. Files larger than
+// chunkedUploadThreshold are sent in resumable chunks; progress, if non-nil,
+// receives their chunk-level status.
+func uploadSingleFile(apiClient *client.APIClient, projectID, localPath, remotePath string, overwrite bool, progress func(string)) error {
+	if info, err := os.Stat(localPath); err == nil && info.Size() >= chunkedUploadThreshold {
+		return uploadChunked(apiClient, projectID, localPath, remotePath, overwrite, progress)
+	}
+
 	// Read file content
 	content, err := os.ReadFile(localPath)
 	if err != nil {
@@ -401,7 +805,39 @@ func uploadSingleFile(apiClient *client.APIClient, projectID, localPath, remoteP
 	return apiClient.POST(endpoint, request, nil)
 }
 
-func uploadDirectory(apiClient *client.APIClient, projectID, localDir, remoteDir string, overwrite bool) error {
+// uploadDirectoryArchive sends localDir as one archive when forced or when
+// it holds at least archiveUploadMinFiles files. It returns nil without an
+// error when the directory should be uploaded file by file instead,
+// including when the server doesn't accept archives and none was asked for.
+func uploadDirectoryArchive(cfg *config.Config, apiClient *client.APIClient, projectID, localDir, remoteDir string, overwrite, force bool, uploader *deltaUploader, progress func(string)) (*ArchiveUploadResponse, error) {
+	entries, total, err := collectArchiveEntries(cfg, localDir)
+	if err != nil {
+		return nil, err
+	}
+	if !force && len(entries) < archiveUploadMinFiles {
+		return nil, nil
+	}
+
+	response, err := uploadArchive(apiClient, projectID, remoteDir, entries, total, overwrite, uploader, progress)
+	if err != nil && !force && archiveUnsupported(err) {
+		progress("Server doesn't accept archives; uploading files one by one...")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Fall back to our own counts if the server doesn't report them
+	if response.Files == 0 {
+		response.Files, response.Bytes = len(entries), total
+	}
+	return response, nil
+}
+
+// uploadDirectory uploads every file under localDir except those matched by
+// workspace.ignore_patterns, like an archive upload, recording each file's
+// outcome in result so one failure doesn't stop the rest. When uploader is
+// non-nil files go through it so unchanged ones are skipped.
+func uploadDirectory(apiClient *client.APIClient, projectID, localDir, remoteDir string, overwrite bool, uploader *deltaUploader, progress func(string), result *BulkResult) error {
 	return filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -420,7 +856,13 @@ func uploadDirectory(apiClient *client.APIClient, projectID, localDir, remoteDir
 		remotePath := filepath.Join(remoteDir, relPath)
 		remotePath = strings.ReplaceAll(remotePath, "\\", "/") // Normalize path separators
 
-		return uploadSingleFile(apiClient, projectID, path, remotePath, overwrite)
+		if uploader != nil {
+			err = uploader.upload(path, remotePath)
+		} else {
+			err = uploadSingleFile(apiClient, projectID, path, remotePath, overwrite, progress)
+		}
+		result.Record(remotePath, err)
+		return nil
 	})
 }
 
@@ -434,61 +876,212 @@ func downloadFile(projectID, remotePath, localPath string, cmd *cobra.Command) e
 		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
 	}
 
+	localPath, err = expandPath(localPath)
+	if err != nil {
+		return err
+	}
+
+	recursive, _ := cmd.Flags().GetBool("recursive")
 	overwrite, _ := cmd.Flags().GetBool("overwrite")
 
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	if recursive {
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		return downloadDirectory(apiClient, projectID, remotePath, localPath, overwrite, parallel, cmd)
+	}
+
 	// Check if local file exists
 	if _, err := os.Stat(localPath); err == nil && !overwrite {
 		return fmt.Errorf("local file exists. Use --overwrite to replace it")
 	}
 
-	// Create API client
-	apiClient := client.NewAPIClient()
-	apiClient.SetAPIKey(cfg.GetAPIKey())
-
 	// Start spinner
 	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
 	s.Suffix = " Downloading file..."
 	s.Start()
 	defer s.Stop()
 
+	if err := downloadSingleFile(apiClient, projectID, remotePath, localPath); err != nil {
+		s.Stop()
+		return err
+	}
+
+	s.Stop()
+
+	fmt.Printf("%s File downloaded successfully: %s → %s\n",
+		ui.Green("📥"),
+		ui.Cyan(remotePath),
+		ui.Yellow(localPath))
+
+	return nil
+}
+
+func downloadSingleFile(apiClient *client.APIClient, projectID, remotePath, localPath string) error {
 	// Download file
 	var response FileDownloadResponse
 	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/download?path=%s", projectID, remotePath)
 	if err := apiClient.GET(endpoint, &response); err != nil {
-		s.Stop()
 		return fmt.Errorf("download failed: %w", err)
 	}
 
 	// Decode content
 	content, err := base64.StdEncoding.DecodeString(response.Content)
 	if err != nil {
-		s.Stop()
 		return fmt.Errorf("failed to decode file content: %w", err)
 	}
 
 	// Ensure local directory exists
 	localDir := filepath.Dir(localPath)
 	if err := os.MkdirAll(localDir, 0755); err != nil {
-		s.Stop()
 		return fmt.Errorf("failed to create local directory: %w", err)
 	}
 
 	// Write file
-	if err := os.WriteFile(localPath, content, 0644); err != nil {
-		s.Stop()
+	if err := writeFileAtomic(localPath, content, 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
-	s.Stop()
+	return nil
+}
 
-	fmt.Printf("%s File downloaded successfully: %s → %s\n",
-		color.GreenString("📥"),
-		color.CyanString(remotePath),
-		color.YellowString(localPath))
+// writeFileAtomic writes content to a temporary file next to path and renames
+// it into place, so an interrupted write never leaves a truncated file behind
+// or clobbers an existing good copy.
+func writeFileAtomic(path string, content []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
 
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
 	return nil
 }
 
+// downloadDirectory mirrors a remote directory into localDir using a bounded
+// pool of workers. Individual failures are collected and reported once every
+// file has been attempted instead of aborting the whole transfer.
+func downloadDirectory(apiClient *client.APIClient, projectID, remoteDir, localDir string, overwrite bool, parallel int, cmd *cobra.Command) error {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	// List the remote tree
+	var entries []FileInfo
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s?path=%s&recursive=true", projectID, url.QueryEscape(remoteDir))
+	if err := apiClient.GET(endpoint, &entries); err != nil {
+		return fmt.Errorf("failed to list remote directory: %w", err)
+	}
+
+	// Create the local tree and collect the files to fetch
+	remoteRoot := strings.TrimSuffix(remoteDir, "/")
+	files := make(map[string]string)
+	for _, entry := range entries {
+		relPath := strings.TrimPrefix(strings.TrimPrefix(entry.Path, remoteRoot), "/")
+		if relPath == "" {
+			continue
+		}
+		localPath := filepath.Join(localDir, filepath.FromSlash(relPath))
+
+		if entry.Type == "directory" {
+			if err := os.MkdirAll(localPath, 0755); err != nil {
+				return fmt.Errorf("failed to create local directory: %w", err)
+			}
+			continue
+		}
+		files[entry.Path] = localPath
+	}
+
+	if len(files) == 0 {
+		fmt.Printf("%s No files found in %s\n",
+			ui.Yellow("📁"), ui.Cyan(remoteDir))
+		return nil
+	}
+
+	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
+	s.Suffix = fmt.Sprintf(" Downloading files (0/%d)...", len(files))
+	s.Start()
+	defer s.Stop()
+
+	type job struct {
+		remotePath string
+		localPath  string
+	}
+
+	jobs := make(chan job)
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		completed int
+	)
+	result := newBulkResult("file", "download")
+
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				var err error
+				if _, statErr := os.Stat(j.localPath); statErr == nil && !overwrite {
+					err = fmt.Errorf("local file exists (use --overwrite to replace it)")
+				} else {
+					err = downloadSingleFile(apiClient, projectID, j.remotePath, j.localPath)
+				}
+
+				result.Record(j.remotePath, err)
+				mu.Lock()
+				completed++
+				s.Lock()
+				s.Suffix = fmt.Sprintf(" Downloading files (%d/%d)...", completed, len(files))
+				s.Unlock()
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for remotePath, localPath := range files {
+		jobs <- job{remotePath: remotePath, localPath: localPath}
+	}
+	close(jobs)
+	wg.Wait()
+
+	s.Stop()
+
+	fmt.Printf("%s Downloaded %s of %d files: %s → %s\n",
+		ui.Green("📥"),
+		ui.Green(fmt.Sprintf("%d", result.Succeeded())),
+		len(files),
+		ui.Cyan(remoteDir),
+		ui.Yellow(localDir))
+
+	result.PrintSummary()
+	return result.Err(cmd)
+}
+
 func createFile(projectID, path, content string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -499,9 +1092,35 @@ func createFile(projectID, path, content string, cmd *cobra.Command) error {
 		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
 	}
 
-	// Read from stdin if requested
+	templateName, _ := cmd.Flags().GetString("template")
+	vars, _ := cmd.Flags().GetStringArray("var")
 	useStdin, _ := cmd.Flags().GetBool("stdin")
-	if useStdin || content == "" {
+
+	if templateName != "" && (content != "" || useStdin) {
+		return fmt.Errorf("--template cannot be combined with content or --stdin")
+	}
+	if useStdin && content != "" {
+		return fmt.Errorf("--stdin cannot be combined with a content argument")
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	if templateName != "" {
+		template, err := fetchFileTemplate(apiClient, templateName)
+		if err != nil {
+			return err
+		}
+		values, err := templateVars(template, path, vars)
+		if err != nil {
+			return err
+		}
+		if content, err = renderFileTemplate(template, values); err != nil {
+			return err
+		}
+	} else if useStdin || content == "" {
+		// Read from stdin if requested
 		stdinContent, err := io.ReadAll(os.Stdin)
 		if err != nil {
 			return fmt.Errorf("failed to read from stdin: %w", err)
@@ -509,10 +1128,6 @@ func createFile(projectID, path, content string, cmd *cobra.Command) error {
 		content = string(stdinContent)
 	}
 
-	// Create API client
-	apiClient := client.NewAPIClient()
-	apiClient.SetAPIKey(cfg.GetAPIKey())
-
 	// Encode content as base64
 	encodedContent := base64.StdEncoding.EncodeToString([]byte(content))
 
@@ -529,7 +1144,7 @@ func createFile(projectID, path, content string, cmd *cobra.Command) error {
 	}
 
 	fmt.Printf("%s File created successfully: %s\n",
-		color.GreenString("📝"), color.CyanString(path))
+		ui.Green("📝"), ui.Cyan(path))
 
 	return nil
 }
@@ -548,7 +1163,7 @@ func deleteFile(projectID, path string, cmd *cobra.Command) error {
 
 	if !force {
 		fmt.Printf("%s Are you sure you want to delete '%s'? [y/N] ",
-			color.RedString("⚠️"), path)
+			ui.Red("⚠️"), path)
 
 		var response string
 		fmt.Scanln(&response)
@@ -569,11 +1184,74 @@ func deleteFile(projectID, path string, cmd *cobra.Command) error {
 	}
 
 	fmt.Printf("%s File deleted successfully: %s\n",
-		color.GreenString("🗑️"), color.CyanString(path))
+		ui.Green("🗑️"), ui.Cyan(path))
+
+	return nil
+}
+
+func chmodFile(projectID, mode, path string, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	recursive, _ := cmd.Flags().GetBool("recursive")
+
+	mode, err = parseFileMode(mode)
+	if err != nil {
+		return err
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	request := FileChmodRequest{
+		Path:      path,
+		Mode:      mode,
+		Recursive: recursive,
+	}
+
+	var response FileChmodResponse
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/chmod", projectID)
+	if err := apiClient.POST(endpoint, request, &response); err != nil {
+		return fmt.Errorf("failed to change permissions: %w", err)
+	}
+
+	if isJSONOutput() {
+		return printJSON(response)
+	}
+
+	permissions := response.Permissions
+	if permissions == "" {
+		permissions = mode
+	}
+	fmt.Printf("%s Permissions of %s set to %s\n",
+		ui.Green("🔐"), ui.Cyan(path), ui.Yellow(permissions))
+	if recursive && response.FilesChanged > 0 {
+		fmt.Printf("Files changed: %d\n", response.FilesChanged)
+	}
 
 	return nil
 }
 
+// parseFileMode validates an octal permission mode such as "755" or "0644"
+// and returns it in four-digit form
+func parseFileMode(mode string) (string, error) {
+	if len(mode) < 3 || len(mode) > 4 {
+		return "", fmt.Errorf("invalid mode %q: expected 3 or 4 octal digits (e.g. 755)", mode)
+	}
+	value, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid mode %q: expected 3 or 4 octal digits (e.g. 755)", mode)
+	}
+	return fmt.Sprintf("%04o", value), nil
+}
+
 func watchFiles(projectID string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -588,6 +1266,29 @@ func watchFiles(projectID string, cmd *cobra.Command) error {
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
+	summary, _ := cmd.Flags().GetBool("summary")
+	window, _ := cmd.Flags().GetDuration("window")
+	execCommand, _ := cmd.Flags().GetString("exec")
+	match, _ := cmd.Flags().GetString("match")
+	debounce, _ := cmd.Flags().GetDuration("debounce")
+	if summary && window <= 0 {
+		return fmt.Errorf("--window must be positive")
+	}
+
+	// Optional local command triggered by changes
+	var runner *changeRunner
+	var runnerReady <-chan time.Time
+	if execCommand != "" {
+		if runner, err = newChangeRunner(execCommand, match, debounce); err != nil {
+			return err
+		}
+		runnerReady = runner.ready()
+		if isNDJSONOutput() {
+			// Keep stdout a clean event stream
+			runner.out = os.Stderr
+		}
+	}
+
 	// Create stream reader for file changes
 	streamPath := fmt.Sprintf("/ws/files/%s/watch", projectID)
 	stream, err := apiClient.NewStreamReader(streamPath)
@@ -595,49 +1296,67 @@ func watchFiles(projectID string, cmd *cobra.Command) error {
 		return fmt.Errorf("failed to connect to file watch stream: %w", err)
 	}
 	defer stream.Close()
+	defer reportDroppedMessages(stream)
 
-	fmt.Printf("%s Watching file changes for %s (Press Ctrl+C to stop)\n\n",
-		color.CyanString("👀"), color.YellowString(projectID))
+	if !isNDJSONOutput() {
+		fmt.Printf("%s Watching file changes for %s (Press Ctrl+C to stop)\n\n",
+			ui.Cyan("👀"), ui.Yellow(projectID))
+	}
+
+	// In summary mode events are batched and flushed once per window
+	var batch *fileChangeBatch
+	var flush <-chan time.Time
+	if summary && !isNDJSONOutput() {
+		batch = newFileChangeBatch()
+		ticker := time.NewTicker(window)
+		defer ticker.Stop()
+		flush = ticker.C
+	}
 
 	// Stream file change events
 	for {
 		select {
 		case msg, ok := <-stream.Messages():
 			if !ok {
-				fmt.Printf("\n%s File watch stream ended\n", color.GreenString("✅"))
+				if batch != nil {
+					batch.print(window)
+				}
+				if runner != nil {
+					runner.run()
+					runner.wait()
+				}
+				if !isNDJSONOutput() {
+					fmt.Printf("\n%s File watch stream ended\n", ui.Green("✅"))
+				}
 				return nil
 			}
 
+			if _, exists := msg.Metadata["type"]; exists && runner != nil {
+				runner.add(fmt.Sprintf("%v", msg.Metadata["path"]))
+			}
+
+			if isNDJSONOutput() {
+				if err := printStreamEvent("files", msg); err != nil {
+					return err
+				}
+				continue
+			}
+
 			// Parse file change event from message metadata
 			if changeType, exists := msg.Metadata["type"]; exists {
-				path := msg.Metadata["path"]
-				actor := msg.Metadata["actor"]
-				timestamp := msg.Timestamp.Format("15:04:05")
-
-				var icon, typeColor string
-				switch changeType {
-				case "created":
-					icon = "📝"
-					typeColor = color.GreenString("CREATED")
-				case "modified":
-					icon = "✏️"
-					typeColor = color.YellowString("MODIFIED")
-				case "deleted":
-					icon = "🗑️"
-					typeColor = color.RedString("DELETED")
-				default:
-					icon = "📄"
-					typeColor = color.WhiteString(fmt.Sprintf("%v", changeType))
+				if batch != nil {
+					batch.add(fmt.Sprintf("%v", changeType), fmt.Sprintf("%v", msg.Metadata["path"]))
+					continue
 				}
-
-				fmt.Printf("[%s] %s %s %s (by %s)\n",
-					color.MagentaString(timestamp),
-					icon,
-					typeColor,
-					color.CyanString(fmt.Sprintf("%v", path)),
-					color.BlueString(fmt.Sprintf("%v", actor)))
+				printFileChange(msg, changeType)
 			}
 
+		case <-flush:
+			batch.print(window)
+
+		case <-runnerReady:
+			runner.run()
+
 		case err, ok := <-stream.Errors():
 			if !ok {
 				return nil
@@ -647,6 +1366,121 @@ func watchFiles(projectID string, cmd *cobra.Command) error {
 	}
 }
 
+// printFileChange prints one file change event on its own line
+func printFileChange(msg client.StreamMessage, changeType interface{}) {
+	path := msg.Metadata["path"]
+	actor := msg.Metadata["actor"]
+	timestamp := msg.Timestamp.Format("15:04:05")
+
+	var icon, typeColor string
+	switch changeType {
+	case "created":
+		icon = "📝"
+		typeColor = ui.Green("CREATED")
+	case "modified":
+		icon = "✏️"
+		typeColor = ui.Yellow("MODIFIED")
+	case "deleted":
+		icon = "🗑️"
+		typeColor = ui.Red("DELETED")
+	default:
+		icon = "📄"
+		typeColor = ui.White(fmt.Sprintf("%v", changeType))
+	}
+
+	fmt.Printf("[%s] %s %s %s (by %s)\n",
+		ui.Magenta(timestamp),
+		icon,
+		typeColor,
+		ui.Cyan(fmt.Sprintf("%v", path)),
+		ui.Blue(fmt.Sprintf("%v", actor)))
+}
+
+// fileChangeBatch aggregates file change events for --summary output
+type fileChangeBatch struct {
+	counts map[string]int
+	dir    string
+	total  int
+}
+
+func newFileChangeBatch() *fileChangeBatch {
+	return &fileChangeBatch{counts: make(map[string]int)}
+}
+
+func (b *fileChangeBatch) add(changeType, path string) {
+	b.counts[changeType]++
+	dir := ""
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		dir = path[:i]
+	}
+	if b.total == 0 {
+		b.dir = dir
+	} else {
+		b.dir = commonDir(b.dir, dir)
+	}
+	b.total++
+}
+
+// print writes one aggregate line such as
+// "42 created, 8 modified, 3 deleted in src/ (last 2s)" and resets the batch
+func (b *fileChangeBatch) print(window time.Duration) {
+	if b.total == 0 {
+		return
+	}
+
+	// Well-known types first, anything else alphabetically after
+	order := []string{"created", "modified", "deleted"}
+	var others []string
+	for changeType := range b.counts {
+		if changeType != "created" && changeType != "modified" && changeType != "deleted" {
+			others = append(others, changeType)
+		}
+	}
+	sort.Strings(others)
+
+	var parts []string
+	for _, changeType := range append(order, others...) {
+		count := b.counts[changeType]
+		if count == 0 {
+			continue
+		}
+		part := fmt.Sprintf("%d %s", count, changeType)
+		switch changeType {
+		case "created":
+			part = ui.Green(part)
+		case "modified":
+			part = ui.Yellow(part)
+		case "deleted":
+			part = ui.Red(part)
+		}
+		parts = append(parts, part)
+	}
+
+	location := ""
+	if b.dir != "" {
+		location = " in " + ui.Cyan(b.dir+"/")
+	}
+
+	fmt.Printf("[%s] %s%s (last %s)\n",
+		ui.Magenta(time.Now().Format("15:04:05")),
+		strings.Join(parts, ", "), location, window)
+
+	b.counts = make(map[string]int)
+	b.dir = ""
+	b.total = 0
+}
+
+// commonDir returns the deepest directory containing both a and b
+func commonDir(a, b string) string {
+	aParts := strings.Split(a, "/")
+	bParts := strings.Split(b, "/")
+	n := 0
+	for n < len(aParts) && n < len(bParts) && aParts[n] == bParts[n] {
+		n++
+	}
+	return strings.Join(aParts[:n], "/")
+}
+
 func formatFileSize(bytes int64) string {
 	const unit = 1024
 	if bytes < unit {
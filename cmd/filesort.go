@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// fileSortKeys are the values accepted by --sort
+var fileSortKeys = []string{"name", "size", "modified", "type"}
+
+// fileSortOptions orders file listings client-side. The zero value keeps
+// the server's order.
+type fileSortOptions struct {
+	key       string // one of fileSortKeys, or "" for server order
+	reverse   bool
+	dirsFirst bool
+}
+
+// addFileSortFlags registers --sort, --reverse and --dirs-first
+func addFileSortFlags(cmd *cobra.Command) {
+	cmd.Flags().String("sort", "", "Sort by name, size (largest first), modified (newest first) or type")
+	cmd.Flags().Bool("reverse", false, "Reverse the --sort order")
+	cmd.Flags().Bool("dirs-first", false, "List directories before files")
+	markFlagRequires(cmd, "reverse", "sort")
+}
+
+// getFileSortOptions reads the flags registered by addFileSortFlags
+func getFileSortOptions(cmd *cobra.Command) (fileSortOptions, error) {
+	var opts fileSortOptions
+	opts.key, _ = cmd.Flags().GetString("sort")
+	opts.reverse, _ = cmd.Flags().GetBool("reverse")
+	opts.dirsFirst, _ = cmd.Flags().GetBool("dirs-first")
+
+	opts.key = strings.ToLower(strings.TrimSpace(opts.key))
+	if opts.key == "" {
+		return opts, nil
+	}
+	for _, key := range fileSortKeys {
+		if opts.key == key {
+			return opts, nil
+		}
+	}
+	return opts, fmt.Errorf("invalid --sort %q (expected %s)", opts.key, strings.Join(fileSortKeys, ", "))
+}
+
+// active reports whether the listing needs sorting at all
+func (o fileSortOptions) active() bool {
+	return o.key != "" || o.dirsFirst
+}
+
+// sortFiles orders files in place. Size and modified sort largest and newest
+// first, like ls -S and ls -t; ties fall back to the name so the order is
+// stable. Directories stay on top with dirsFirst, even when reversed.
+func sortFiles(files []FileInfo, opts fileSortOptions) {
+	if !opts.active() {
+		return
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := files[i], files[j]
+		if opts.dirsFirst {
+			if aDir, bDir := a.Type == "directory", b.Type == "directory"; aDir != bDir {
+				return aDir
+			}
+		}
+		if opts.key == "" {
+			return false
+		}
+		if cmp := compareFiles(a, b, opts.key); cmp != 0 {
+			if opts.reverse {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+// compareFiles compares two files by key, returning <0 when a sorts first
+func compareFiles(a, b FileInfo, key string) int {
+	switch key {
+	case "size":
+		if a.Size != b.Size {
+			if a.Size > b.Size {
+				return -1
+			}
+			return 1
+		}
+	case "modified":
+		if !a.ModifiedAt.Equal(b.ModifiedAt) {
+			if a.ModifiedAt.After(b.ModifiedAt) {
+				return -1
+			}
+			return 1
+		}
+	case "type":
+		if a.Type != b.Type {
+			return strings.Compare(a.Type, b.Type)
+		}
+		if cmp := strings.Compare(strings.ToLower(path.Ext(a.Name)), strings.ToLower(path.Ext(b.Name))); cmp != 0 {
+			return cmp
+		}
+	}
+	return strings.Compare(fileSortName(a), fileSortName(b))
+}
+
+// fileSortName is the case-insensitive name files are sorted by. Recursive
+// listings compare full paths so each directory's files stay together.
+func fileSortName(file FileInfo) string {
+	if file.Path != "" {
+		return strings.ToLower(file.Path)
+	}
+	return strings.ToLower(file.Name)
+}
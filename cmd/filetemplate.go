@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+)
+
+// FileTemplate is a named file scaffold served by the API. Placeholders in
+// Content look like {{name}}.
+type FileTemplate struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Content     string            `json:"content"`
+	Defaults    map[string]string `json:"defaults,omitempty"`
+}
+
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// fetchFileTemplate downloads a named file template
+func fetchFileTemplate(apiClient *client.APIClient, name string) (*FileTemplate, error) {
+	var template FileTemplate
+	endpoint := fmt.Sprintf("/api/v1/sdk/templates/files/%s", url.PathEscape(name))
+	if err := apiClient.GET(endpoint, &template); err != nil {
+		return nil, fmt.Errorf("failed to fetch template '%s': %w", name, err)
+	}
+	return &template, nil
+}
+
+// templateVars builds the substitutions for filePath: built-ins derived from
+// the path, then the template's defaults, then the user's key=value pairs.
+func templateVars(template *FileTemplate, filePath string, pairs []string) (map[string]string, error) {
+	base := path.Base(filePath)
+	ext := path.Ext(base)
+
+	vars := map[string]string{
+		"path":     filePath,
+		"dir":      path.Dir(filePath),
+		"filename": base,
+		"name":     strings.TrimSuffix(base, ext),
+		"ext":      strings.TrimPrefix(ext, "."),
+	}
+	for key, value := range template.Defaults {
+		vars[key] = value
+	}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q (expected key=value)", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// renderFileTemplate substitutes vars into the template. It never prompts:
+// any placeholder without a value is reported as an error.
+func renderFileTemplate(template *FileTemplate, vars map[string]string) (string, error) {
+	missing := make(map[string]bool)
+	rendered := templatePlaceholder.ReplaceAllStringFunc(template.Content, func(match string) string {
+		key := templatePlaceholder.FindStringSubmatch(match)[1]
+		value, ok := vars[key]
+		if !ok {
+			missing[key] = true
+			return match
+		}
+		return value
+	})
+
+	if len(missing) > 0 {
+		keys := make([]string, 0, len(missing))
+		for key := range missing {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return "", fmt.Errorf("template '%s' needs values for: %s (pass --var key=value)",
+			template.Name, strings.Join(keys, ", "))
+	}
+	return rendered, nil
+}
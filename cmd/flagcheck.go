@@ -0,0 +1,88 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// flagRequiresAnnotation lists the flags a flag only makes sense with, in
+// the same way cobra records its flag groups
+const flagRequiresAnnotation = "fleeks_flag_requires"
+
+// markFlagRequires records that flag only applies when at least one of
+// required is set too. Like cobra's MarkFlagsMutuallyExclusive, the check
+// runs before the command does anything.
+func markFlagRequires(cmd *cobra.Command, flag string, required ...string) {
+	f := cmd.Flags().Lookup(flag)
+	if f == nil {
+		panic(fmt.Sprintf("markFlagRequires: unknown flag %q on %s", flag, cmd.CommandPath()))
+	}
+	for _, name := range required {
+		if cmd.Flags().Lookup(name) == nil {
+			panic(fmt.Sprintf("markFlagRequires: unknown flag %q on %s", name, cmd.CommandPath()))
+		}
+	}
+	if f.Annotations == nil {
+		f.Annotations = map[string][]string{}
+	}
+	f.Annotations[flagRequiresAnnotation] = append(f.Annotations[flagRequiresAnnotation], required...)
+}
+
+// flagIsSet reports whether a flag was given. A boolean flag only counts
+// when it is true, so --wait=false doesn't satisfy a flag that needs --wait.
+func flagIsSet(f *pflag.Flag) bool {
+	if !f.Changed {
+		return false
+	}
+	if f.Value.Type() == "bool" {
+		return f.Value.String() == "true"
+	}
+	return true
+}
+
+// validateFlagDependencies checks every flag recorded with markFlagRequires
+// that was given without any of the flags it needs
+func validateFlagDependencies(cmd *cobra.Command) error {
+	var problems []string
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		required := f.Annotations[flagRequiresAnnotation]
+		if len(required) == 0 || !f.Changed {
+			return
+		}
+		for _, name := range required {
+			if dep := cmd.Flags().Lookup(name); dep != nil && flagIsSet(dep) {
+				return
+			}
+		}
+		names := make([]string, len(required))
+		for i, name := range required {
+			names[i] = "--" + name
+		}
+		problems = append(problems, fmt.Sprintf("--%s requires %s", f.Name, strings.Join(names, " or ")))
+	})
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%s", strings.Join(problems, "; "))
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+)
+
+// completionFunc completes the value of a flag
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// Value sets for flags with a fixed set of values
+var (
+	environmentValues = []string{string(config.Development), string(config.Staging), string(config.Production)}
+	outputValues      = []string{outputTable, outputJSON, outputNDJSON}
+	shellValues       = []string{"bash", "zsh", "fish"}
+	jobStatusValues   = []string{"running", "completed", "failed", "cancelled"}
+	agentStatusValues = []string{"running", "starting", agentStatusProposalReady, "completed", "failed", "stopped"}
+)
+
+// registerFlagCompletion sets the completion for a flag of cmd. It panics if
+// the flag doesn't exist, which is a programming error caught at startup.
+func registerFlagCompletion(cmd *cobra.Command, flag string, complete completionFunc) {
+	if err := cmd.RegisterFlagCompletionFunc(flag, complete); err != nil {
+		panic(err)
+	}
+}
+
+// completeValues completes a flag from a fixed set of values
+func completeValues(values ...string) completionFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return matchingCompletions(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// matchingCompletions keeps the candidates that start with toComplete.
+// Candidates may carry a tab-separated description, which isn't matched.
+func matchingCompletions(candidates []string, toComplete string) []string {
+	var matches []string
+	for _, candidate := range candidates {
+		value, _, _ := strings.Cut(candidate, "\t")
+		if strings.HasPrefix(value, toComplete) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// completionCandidate pairs a value with the description shells show beside it
+func completionCandidate(value, description string) string {
+	if description == "" {
+		return value
+	}
+	return value + "\t" + description
+}
+
+// completionTimeout bounds completion requests so a slow or unreachable
+// server doesn't stall the shell for the full api.timeout
+const completionTimeout = 2 * time.Second
+
+// completionClient returns an API client for completions that have to ask
+// the server, or nil when no API key is configured. Completions run on every
+// Tab press, so requests give up after completionTimeout and failures are
+// silent and simply offer nothing.
+func completionClient() *client.APIClient {
+	if initializeConfig() != nil {
+		return nil
+	}
+	cfg, err := config.Load()
+	if err != nil || cfg.GetAPIKey() == "" {
+		return nil
+	}
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+	apiClient.SetTimeout(completionTimeout)
+	return apiClient
+}
+
+// completeWorkspaceTemplates completes 'workspace create --template' from
+// the templates endpoint, reusing the cached list when it is fresh
+func completeWorkspaceTemplates(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	apiClient := completionClient()
+	if apiClient == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	templates, err := fetchTemplates(apiClient, false)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	candidates := make([]string, 0, len(templates))
+	for _, template := range templates {
+		candidates = append(candidates, completionCandidate(template.Name, template.Description))
+	}
+	return matchingCompletions(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAgentTemplates completes 'agent start --template' from the server
+// and config templates
+func completeAgentTemplates(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	apiClient := completionClient()
+	if apiClient == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	templates, err := fetchAgentTemplates(apiClient)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	candidates := make([]string, 0, len(templates))
+	for _, template := range templates {
+		candidates = append(candidates, completionCandidate(template.Name, template.Description))
+	}
+	return matchingCompletions(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,203 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// flagDefaultsSection holds per-command flag defaults in the config file,
+// keyed by the command path and flag name:
+//
+//	defaults:
+//	  container:
+//	    logs:
+//	      tail: 200
+//	      follow: true
+const flagDefaultsSection = "defaults"
+
+// cobra records MarkFlagsMutuallyExclusive groups under this annotation
+const mutuallyExclusiveAnnotation = "cobra_annotation_mutually_exclusive"
+
+// applyFlagDefaults seeds the flags of cmd that weren't given on the command
+// line from defaults.{command}.{flag}. Explicit flags always win, and a
+// default is skipped when a flag it is mutually exclusive with was given, so
+// a configured --follow doesn't break an explicit --since-last. A default
+// for a flag that needs another flag (markFlagRequires) only applies when
+// that flag is set, on the command line or by its own default. Applied
+// defaults count as given: Changed reports them like typed flags.
+//
+// Global flags are read while the config is loaded, before any defaults are
+// known, so they can't take one.
+func applyFlagDefaults(cmd *cobra.Command) error {
+	name := commandName(cmd)
+	section := viper.GetStringMap(flagDefaultsSection + "." + name)
+	if len(section) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(section))
+	for key := range section {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	var dependent []*pflag.Flag
+	apply := func(f *pflag.Flag) {
+		if err := setFlagDefault(f, section[f.Name]); err != nil {
+			key := fmt.Sprintf("%s.%s.%s", flagDefaultsSection, name, f.Name)
+			problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+	for _, flagName := range keys {
+		key := fmt.Sprintf("%s.%s.%s", flagDefaultsSection, name, flagName)
+		f := cmd.Flags().Lookup(flagName)
+		if f == nil {
+			// A nested section belongs to a subcommand, e.g. defaults.container.logs
+			if _, isSection := section[flagName].(map[string]interface{}); !isSection {
+				fmt.Fprintf(os.Stderr, "%s %s: '%s' has no --%s flag\n",
+					ui.Yellow("⚠️"), key, cmd.CommandPath(), flagName)
+			}
+			continue
+		}
+		if isGlobalFlag(cmd, flagName) {
+			fmt.Fprintf(os.Stderr, "%s %s: --%s is a global flag and can't have a command default\n",
+				ui.Yellow("⚠️"), key, flagName)
+			continue
+		}
+		if f.Changed || excludedByGivenFlag(cmd, f) {
+			continue
+		}
+		if len(f.Annotations[flagRequiresAnnotation]) > 0 {
+			dependent = append(dependent, f)
+			continue
+		}
+		apply(f)
+	}
+	// Flags that need another flag go last, once every default they could
+	// rely on is in place
+	for _, f := range dependent {
+		for _, required := range f.Annotations[flagRequiresAnnotation] {
+			if dep := cmd.Flags().Lookup(required); dep != nil && flagIsSet(dep) {
+				apply(f)
+				break
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid flag default in config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// excludedByGivenFlag reports whether a flag in one of f's mutually
+// exclusive groups was given on the command line
+func excludedByGivenFlag(cmd *cobra.Command, f *pflag.Flag) bool {
+	for _, group := range f.Annotations[mutuallyExclusiveAnnotation] {
+		for _, other := range strings.Fields(group) {
+			if other == f.Name {
+				continue
+			}
+			if o := cmd.Flags().Lookup(other); o != nil && flagIsSet(o) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isGlobalFlag reports whether name is one of the root command's persistent
+// flags
+func isGlobalFlag(cmd *cobra.Command, name string) bool {
+	return cmd.Root().PersistentFlags().Lookup(name) != nil
+}
+
+// setFlagDefault sets a flag from a config value and marks it as given.
+// Lists set each element, so list flags take every item.
+func setFlagDefault(f *pflag.Flag, value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return fmt.Errorf("expected a value for --%s, not a section", f.Name)
+	case []interface{}:
+		for _, item := range v {
+			if err := f.Value.Set(fmt.Sprint(item)); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := f.Value.Set(fmt.Sprint(v)); err != nil {
+			return err
+		}
+	}
+	f.Changed = true
+	return nil
+}
+
+// validateFlagDefault checks a defaults.{command}.{flag} key and value for
+// 'config set': the command and flag must exist and the value must parse as
+// the flag's type
+func validateFlagDefault(key, raw string) error {
+	path := strings.Split(strings.TrimPrefix(key, flagDefaultsSection+"."), ".")
+	if len(path) < 2 {
+		return fmt.Errorf("%s must name a command and a flag, e.g. %s.container.logs.tail", key, flagDefaultsSection)
+	}
+
+	target := rootCmd
+	if path[0] != "root" {
+		found, rest, err := rootCmd.Find(path[:len(path)-1])
+		if err != nil || len(rest) > 0 || found == rootCmd {
+			return fmt.Errorf("%s: unknown command '%s'", key, strings.Join(path[:len(path)-1], " "))
+		}
+		target = found
+	}
+
+	flagName := path[len(path)-1]
+	if isGlobalFlag(target, flagName) {
+		return fmt.Errorf("%s: --%s is a global flag and can't have a command default", key, flagName)
+	}
+	f := target.Flags().Lookup(flagName)
+	if f == nil {
+		return fmt.Errorf("%s: '%s' has no --%s flag", key, target.CommandPath(), flagName)
+	}
+
+	raw = strings.TrimSpace(raw)
+	var err error
+	switch f.Value.Type() {
+	case "bool":
+		_, err = strconv.ParseBool(raw)
+	case "int", "int32", "int64":
+		_, err = strconv.Atoi(raw)
+	case "duration":
+		_, err = time.ParseDuration(raw)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: invalid %s %q", key, f.Value.Type(), raw)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// idFileFlagUsage is the help text for --id-file on commands that create
+// something
+const idFileFlagUsage = "Write the ID of the created resource to this file, for use by later pipeline steps"
+
+// writeIDFile writes ids, one per line, to the file named by the command's
+// --id-file flag. Commands without the flag, or run without it, write nothing.
+func writeIDFile(cmd *cobra.Command, ids ...string) error {
+	path, _ := cmd.Flags().GetString("id-file")
+	if path == "" || len(ids) == 0 {
+		return nil
+	}
+	path, err := expandPath(path)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, []byte(strings.Join(ids, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write ID file: %w", err)
+	}
+	return nil
+}
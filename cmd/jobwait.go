@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// jobPollInterval is how often 'terminal run --wait' checks the job status
+// once its output stream has ended
+const jobPollInterval = 2 * time.Second
+
+// isFinishedJobStatus reports whether a background job has stopped running
+func isFinishedJobStatus(status string) bool {
+	switch status {
+	case "completed", "failed", "cancelled", "stopped":
+		return true
+	}
+	return false
+}
+
+// getJob fetches the current state of a background job
+func getJob(apiClient *client.APIClient, projectID, jobID string) (*JobInfo, error) {
+	var job JobInfo
+	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/jobs/%s", projectID, jobID)
+	if err := apiClient.GET(endpoint, &job); err != nil {
+		return nil, fmt.Errorf("failed to get job status: %w", err)
+	}
+	return &job, nil
+}
+
+// waitForJob follows a job's output until it finishes and exits with the
+// job's exit code, for 'terminal run --wait'. The job keeps running if the
+// timeout (0 = no limit) passes first.
+func waitForJob(apiClient *client.APIClient, projectID, jobID string, timeout time.Duration) error {
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	timedOut := func() error {
+		return fmt.Errorf("timed out after %s waiting for job %s; it is still running (stop it with 'fleeks terminal stop %s %s')",
+			timeout, jobID, projectID, jobID)
+	}
+
+	if err := followJobOutput(apiClient, projectID, jobID, "", nil, deadline); err != nil {
+		if err == errFollowTimedOut {
+			return timedOut()
+		}
+		// The job status, not the output stream, decides the result
+		fmt.Fprintf(os.Stderr, "%s Lost the output stream (%v); waiting for the job to finish\n",
+			ui.Yellow("⚠️"), err)
+	}
+	return finishJob(apiClient, projectID, jobID, deadline, timedOut)
+}
+
+// finishJob waits for a job whose output has been followed to report its
+// final status and exits with the job's exit code. If deadline fires first,
+// the result of timedOut is returned instead.
+func finishJob(apiClient *client.APIClient, projectID, jobID string, deadline <-chan time.Time, timedOut func() error) error {
+	// The stream can end a moment before the job's status is updated
+	job, err := getJob(apiClient, projectID, jobID)
+	for err == nil && !isFinishedJobStatus(job.Status) {
+		select {
+		case <-deadline:
+			return timedOut()
+		case <-time.After(jobPollInterval):
+		}
+		job, err = getJob(apiClient, projectID, jobID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if job.ExitCode == nil {
+		if job.Status != "completed" {
+			return fmt.Errorf("job %s finished with status %s", jobID, job.Status)
+		}
+		fmt.Printf("%s Job %s completed\n", ui.Green("✅"), ui.Cyan(jobID))
+		return nil
+	}
+
+	if *job.ExitCode == 0 {
+		fmt.Printf("%s Job %s completed successfully (exit code: 0)\n", ui.Green("✅"), ui.Cyan(jobID))
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "%s Job %s %s (exit code: %d)\n",
+		ui.Red("❌"), ui.Cyan(jobID), job.Status, *job.ExitCode)
+	os.Exit(*job.ExitCode)
+	return nil
+}
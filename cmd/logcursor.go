@@ -0,0 +1,46 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/state"
+)
+
+// logCursor records how far --since-last has read a log or job output, so
+// the next invocation shows only what came after it
+type logCursor struct {
+	Line      int       `json:"line,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// cursorStore holds the cursors, keyed by project and resource
+// (e.g. "container-logs" or "job-output/<job-id>")
+var cursorStore = state.Open("cursors")
+
+// loadLogCursor returns the saved cursor for a resource, if any
+func loadLogCursor(projectID, resource string) (logCursor, bool) {
+	var cursor logCursor
+	ok, err := cursorStore.Get(projectID, resource, &cursor)
+	return cursor, ok && err == nil
+}
+
+// saveLogCursor records the cursor for a resource
+func saveLogCursor(projectID, resource string, cursor logCursor) error {
+	return cursorStore.Set(projectID, resource, cursor)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+)
+
+// Supported values for the persistent --output flag
+const (
+	outputTable  = "table"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+)
+
+// outputFormat holds the value of the persistent --output flag
+var outputFormat string
+
+// validateOutputFormat checks the --output flag against the supported formats
+func validateOutputFormat() error {
+	switch outputFormat {
+	case "", outputTable, outputJSON, outputNDJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q (supported: %s, %s, %s)", outputFormat, outputTable, outputJSON, outputNDJSON)
+	}
+}
+
+// isJSONOutput reports whether machine-readable JSON output was requested
+func isJSONOutput() bool {
+	return outputFormat == outputJSON
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return nil
+}
+
+// isNDJSONOutput reports whether newline-delimited JSON streaming output was requested
+func isNDJSONOutput() bool {
+	return outputFormat == outputNDJSON
+}
+
+// printNDJSON writes v to stdout as a single line of JSON. Stdout is unbuffered,
+// so each event reaches downstream consumers as soon as it is written.
+func printNDJSON(v interface{}) error {
+	if err := json.NewEncoder(os.Stdout).Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return nil
+}
+
+// StreamEvent is the stable NDJSON representation of a streamed message.
+// Every field is always present regardless of the message type.
+type StreamEvent struct {
+	Source    string                 `json:"source"`
+	Type      string                 `json:"type"`
+	Content   string                 `json:"content"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Timestamp string                 `json:"timestamp"`
+}
+
+// logUnknownMessage reports a stream message type this CLI version doesn't
+// handle. It is only shown with --verbose so newer servers don't spam output.
+func logUnknownMessage(source string, msg client.StreamMessage) {
+	if !IsVerbose() {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "[%s] unrecognized message type %q: %s\n", source, msg.Type, msg.Content)
+}
+
+// reportDroppedMessages warns on stderr when a stream discarded messages
+// because the consumer fell behind (streaming.overflow_policy=drop-oldest)
+func reportDroppedMessages(stream *client.StreamReader) {
+	if dropped := stream.Dropped(); dropped > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d stream messages were dropped because output fell behind; raise streaming.buffer_size to keep more\n", dropped)
+	}
+}
+
+// printStreamEvent emits a stream message from the given source as one NDJSON line
+func printStreamEvent(source string, msg client.StreamMessage) error {
+	timestamp := msg.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	metadata := msg.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	return printNDJSON(StreamEvent{
+		Source:    source,
+		Type:      string(msg.Type),
+		Content:   msg.Content,
+		Metadata:  metadata,
+		Timestamp: timestamp.UTC().Format(time.RFC3339),
+	})
+}
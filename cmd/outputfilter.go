@@ -0,0 +1,175 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// lineFilter keeps the output lines matching --grep and not matching
+// --grep-v, highlighting the --grep matches
+type lineFilter struct {
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+
+	partial string // follow output received so far without a newline
+}
+
+// newLineFilter compiles the patterns; it returns nil when both are empty
+func newLineFilter(grep, grepV string) (*lineFilter, error) {
+	if grep == "" && grepV == "" {
+		return nil, nil
+	}
+
+	f := &lineFilter{}
+	var err error
+	if grep != "" {
+		if f.include, err = regexp.Compile(grep); err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+	}
+	if grepV != "" {
+		if f.exclude, err = regexp.Compile(grepV); err != nil {
+			return nil, fmt.Errorf("invalid --grep-v pattern: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// match reports whether a line is kept. A nil filter keeps everything.
+func (f *lineFilter) match(line string) bool {
+	if f == nil {
+		return true
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if f.include != nil && !f.include.MatchString(line) {
+		return false
+	}
+	return f.exclude == nil || !f.exclude.MatchString(line)
+}
+
+// highlight colors the --grep matches in a kept line
+func (f *lineFilter) highlight(line string) string {
+	if f == nil || f.include == nil {
+		return line
+	}
+	return f.include.ReplaceAllStringFunc(line, func(match string) string {
+		return ui.New(color.FgHiRed, color.Bold).Sprint(match)
+	})
+}
+
+// feed takes a chunk of streamed output and returns the kept complete lines,
+// holding back a trailing partial line until the rest of it arrives
+func (f *lineFilter) feed(chunk string) string {
+	if f == nil {
+		return chunk
+	}
+	text := f.partial + chunk
+	f.partial = ""
+	if i := strings.LastIndex(text, "\n"); i < len(text)-1 {
+		f.partial = text[i+1:]
+		text = text[:i+1]
+	}
+	return f.filterLines(text)
+}
+
+// flush returns the held-back partial line, if it is kept
+func (f *lineFilter) flush() string {
+	if f == nil || f.partial == "" {
+		return ""
+	}
+	text := f.partial
+	f.partial = ""
+	return f.filterLines(text)
+}
+
+func (f *lineFilter) filterLines(text string) string {
+	var kept strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line != "" && f.match(line) {
+			kept.WriteString(f.highlight(line))
+		}
+	}
+	return kept.String()
+}
+
+// messageTypeFilter keeps the stream messages whose type is in include (when
+// set) and not in exclude
+type messageTypeFilter struct {
+	include map[client.MessageType]bool
+	exclude map[client.MessageType]bool
+}
+
+// newMessageTypeFilter checks the types named by --filter and --exclude
+// against the valid ones; it returns nil when both are empty
+func newMessageTypeFilter(include, exclude []string, valid []client.MessageType) (*messageTypeFilter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+
+	parse := func(flag string, names []string) (map[client.MessageType]bool, error) {
+		if len(names) == 0 {
+			return nil, nil
+		}
+		types := make(map[client.MessageType]bool, len(names))
+		for _, name := range names {
+			t := client.MessageType(strings.ToLower(strings.TrimSpace(name)))
+			known := false
+			for _, v := range valid {
+				known = known || v == t
+			}
+			if !known {
+				names := make([]string, len(valid))
+				for i, v := range valid {
+					names[i] = string(v)
+				}
+				return nil, fmt.Errorf("unknown message type %q for --%s (valid: %s)", name, flag, strings.Join(names, ", "))
+			}
+			types[t] = true
+		}
+		return types, nil
+	}
+
+	f := &messageTypeFilter{}
+	var err error
+	if f.include, err = parse("filter", include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = parse("exclude", exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// keeps reports whether a message of type t is shown. A nil filter keeps
+// everything.
+func (f *messageTypeFilter) keeps(t client.MessageType) bool {
+	if f == nil {
+		return true
+	}
+	if f.include != nil && !f.include[t] {
+		return false
+	}
+	return !f.exclude[t]
+}
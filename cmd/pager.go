@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// defaultPager is used when $PAGER isn't set; -R keeps colors intact
+const defaultPager = "less -R"
+
+// noPager holds the value of the persistent --no-pager flag
+var noPager bool
+
+// outputPager sends everything written to stdout through $PAGER, but only
+// once the output turns out to be taller than the terminal. Shorter output is
+// written straight through, as with git.
+type outputPager struct {
+	stdout *os.File
+	pipe   *os.File
+	done   chan struct{}
+}
+
+// startPager starts paging stdout for a command with potentially long
+// output. It returns nil (which is safe to Close) when paging doesn't apply:
+// stdout isn't a terminal, --no-pager or --quiet was given, or the output is
+// machine-readable.
+//
+//	defer startPager().Close()
+func startPager() *outputPager {
+	if noPager || IsQuiet() || outputFormat != outputTable {
+		return nil
+	}
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return nil
+	}
+	width, height, err := term.GetSize(fd)
+	if err != nil || height <= 0 {
+		return nil
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil
+	}
+
+	p := &outputPager{
+		stdout: os.Stdout,
+		pipe:   writer,
+		done:   make(chan struct{}),
+	}
+	os.Stdout = writer
+	go p.run(reader, width, height)
+	return p
+}
+
+// run buffers output until it fills the screen, then hands everything to the
+// pager. If the output ends first, the buffer is printed directly.
+func (p *outputPager) run(reader *os.File, width, height int) {
+	defer close(p.done)
+	defer reader.Close()
+
+	in := bufio.NewReader(reader)
+	var buffered bytes.Buffer
+	rows := 0
+	for rows < height {
+		line, err := in.ReadString('\n')
+		buffered.WriteString(line)
+		// Long lines wrap onto several rows
+		rows += 1 + displayWidth(line)/max(width, 1)
+		if err != nil {
+			p.stdout.Write(buffered.Bytes())
+			return
+		}
+	}
+
+	pagerCommand := strings.TrimSpace(os.Getenv("PAGER"))
+	if pagerCommand == "" {
+		pagerCommand = defaultPager
+	}
+	if _, err := exec.LookPath(strings.Fields(pagerCommand)[0]); err != nil {
+		// No pager installed, so print the output as is
+		io.Copy(p.stdout, io.MultiReader(&buffered, in))
+		return
+	}
+	pager := shellCommand(pagerCommand)
+	pager.Stdin = io.MultiReader(&buffered, in)
+	pager.Stdout = p.stdout
+	pager.Stderr = os.Stderr
+
+	// Like git, leave Ctrl+C to the pager while it is running. Catching
+	// SIGINT on a channel of our own keeps it from killing us without
+	// touching the handlers the command may have installed.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	err := pager.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		// The pager couldn't be started, so print the output as is
+		io.Copy(p.stdout, io.MultiReader(&buffered, in))
+		return
+	}
+
+	// The pager may have been quit before reading everything; keep draining
+	// so the command's writes don't block
+	io.Copy(io.Discard, in)
+}
+
+// Close restores stdout and waits for the pager to exit
+func (p *outputPager) Close() {
+	if p == nil {
+		return
+	}
+	os.Stdout = p.stdout
+	p.pipe.Close()
+	<-p.done
+}
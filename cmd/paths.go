@@ -0,0 +1,73 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+)
+
+// expandPath expands a leading ~ (the current user's home) or ~user (that
+// user's home) and then $VAR / ${VAR} references in a local path, the way a
+// shell would have if the path hadn't been quoted. A tilde produced by a
+// variable is left alone, as in a shell. "-" (stdin/stdout) and "" are
+// returned unchanged.
+func expandPath(path string) (string, error) {
+	if path == "" || path == "-" {
+		return path, nil
+	}
+
+	if strings.HasPrefix(path, "~") {
+		name, rest := path[1:], ""
+		if i := strings.IndexAny(name, `/\`); i >= 0 {
+			name, rest = name[:i], name[i:]
+		}
+
+		var home string
+		if name == "" {
+			dir, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("cannot expand ~ in %q: %w", path, err)
+			}
+			home = dir
+		} else {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", fmt.Errorf("cannot expand ~%s in %q: %w", name, path, err)
+			}
+			home = u.HomeDir
+		}
+		path = home + rest
+	}
+
+	return os.ExpandEnv(path), nil
+}
+
+// expandPaths applies expandPath to each path
+func expandPaths(paths []string) ([]string, error) {
+	expanded := make([]string, 0, len(paths))
+	for _, path := range paths {
+		p, err := expandPath(path)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, p)
+	}
+	return expanded, nil
+}
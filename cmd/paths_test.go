@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os/user"
+	"strings"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("FLEEKS_TEST_DIR", "/srv/data")
+	t.Setenv("FLEEKS_TEST_TILDE", "~")
+
+	current, err := user.Current()
+	if err != nil {
+		t.Fatalf("failed to look up the current user: %v", err)
+	}
+	// Windows reports DOMAIN\user; the backslash would end the ~user part
+	username := current.Username[strings.LastIndex(current.Username, `\`)+1:]
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"empty", "", ""},
+		{"stdio", "-", "-"},
+		{"tilde", "~", home},
+		{"tilde slash", "~/x", home + "/x"},
+		{"tilde backslash", `~\x`, home + `\x`},
+		{"tilde user", "~" + username + "/x", current.HomeDir + "/x"},
+		{"variable", "$FLEEKS_TEST_DIR/x", "/srv/data/x"},
+		{"braced variable", "${FLEEKS_TEST_DIR}x", "/srv/datax"},
+		{"unset variable", "$FLEEKS_TEST_UNSET/x", "/x"},
+		{"tilde from variable", "$FLEEKS_TEST_TILDE/x", "~/x"},
+		{"tilde then variable", "~/$FLEEKS_TEST_DIR", home + "//srv/data"},
+		{"relative", "src/main.go", "src/main.go"},
+		{"dot relative", "./src", "./src"},
+		{"tilde inside", "a/~/b", "a/~/b"},
+		{"absolute", "/tmp/x", "/tmp/x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandPath(tt.path)
+			if err != nil {
+				t.Fatalf("expandPath(%q) failed: %v", tt.path, err)
+			}
+			if got != tt.want {
+				t.Errorf("expandPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandPathUnknownUser(t *testing.T) {
+	if _, err := expandPath("~fleeks-no-such-user/x"); err == nil {
+		t.Error("expandPath with an unknown ~user succeeded, want an error")
+	}
+}
+
+func TestExpandPaths(t *testing.T) {
+	t.Setenv("FLEEKS_TEST_DIR", "/srv/data")
+
+	got, err := expandPaths([]string{"$FLEEKS_TEST_DIR/a", "b"})
+	if err != nil {
+		t.Fatalf("expandPaths failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != "/srv/data/a" || got[1] != "b" {
+		t.Errorf("expandPaths = %q, want [/srv/data/a b]", got)
+	}
+
+	if _, err := expandPaths([]string{"a", "~fleeks-no-such-user"}); err == nil {
+		t.Error("expandPaths with an unknown ~user succeeded, want an error")
+	}
+}
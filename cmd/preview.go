@@ -17,15 +17,25 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
 	"os/exec"
+	"os/signal"
 	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/fleeks-inc/fleeks-cli/internal/client"
 	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 // previewCmd represents the preview command
@@ -56,6 +66,12 @@ Examples:
 
   # Do both
   fleeks preview my-app --open --copy
+
+  # Keep a live session open showing connection status until Ctrl+C
+  fleeks preview my-app --tunnel
+
+  # Share a protected link that expires in two days
+  fleeks preview my-app --auth --ttl 48h --password s3cret
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -68,6 +84,17 @@ func init() {
 
 	previewCmd.Flags().BoolP("open", "o", false, "Open preview URL in browser")
 	previewCmd.Flags().BoolP("copy", "c", false, "Copy preview URL to clipboard")
+	previewCmd.Flags().BoolP("tunnel", "t", false, "Keep a persistent preview session open and show live status")
+	previewCmd.Flags().Bool("auth", false, "Get a signed, expiring link instead of the public preview URL")
+	previewCmd.Flags().Duration("ttl", defaultPreviewLinkTTL, "How long the --auth link stays valid")
+	previewCmd.Flags().String("password", "", "Also require this password to open the --auth link")
+	markFlagRequires(previewCmd, "ttl", "auth")
+	markFlagRequires(previewCmd, "password", "auth")
+
+	// Accept --project in place of the [project-id] argument
+	acceptProjectFlag(
+		previewCmd,
+	)
 }
 
 // PreviewURLResponse contains preview URL information
@@ -77,8 +104,15 @@ type PreviewURLResponse struct {
 	WebSocketURL string `json:"websocket_url"`
 	Status       string `json:"status"`
 	ContainerID  string `json:"container_id"`
+
+	// Set for signed links requested with auth=true
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	PasswordProtected bool       `json:"password_protected,omitempty"`
 }
 
+// defaultPreviewLinkTTL is how long a signed preview link is valid by default
+const defaultPreviewLinkTTL = 24 * time.Hour
+
 func getPreviewURL(projectID string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -92,6 +126,14 @@ func getPreviewURL(projectID string, cmd *cobra.Command) error {
 	// Get flags
 	openBrowser, _ := cmd.Flags().GetBool("open")
 	copyClipboard, _ := cmd.Flags().GetBool("copy")
+	tunnel, _ := cmd.Flags().GetBool("tunnel")
+	signed, _ := cmd.Flags().GetBool("auth")
+	ttl, _ := cmd.Flags().GetDuration("ttl")
+	password, _ := cmd.Flags().GetString("password")
+
+	if signed && ttl < time.Minute {
+		return fmt.Errorf("--ttl must be at least 1m")
+	}
 
 	// Create API client
 	apiClient := client.NewAPIClient()
@@ -100,102 +142,355 @@ func getPreviewURL(projectID string, cmd *cobra.Command) error {
 	// Fetch preview URL
 	var preview PreviewURLResponse
 	endpoint := fmt.Sprintf("/api/v1/sdk/workspaces/%s/preview-url", projectID)
+	if signed {
+		// The TTL is sent in seconds; the password is redacted from --log-file
+		query := url.Values{}
+		query.Set("auth", "true")
+		query.Set("ttl", strconv.Itoa(int(ttl.Seconds())))
+		if password != "" {
+			query.Set("password", password)
+		}
+		endpoint += "?" + query.Encode()
+	}
 	if err := apiClient.GET(endpoint, &preview); err != nil {
-		color.Red("❌ Failed to get preview URL: %v", err)
+		if errors.Is(err, client.ErrRawResponse) {
+			return err
+		}
+		fmt.Println(ui.Red("❌ Failed to get preview URL: %v", err))
 		fmt.Println()
-		color.Yellow("💡 Make sure workspace '%s' exists:", projectID)
-		color.Yellow("   fleeks workspace get %s", projectID)
+		fmt.Println(ui.Yellow("💡 Make sure workspace '%s' exists:", projectID))
+		fmt.Println(ui.Yellow("   fleeks workspace get %s", projectID))
 		return nil
 	}
 
-	// Display information
-	fmt.Println()
-	fmt.Printf("🌐 Preview URL: %s\n", color.CyanString(preview.PreviewURL))
-	fmt.Printf("🔌 WebSocket URL: %s\n", color.CyanString(preview.WebSocketURL))
-	fmt.Println()
-	fmt.Printf("📋 Status: %s\n", getStatusColor(preview.Status))
-	fmt.Printf("📦 Container: %s\n", color.BlueString(preview.ContainerID))
-	fmt.Println()
-
-	// Tips
-	fmt.Println(color.YellowString("💡 Tips:"))
-	fmt.Println("   • Start a web server in your workspace")
-	fmt.Println("   • Access your app via the preview URL")
-	fmt.Println("   • WebSocket URL supports real-time features")
-	fmt.Println()
+	if signed {
+		printSignedPreviewLink(&preview, ttl)
+	} else {
+		printPreviewInfo(&preview)
+	}
 
 	// Open in browser
 	if openBrowser {
 		fmt.Printf("🌐 Opening %s in your browser...\n", preview.PreviewURL)
 		if err := openURL(preview.PreviewURL); err != nil {
-			color.Yellow("⚠️  Could not open browser: %v", err)
-			color.Yellow("   Please open the URL manually")
+			fmt.Println(ui.Yellow("⚠️  Could not open browser: %v", err))
+			fmt.Println(ui.Yellow("   Please open the URL manually"))
 		} else {
-			fmt.Println(color.GreenString("✅ Browser opened!"))
+			fmt.Println(ui.Green("✅ Browser opened!"))
 		}
 		fmt.Println()
 	}
 
 	// Copy to clipboard
 	if copyClipboard {
-		if err := copyToClipboard(preview.PreviewURL); err == nil {
-			fmt.Println(color.GreenString("✅ Preview URL copied to clipboard!"))
+		err := validatePreviewURL(preview.PreviewURL)
+		if err == nil {
+			err = copyToClipboard(preview.PreviewURL)
+		}
+		if err == nil {
+			fmt.Println(ui.Green("✅ Preview URL copied to clipboard!"))
 			fmt.Printf("   %s\n", preview.PreviewURL)
 			fmt.Println()
 		} else {
-			color.Yellow("⚠️  Could not copy to clipboard: %v", err)
+			fmt.Println(ui.Yellow("⚠️  Could not copy to clipboard: %v", err))
 			fmt.Println()
 		}
 	}
 
+	if tunnel {
+		return runPreviewTunnel(apiClient, projectID)
+	}
+
 	return nil
 }
 
-// openURL opens a URL in the default browser
-func openURL(url string) error {
-	var cmd *exec.Cmd
+// printPreviewInfo shows the public preview URL and workspace status
+func printPreviewInfo(preview *PreviewURLResponse) {
+	fmt.Println()
+	fmt.Printf("🌐 Preview URL: %s\n", ui.Cyan(preview.PreviewURL))
+	fmt.Printf("🔌 WebSocket URL: %s\n", ui.Cyan(preview.WebSocketURL))
+	fmt.Println()
+	fmt.Printf("📋 Status: %s\n", getStatusColor(preview.Status))
+	fmt.Printf("📦 Container: %s\n", ui.Blue(preview.ContainerID))
+	fmt.Println()
+
+	// Tips
+	fmt.Println(ui.Yellow("💡 Tips:"))
+	fmt.Println("   • Start a web server in your workspace")
+	fmt.Println("   • Access your app via the preview URL")
+	fmt.Println("   • WebSocket URL supports real-time features")
+	fmt.Println()
+}
+
+// printSignedPreviewLink shows a signed preview link and when it expires
+func printSignedPreviewLink(preview *PreviewURLResponse, ttl time.Duration) {
+	expiresAt := time.Now().Add(ttl)
+	if preview.ExpiresAt != nil {
+		expiresAt = *preview.ExpiresAt
+	}
 
+	fmt.Println()
+	fmt.Printf("🔒 Shareable link: %s\n", ui.Cyan(preview.PreviewURL))
+	fmt.Printf("⏳ Expires: %s (in %s)\n",
+		ui.Yellow(expiresAt.Local().Format("2006-01-02 15:04:05 MST")),
+		time.Until(expiresAt).Round(time.Minute))
+	fmt.Println()
+	if preview.PasswordProtected {
+		fmt.Printf("🔑 %s\n", ui.Green("Password required to open"))
+		fmt.Println(ui.Yellow("💡 Share the password separately from the link."))
+	} else {
+		fmt.Println(ui.Yellow("💡 Anyone with the link can open the preview until it expires."))
+	}
+	fmt.Println()
+}
+
+// previewTunnelStats tracks what the preview session stream has reported so far
+type previewTunnelStats struct {
+	Requests    int64
+	Connections int64
+	Reconnects  int
+}
+
+// runPreviewTunnel keeps a WebSocket session to the workspace preview open,
+// printing live status updates and re-establishing the connection whenever it
+// drops, until the user presses Ctrl+C.
+func runPreviewTunnel(apiClient *client.APIClient, projectID string) error {
+	reconnectDelay := viper.GetDuration("streaming.reconnect_delay")
+	if reconnectDelay <= 0 {
+		reconnectDelay = 5 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
+	go func() {
+		<-c
+		fmt.Printf("\n%s Closing preview session...\n", ui.Yellow("🛑"))
+		cancel()
+	}()
+
+	fmt.Printf("%s Preview session for %s (Press Ctrl+C to stop)\n\n",
+		ui.Cyan("🔗"), ui.Yellow(projectID))
+
+	stats := &previewTunnelStats{}
+	streamPath := fmt.Sprintf("/ws/workspaces/%s/preview", projectID)
+
+	for {
+		err := streamPreviewSession(ctx, apiClient, streamPath, stats)
+		if ctx.Err() != nil {
+			fmt.Printf("%s Session closed (%d requests served, %d reconnects)\n",
+				ui.Green("✅"), stats.Requests, stats.Reconnects)
+			return nil
+		}
+
+		if err != nil {
+			fmt.Printf("[%s] %s Connection lost: %v\n",
+				ui.Magenta(time.Now().Format("15:04:05")),
+				ui.Red("🔴"), err)
+		} else {
+			fmt.Printf("[%s] %s Connection closed by server\n",
+				ui.Magenta(time.Now().Format("15:04:05")),
+				ui.Yellow("🟡"))
+		}
+		fmt.Printf("%s Reconnecting in %s...\n", ui.Yellow("🔄"), reconnectDelay)
+
+		select {
+		case <-ctx.Done():
+			continue
+		case <-time.After(reconnectDelay):
+			stats.Reconnects++
+		}
+	}
+}
+
+// streamPreviewSession runs a single connection of the preview session,
+// returning when the stream ends, fails, or ctx is cancelled.
+func streamPreviewSession(ctx context.Context, apiClient *client.APIClient, streamPath string, stats *previewTunnelStats) error {
+	stream, err := apiClient.NewStreamReader(streamPath)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	defer reportDroppedMessages(stream)
+
+	fmt.Printf("[%s] %s Connected\n",
+		ui.Magenta(time.Now().Format("15:04:05")),
+		ui.Green("🟢"))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-stream.Messages():
+			if !ok {
+				return nil
+			}
+
+			timestamp := msg.Timestamp.Format("15:04:05")
+			if msg.Timestamp.IsZero() {
+				timestamp = time.Now().Format("15:04:05")
+			}
+
+			// Request and connection counts are optional; only print what the server sends
+			if requests, ok := msg.Metadata["requests"].(float64); ok {
+				stats.Requests = int64(requests)
+			}
+			if connections, ok := msg.Metadata["active_connections"].(float64); ok {
+				stats.Connections = int64(connections)
+			}
+
+			switch msg.KnownType() {
+			case client.MessageRequest:
+				fmt.Printf("[%s] %s %s (total: %d)\n",
+					ui.Magenta(timestamp),
+					ui.Blue("↔"),
+					msg.Content,
+					stats.Requests)
+			case client.MessageStatus:
+				fmt.Printf("[%s] %s %s | requests: %s | connections: %s\n",
+					ui.Magenta(timestamp),
+					ui.Cyan("📊"),
+					getStatusColor(msg.Content),
+					ui.Green(fmt.Sprintf("%d", stats.Requests)),
+					ui.Blue(fmt.Sprintf("%d", stats.Connections)))
+			default:
+				if msg.KnownType() == client.MessageUnknown {
+					logUnknownMessage("preview", msg)
+				}
+				if msg.Content != "" {
+					fmt.Printf("[%s] %s\n", ui.Magenta(timestamp), msg.Content)
+				}
+			}
+
+		case err, ok := <-stream.Errors():
+			if !ok {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// helperCommand is a program that can open a URL or fill the clipboard
+type helperCommand struct {
+	name string
+	args []string
+}
+
+// validatePreviewURL rejects anything but an absolute http(s) URL, so a
+// malformed server response can't make a helper open a local file or an
+// unexpected protocol handler
+func validatePreviewURL(rawURL string) error {
+	if rawURL == "" {
+		return fmt.Errorf("the server returned no preview URL")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid preview URL %q: %v", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("refusing to use preview URL %q: scheme must be http or https", rawURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid preview URL %q: missing host", rawURL)
+	}
+	return nil
+}
+
+// browserHelpers are the programs tried, in order, to open a URL
+func browserHelpers(rawURL string) []helperCommand {
 	switch runtime.GOOS {
 	case "windows":
-		cmd = exec.Command("cmd", "/c", "start", url)
+		// Unlike "cmd /c start", rundll32 doesn't interpret & and other
+		// shell characters in the URL
+		return []helperCommand{{"rundll32", []string{"url.dll,FileProtocolHandler", rawURL}}}
 	case "darwin":
-		cmd = exec.Command("open", url)
+		return []helperCommand{{"open", []string{rawURL}}}
 	default: // linux, freebsd, openbsd, netbsd
-		cmd = exec.Command("xdg-open", url)
+		return []helperCommand{
+			{"xdg-open", []string{rawURL}},
+			{"wslview", []string{rawURL}},
+			{"sensible-browser", []string{rawURL}},
+		}
 	}
-
-	return cmd.Start()
 }
 
-// copyToClipboard copies text to the system clipboard
-func copyToClipboard(text string) error {
-	var cmd *exec.Cmd
-
+// clipboardHelpers are the programs tried, in order, to copy text
+func clipboardHelpers() []helperCommand {
 	switch runtime.GOOS {
 	case "windows":
-		cmd = exec.Command("clip")
+		return []helperCommand{{"clip", nil}}
 	case "darwin":
-		cmd = exec.Command("pbcopy")
+		return []helperCommand{{"pbcopy", nil}}
 	default: // linux
-		cmd = exec.Command("xclip", "-selection", "clipboard")
+		helpers := []helperCommand{
+			{"xclip", []string{"-selection", "clipboard"}},
+			{"xsel", []string{"--clipboard", "--input"}},
+		}
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			helpers = append([]helperCommand{{"wl-copy", nil}}, helpers...)
+		}
+		return helpers
 	}
+}
 
-	in, err := cmd.StdinPipe()
-	if err != nil {
-		return err
+// runHelpers tries each installed helper in turn until one succeeds. It
+// returns an error naming the helpers to install when none is found.
+func runHelpers(helpers []helperCommand, run func(path string, helper helperCommand) error) error {
+	var names []string
+	var lastErr error
+	for _, helper := range helpers {
+		names = append(names, helper.name)
+		path, err := exec.LookPath(helper.name)
+		if err != nil {
+			continue
+		}
+		if lastErr = run(path, helper); lastErr == nil {
+			return nil
+		}
 	}
-
-	if err := cmd.Start(); err != nil {
-		return err
+	if lastErr != nil {
+		return lastErr
 	}
-
-	if _, err := in.Write([]byte(text)); err != nil {
-		return err
+	if len(names) == 1 {
+		return fmt.Errorf("%s was not found in PATH", names[0])
 	}
+	return fmt.Errorf("none of %s was found in PATH; install one of them", strings.Join(names, ", "))
+}
 
-	if err := in.Close(); err != nil {
+// openURL opens an http(s) URL in the default browser
+func openURL(rawURL string) error {
+	if err := validatePreviewURL(rawURL); err != nil {
 		return err
 	}
+	return runHelpers(browserHelpers(rawURL), func(path string, helper helperCommand) error {
+		return exec.Command(path, helper.args...).Start()
+	})
+}
+
+// clipboardTimeout bounds a clipboard helper that never returns
+const clipboardTimeout = 5 * time.Second
 
-	return cmd.Wait()
+// copyToClipboard copies text to the system clipboard. xclip, xsel and
+// wl-copy fork a child that keeps serving the selection with the helper's
+// stdout and stderr still open, so those are left unattached: capturing them
+// would wait for that child to exit.
+func copyToClipboard(text string) error {
+	return runHelpers(clipboardHelpers(), func(path string, helper helperCommand) error {
+		ctx, cancel := context.WithTimeout(context.Background(), clipboardTimeout)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, path, helper.args...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("%s did not finish within %s", helper.name, clipboardTimeout)
+			}
+			return fmt.Errorf("%s: %w", helper.name, err)
+		}
+		return nil
+	})
 }
@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// acceptProjectFlag lets commands whose first argument is [project-id] take
+// the project from --project/-p instead, so scripts can pass it the same way
+// everywhere. If both are given, the positional argument wins.
+// Call it after the command's own flags are registered; -p is left off
+// commands that already use it.
+func acceptProjectFlag(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		usage := "Project ID (alternative to the [project-id] argument)"
+		if cmd.Flags().ShorthandLookup("p") == nil {
+			cmd.Flags().StringP("project", "p", "", usage)
+		} else {
+			cmd.Flags().String("project", "", usage)
+		}
+
+		validate := cmd.Args
+		if validate == nil {
+			validate = cobra.ArbitraryArgs
+		}
+		run := cmd.RunE
+
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			resolved := withProjectArg(cmd, args, validate)
+			if err := validate(cmd, resolved); err != nil {
+				if len(resolved) == 0 {
+					return fmt.Errorf("%w (pass the project ID as the first argument or with --project)", err)
+				}
+				return err
+			}
+			return nil
+		}
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return run(cmd, withProjectArg(cmd, args, validate))
+		}
+	}
+}
+
+// withProjectArg puts the --project value in front of args when the
+// arguments are a project ID short. Arguments that are valid on their own
+// already start with a project ID, which wins over the flag.
+func withProjectArg(cmd *cobra.Command, args []string, validate cobra.PositionalArgs) []string {
+	project, _ := cmd.Flags().GetString("project")
+	if project == "" || (len(args) > 0 && validate(cmd, args) == nil) {
+		return args
+	}
+	return append([]string{project}, args...)
+}
@@ -36,19 +36,33 @@ Use "fleeks [command] --help" for more information about a command.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	colorful "github.com/gookit/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 var (
 	cfgFile     string
 	environment string
 	verbose     bool
+	quiet       bool
+	insecure    bool
+	mock        bool
+	headers     []string
+	logFile     string
+	rawOutput   bool
+	noCache     bool
 )
 
 // Version information (set via ldflags at build time)
@@ -123,33 +137,212 @@ var rootCmd = &cobra.Command{
 		gradientLine(" ██║      ███████╗ ███████╗ ███████╗ ██║  ██╗ ███████║", 235, 88, 243, 210, 98, 240),
 		gradientLine(" ╚═╝      ╚══════╝ ╚══════╝ ╚══════╝ ╚═╝  ╚═╝ ╚══════╝", 210, 98, 240, 170, 115, 245),
 		"",
-		color.New(color.Bold, color.FgHiCyan).Sprint("World's First Universal AI Software Engineer CLI"),
-		color.WhiteString("Fleeks brings the power of a universal AI software engineer to your terminal."),
-		color.HiBlackString("One intelligent agent that adapts to ANY project type - no role selection needed!"),
-		color.New(color.Bold).Sprint("Features:"),
-		color.New(color.FgHiGreen).Sprint("✅ Universal AI software engineer (adapts to web, mobile, blockchain, games, AI/ML, IoT, etc.)"),
-		color.New(color.FgGreen).Sprint("✅ Automatic project type detection from conversation"),
-		color.New(color.FgHiYellow).Sprint("✅ Real-time streaming with tool execution visibility"),
-		color.New(color.FgYellow).Sprint("✅ Secure workspace isolation with Docker containers"),
-		color.New(color.FgHiGreen).Sprint("✅ Built-in terminal, file operations, and Git integration"),
-		"",
-		color.New(color.Bold).Sprint("Getting Started:"),
-		color.New(color.FgHiCyan).Sprint("  1. Authenticate:    fleeks auth login"),
-		color.New(color.FgCyan).Sprint("  2. Create project:  fleeks workspace create my-project"),
-		color.New(color.FgHiBlue).Sprint("  3. Start building:  fleeks agent start --project my-project --task \"Build authentication system\""),
-		"",
-		color.HiBlackString("The agent automatically detects what you're building and adapts its expertise!"),
-		"",
-		color.New(color.FgBlue).Sprint("📚 Learn more: https://docs.fleeks.dev")),
+		ui.New(color.Bold, color.FgHiCyan).Sprint("World's First Universal AI Software Engineer CLI"),
+		ui.White("Fleeks brings the power of a universal AI software engineer to your terminal."),
+		ui.Muted("One intelligent agent that adapts to ANY project type - no role selection needed!"),
+		ui.New(color.Bold).Sprint("Features:"),
+		ui.New(color.FgHiGreen).Sprint("✅ Universal AI software engineer (adapts to web, mobile, blockchain, games, AI/ML, IoT, etc.)"),
+		ui.New(color.FgGreen).Sprint("✅ Automatic project type detection from conversation"),
+		ui.New(color.FgHiYellow).Sprint("✅ Real-time streaming with tool execution visibility"),
+		ui.New(color.FgYellow).Sprint("✅ Secure workspace isolation with Docker containers"),
+		ui.New(color.FgHiGreen).Sprint("✅ Built-in terminal, file operations, and Git integration"),
+		ui.New(color.Bold).Sprint("Getting Started:"),
+		ui.New(color.FgHiCyan).Sprint("  1. Authenticate:    fleeks auth login"),
+		ui.New(color.FgCyan).Sprint("  2. Create project:  fleeks workspace create my-project"),
+		ui.New(color.FgHiBlue).Sprint("  3. Start building:  fleeks agent start --project my-project --task \"Build authentication system\""),
+		ui.Muted("The agent automatically detects what you're building and adapts its expertise!"),
+		ui.New(color.FgBlue).Sprint("📚 Learn more: https://docs.fleeks.dev")),
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return initializeConfig()
+		client.SetUserAgent(Version, commandName(cmd))
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
+		if err := validateFlagDependencies(cmd); err != nil {
+			return err
+		}
+		if err := initializeConfig(); err != nil {
+			return err
+		}
+		if rawOutput {
+			// Execute reports errors itself so the raw stop isn't one
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+		}
+		if err := applyFlagDefaults(cmd); err != nil {
+			return err
+		}
+		return startSessionLog(cmd)
 	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
-	return rootCmd.Execute()
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	if errors.Is(err, client.ErrRawResponse) {
+		// --raw printed the response and stopped the command before rendering
+		err = nil
+	} else if err != nil && rawOutput && cmd.SilenceErrors {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+
+	exitCode := 0
+	if err != nil {
+		exitCode = 1
+		client.LogEvent("error", map[string]interface{}{"error": err.Error()})
+	}
+	client.LogEvent("exit", map[string]interface{}{
+		"exit_code":   exitCode,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	client.CloseSessionLog()
+	return err
+}
+
+// commandName identifies the invoked subcommand in the User-Agent, e.g.
+// "workspace.sync" for 'fleeks workspace sync'
+func commandName(cmd *cobra.Command) string {
+	parts := strings.Fields(cmd.CommandPath())
+	if len(parts) <= 1 {
+		return "root"
+	}
+	return strings.Join(parts[1:], ".")
+}
+
+// silenceUsage stops cobra from printing the usage text with the error a
+// command returns. Commands call it when the exit status itself is the
+// result, as with a drift check or a wait, so a failure is not a mistake in
+// how the command was called.
+func silenceUsage(cmd *cobra.Command) {
+	cmd.SilenceUsage = true
+}
+
+// startSessionLog opens the --log-file (or FLEEKS_LOG_FILE) session log and
+// records the command being run
+func startSessionLog(cmd *cobra.Command) error {
+	if logFile == "" {
+		logFile = os.Getenv("FLEEKS_LOG_FILE")
+	}
+	if logFile == "" {
+		return nil
+	}
+
+	path, err := expandPath(logFile)
+	if err != nil {
+		return err
+	}
+	if err := client.OpenSessionLog(path); err != nil {
+		return err
+	}
+
+	client.LogEvent("command", map[string]interface{}{
+		"command":     cmd.CommandPath(),
+		"args":        redactArgs(cmd, os.Args[1:]),
+		"version":     Version,
+		"environment": config.CurrentEnvironment().String(),
+		"config_file": viper.ConfigFileUsed(),
+		"api_url":     viper.GetString("api.base_url"),
+		"mock":        config.MockAPIsEnabled(),
+	})
+	return nil
+}
+
+// redactArgs hides the values of secret flags such as --api-key (or -k)
+func redactArgs(cmd *cobra.Command, args []string) []string {
+	redacted := make([]string, len(args))
+	hideNext, headerNext := false, false
+	for i, arg := range args {
+		switch {
+		case hideNext:
+			redacted[i] = "[REDACTED]"
+			hideNext = false
+			continue
+		case headerNext:
+			redacted[i] = redactHeaderArg(arg)
+			headerNext = false
+			continue
+		}
+		redacted[i] = arg
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			continue
+		}
+		if !strings.HasPrefix(arg, "--") {
+			redacted[i], hideNext = redactShorthandArg(cmd, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name == "header" {
+			// --header KEY=VALUE only hides the value of credential headers
+			if hasValue {
+				redacted[i] = arg[:strings.Index(arg, "=")+1] + redactHeaderArg(value)
+			} else {
+				headerNext = true
+			}
+			continue
+		}
+		if !isSecretFlag(cmd, name) {
+			continue
+		}
+		if hasValue {
+			redacted[i] = arg[:strings.Index(arg, "=")+1] + "[REDACTED]"
+		} else {
+			hideNext = true
+		}
+	}
+	return redacted
+}
+
+// redactShorthandArg hides the value of a secret flag given by its
+// shorthand, attached (-kSECRET, -k=SECRET, -vkSECRET) or as the next
+// argument, which it reports through hideNext
+func redactShorthandArg(cmd *cobra.Command, arg string) (redacted string, hideNext bool) {
+	shorthands := arg[1:]
+	for j := 0; j < len(shorthands); j++ {
+		flag := cmd.Flags().ShorthandLookup(shorthands[j : j+1])
+		if flag == nil {
+			return arg, false
+		}
+		if flag.NoOptDefVal != "" {
+			continue // a boolean takes no value, the next letter is another flag
+		}
+		// The rest of the argument is this flag's value
+		if !isSecretFlag(cmd, flag.Name) {
+			return arg, false
+		}
+		rest := shorthands[j+1:]
+		if rest == "" {
+			return arg, true
+		}
+		if strings.HasPrefix(rest, "=") {
+			return arg[:j+3] + "[REDACTED]", false
+		}
+		return arg[:j+2] + "[REDACTED]", false
+	}
+	return arg, false
+}
+
+// redactHeaderArg hides the value of a --header KEY=VALUE argument whose
+// header carries a credential
+func redactHeaderArg(value string) string {
+	key, _, ok := strings.Cut(value, "=")
+	if ok && client.IsSecretHeader(strings.TrimSpace(key)) {
+		return key + "=[REDACTED]"
+	}
+	return value
+}
+
+// isSecretFlag reports whether a flag, given by name or shorthand, holds a
+// credential
+func isSecretFlag(cmd *cobra.Command, name string) bool {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil && len(name) == 1 {
+		flag = cmd.Flags().ShorthandLookup(name)
+	}
+	if flag != nil {
+		name = flag.Name
+	}
+	return config.IsSecretKey(strings.ReplaceAll(name, "-", "_"))
 }
 
 func init() {
@@ -157,6 +350,17 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.fleeksconfig.yaml)")
 	rootCmd.PersistentFlags().StringVarP(&environment, "environment", "e", "", "environment to use (development, staging, production)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress informational output")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "do not pipe long output into $PAGER")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputTable, "output format (table, json, ndjson)")
+	rootCmd.PersistentFlags().StringArrayVar(&headers, "header", nil, "extra request header as KEY=VALUE (repeatable)")
+	rootCmd.PersistentFlags().BoolVar(&mock, "mock", false, "serve canned sample responses instead of calling the API (demos and UI work)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "append a structured log of commands, API calls and errors to this file (or set FLEEKS_LOG_FILE)")
+	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "skip TLS certificate verification for this invocation (local development only)")
+	rootCmd.PersistentFlags().BoolVar(&rawOutput, "raw", false, "print the unprocessed API response of GET-backed commands instead of rendering it (read-only)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "ignore locally cached workspace, template and capability lists and fetch them again")
+	registerFlagCompletion(rootCmd, "environment", completeValues(environmentValues...))
+	registerFlagCompletion(rootCmd, "output", completeValues(outputValues...))
 
 	// Register all subcommands
 	rootCmd.AddCommand(authCmd)
@@ -173,21 +377,25 @@ func init() {
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("🚀 Fleeks CLI\n")
-		fmt.Printf("Version:    %s\n", Version)
-		fmt.Printf("Git Commit: %s\n", GitCommit)
-		fmt.Printf("Built:      %s\n", BuildTime)
-		fmt.Printf("Platform:   Universal Multi-Agent Development\n")
-		fmt.Printf("\n🌟 Revolutionary Features: Multi-agent workflows, Hybrid local-cloud, Real-time streaming\n")
+	Long: `Show version information.
+
+Builds without ldflags (version "dev") fall back to the commit recorded by the
+Go toolchain. With --verbose, also show the Go version, VCS revision, commit
+time and dirty flag, and the versions of key dependencies.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showVersion()
 	},
 }
 
 // initializeConfig reads in config file and ENV variables if set
 func initializeConfig() error {
 	if cfgFile != "" {
+		path, err := expandPath(cfgFile)
+		if err != nil {
+			return err
+		}
 		// Use config file from the flag
-		viper.SetConfigFile(cfgFile)
+		viper.SetConfigFile(path)
 	} else {
 		// Find home directory
 		home, err := os.UserHomeDir()
@@ -201,6 +409,34 @@ func initializeConfig() error {
 		viper.SetConfigType("yaml")
 	}
 
+	// Apply the --environment flag without persisting it
+	if environment != "" {
+		config.SetEnvironmentOverride(environment)
+	}
+
+	if mock {
+		config.SetMockOverride(true)
+	}
+
+	// Status lines would get in the way of the raw response
+	if rawOutput {
+		client.SetRawResponses(true)
+		quiet = true
+	}
+
+	if len(headers) > 0 {
+		if err := client.SetExtraHeaders(headers); err != nil {
+			return err
+		}
+	}
+
+	// --insecure is deliberately kept out of viper so it can never be saved
+	if insecure {
+		client.SetInsecureSkipVerify(true)
+		fmt.Fprintf(os.Stderr, "%s TLS certificate verification is DISABLED (--insecure). Never use this against production.\n",
+			ui.New(color.FgRed, color.Bold).Sprint("⚠️  WARNING:"))
+	}
+
 	// Read in environment variables that match
 	viper.SetEnvPrefix("FLEEKS")
 	viper.AutomaticEnv()
@@ -212,6 +448,12 @@ func initializeConfig() error {
 		}
 	}
 
+	// A bad ui.theme shouldn't stop every command; fall back to auto
+	if err := ui.SetTheme(viper.GetString("ui.theme")); err != nil {
+		fmt.Fprintf(os.Stderr, "%s ui.theme: %v\n", ui.Yellow("⚠️"), err)
+		ui.SetTheme(string(ui.ThemeAuto))
+	}
+
 	return nil
 }
 
@@ -227,3 +469,8 @@ func GetEnvironment() string {
 func IsVerbose() bool {
 	return verbose
 }
+
+// IsQuiet returns whether informational output should be suppressed
+func IsQuiet() bool {
+	return quiet
+}
@@ -0,0 +1,275 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+const (
+	// shellDetachKey (Ctrl+]) leaves the shell running on the server and
+	// returns to the local prompt
+	shellDetachKey = 0x1d
+	// shellEOFKey (Ctrl+D) is sent when piped input ends
+	shellEOFKey = 0x04
+	// maxShellReconnects bounds consecutive failed reconnects to a session
+	maxShellReconnects = 10
+)
+
+// ShellSessionRequest opens a server-side shell, or re-attaches to one when
+// SessionID is set
+type ShellSessionRequest struct {
+	SessionID  string `json:"session_id,omitempty"`
+	Shell      string `json:"shell,omitempty"`
+	WorkingDir string `json:"working_dir,omitempty"`
+	Cols       int    `json:"cols,omitempty"`
+	Rows       int    `json:"rows,omitempty"`
+}
+
+// ShellSession is a persistent shell with its own PTY in the workspace. The
+// token authorizes one connection to it and is renewed on every attach.
+type ShellSession struct {
+	SessionID    string    `json:"session_id"`
+	Token        string    `json:"token,omitempty"`
+	Shell        string    `json:"shell"`
+	WorkingDir   string    `json:"working_dir"`
+	Status       string    `json:"status"` // attached, detached or exited
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+}
+
+// openShellSession creates a shell session or, with request.SessionID,
+// gets a fresh token for an existing one
+func openShellSession(apiClient *client.APIClient, projectID string, request ShellSessionRequest) (*ShellSession, error) {
+	var session ShellSession
+	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/shell/session", projectID)
+	if err := apiClient.POST(endpoint, request, &session); err != nil {
+		if request.SessionID != "" {
+			return nil, fmt.Errorf("failed to attach to shell session %s: %w", request.SessionID, err)
+		}
+		return nil, fmt.Errorf("failed to create shell session: %w", err)
+	}
+	if session.SessionID == "" {
+		session.SessionID = request.SessionID
+	}
+	return &session, nil
+}
+
+// shellStreamPath is the stream for one attachment to a session
+func shellStreamPath(projectID string, session *ShellSession) string {
+	query := url.Values{}
+	query.Set("session_id", session.SessionID)
+	if session.Token != "" {
+		query.Set("session_token", session.Token)
+	}
+	return fmt.Sprintf("/ws/terminal/%s/shell?%s", projectID, query.Encode())
+}
+
+// shellConnection relays a local terminal to a shell session. Stdin is read
+// by one goroutine for the whole session, so input typed while reconnecting
+// is sent once the connection is back.
+type shellConnection struct {
+	apiClient *client.APIClient
+	projectID string
+	session   *ShellSession
+	raw       bool             // stdin is a terminal in raw mode
+	signals   *signalForwarder // Ctrl+C and Ctrl+Z when stdin isn't raw
+
+	input       chan []byte
+	lastEventID string
+}
+
+// runShellSession attaches the terminal to the session until the shell
+// exits or the user detaches, reconnecting after network drops
+func runShellSession(apiClient *client.APIClient, projectID string, session *ShellSession) error {
+	conn := &shellConnection{
+		apiClient: apiClient,
+		projectID: projectID,
+		session:   session,
+		input:     make(chan []byte, 16),
+	}
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		guard, err := enterRawMode(fd)
+		if err != nil {
+			return err
+		}
+		defer guard.RestoreOnPanic()
+		defer guard.Restore()
+		conn.raw = true
+	} else {
+		conn.signals = forwardSignals()
+		defer conn.signals.Stop()
+	}
+	go readShellInput(os.Stdin, conn.input)
+
+	reconnectDelay := viper.GetDuration("streaming.reconnect_delay")
+	if reconnectDelay <= 0 {
+		reconnectDelay = 5 * time.Second
+	}
+
+	failures := 0
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			// Tokens are single-use, so each reconnect asks for a new one
+			renewed, err := openShellSession(apiClient, projectID, ShellSessionRequest{SessionID: session.SessionID})
+			if err == nil {
+				conn.session = renewed
+			}
+		}
+
+		done, detached, err := conn.attach(&failures)
+		if done {
+			if detached {
+				conn.println("")
+				conn.println(fmt.Sprintf("%s Detached from shell session %s (still running). Reattach with:",
+					ui.Yellow("⏸"), ui.Cyan(session.SessionID)))
+				conn.println("  " + ui.Cyan(fmt.Sprintf("fleeks terminal shell %s --attach %s", projectID, session.SessionID)))
+			}
+			return err
+		}
+
+		failures++
+		if failures > maxShellReconnects {
+			return fmt.Errorf("shell connection lost after %d reconnect attempts: %w", maxShellReconnects, err)
+		}
+		conn.println("")
+		conn.println(fmt.Sprintf("%s Connection lost (%v), reconnecting to session %s in %s...",
+			ui.Yellow("⚠️"), err, session.SessionID, reconnectDelay))
+		time.Sleep(reconnectDelay)
+	}
+}
+
+// attach runs one connection to the session. It returns done when the shell
+// exited or the user detached, and otherwise the error that dropped the
+// connection. failures is reset once the connection delivers output.
+func (c *shellConnection) attach(failures *int) (done, detached bool, err error) {
+	stream, err := c.apiClient.NewStreamReader(client.ResumePath(shellStreamPath(c.projectID, c.session), c.lastEventID))
+	if err != nil {
+		// Nothing to reconnect to if the very first attach fails
+		if c.lastEventID == "" && *failures == 0 {
+			return true, false, fmt.Errorf("failed to connect to shell session: %w", err)
+		}
+		return false, false, err
+	}
+	defer stream.Close()
+
+	if cols, rows, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		stream.Send(client.StreamMessage{
+			Type:     client.MessageResize,
+			Metadata: map[string]interface{}{"cols": cols, "rows": rows},
+		})
+	}
+
+	for {
+		select {
+		case chunk, ok := <-c.input:
+			if !ok {
+				// Piped input ended: let the shell see end-of-file
+				chunk = []byte{shellEOFKey}
+				c.input = nil
+			}
+			if i := bytes.IndexByte(chunk, shellDetachKey); i >= 0 && c.raw {
+				if i > 0 {
+					stream.Send(client.StreamMessage{Type: client.MessageInput, Content: string(chunk[:i])})
+				}
+				return true, true, nil
+			}
+			if err := stream.Send(client.StreamMessage{Type: client.MessageInput, Content: string(chunk)}); err != nil {
+				return false, false, err
+			}
+
+		case sig := <-c.signals.C():
+			// A double Ctrl+C leaves the shell running, like Ctrl+]
+			if c.signals.Quit(sig) {
+				return true, true, nil
+			}
+			if err := stream.Send(c.signals.Message(sig, true)); err != nil {
+				return false, false, err
+			}
+
+		case msg, ok := <-stream.Messages():
+			if !ok {
+				// The reader closes both channels when it stops; an error
+				// left behind means the connection dropped rather than ended
+				if err, ok := <-stream.Errors(); ok {
+					return false, false, err
+				}
+				return true, false, nil
+			}
+			*failures = 0
+			if id := msg.EventID(); id != "" {
+				c.lastEventID = id
+			}
+			switch msg.KnownType() {
+			case client.MessageOutput:
+				os.Stdout.WriteString(msg.Content)
+			case client.MessageError:
+				c.println(ui.Red(msg.Content))
+			case client.MessageExit, client.MessageComplete:
+				return true, false, nil
+			default:
+				logUnknownMessage("shell", msg)
+			}
+
+		case err, ok := <-stream.Errors():
+			if !ok {
+				return true, false, nil
+			}
+			return false, false, err
+		}
+	}
+}
+
+// readShellInput forwards stdin to input until it ends
+func readShellInput(r io.Reader, input chan<- []byte) {
+	defer close(input)
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			input <- chunk
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// println writes a local status line, which needs an explicit carriage
+// return while the terminal is in raw mode
+func (c *shellConnection) println(line string) {
+	if c.raw {
+		fmt.Print(line + "\r\n")
+		return
+	}
+	fmt.Println(line)
+}
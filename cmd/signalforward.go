@@ -0,0 +1,123 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// doubleInterruptWindow is how soon a second Ctrl+C must follow the first
+// to stop fleeks itself rather than being forwarded
+const doubleInterruptWindow = 2 * time.Second
+
+// forwardedSignal is a local signal that interactive commands pass on to
+// the remote process
+type forwardedSignal struct {
+	signal os.Signal
+	name   string // sent in a signal message, e.g. "SIGINT"
+	key    byte   // the control key a PTY turns into the same signal
+}
+
+// signalForwarder catches Ctrl+C (and Ctrl+Z where the platform has it) so
+// they reach the remote process instead of killing the CLI, like docker and
+// kubectl exec. Pressing Ctrl+C twice in quick succession still quits.
+//
+// It is only needed while stdin is not in raw mode: in raw mode the keys
+// arrive as input bytes and are forwarded with the rest of the input.
+//
+//	signals := forwardSignals()
+//	defer signals.Stop()
+//	...
+//	case sig := <-signals.C():
+//		if signals.Quit(sig) {
+//			return errInterrupted
+//		}
+//		stream.Send(signals.Message(sig, hasPTY))
+type signalForwarder struct {
+	c             chan os.Signal
+	lastInterrupt time.Time
+}
+
+// errInterrupted is returned when the user quits with a double Ctrl+C
+var errInterrupted = errors.New("interrupted; the remote command may still be running")
+
+// forwardSignals starts catching the signals in forwardedSignals
+func forwardSignals() *signalForwarder {
+	f := &signalForwarder{c: make(chan os.Signal, 1)}
+	sigs := make([]os.Signal, 0, len(forwardedSignals))
+	for _, fs := range forwardedSignals {
+		sigs = append(sigs, fs.signal)
+	}
+	signal.Notify(f.c, sigs...)
+	return f
+}
+
+// C delivers the caught signals. A nil forwarder never delivers, so callers
+// can select on it whether or not forwarding is active.
+func (f *signalForwarder) C() <-chan os.Signal {
+	if f == nil {
+		return nil
+	}
+	return f.c
+}
+
+// Stop restores the default signal handling
+func (f *signalForwarder) Stop() {
+	if f != nil {
+		signal.Stop(f.c)
+	}
+}
+
+// Quit reports whether sig is a second Ctrl+C within doubleInterruptWindow
+// of the first. After a first Ctrl+C it prints how to quit instead.
+func (f *signalForwarder) Quit(sig os.Signal) bool {
+	if sig != os.Interrupt {
+		return false
+	}
+	now := time.Now()
+	if !f.lastInterrupt.IsZero() && now.Sub(f.lastInterrupt) <= doubleInterruptWindow {
+		return true
+	}
+	f.lastInterrupt = now
+	fmt.Fprintf(os.Stderr, "\n%s Sent Ctrl+C to the remote command (press Ctrl+C again to quit fleeks)\n",
+		ui.Yellow("⚠️"))
+	return false
+}
+
+// Message is the stream message that delivers sig to the remote process. A
+// PTY gets the control key as input, so its line discipline signals the
+// foreground process group exactly as a local terminal would; a command
+// without one gets a signal message.
+func (f *signalForwarder) Message(sig os.Signal, pty bool) client.StreamMessage {
+	for _, fs := range forwardedSignals {
+		if fs.signal != sig {
+			continue
+		}
+		if pty {
+			return client.StreamMessage{Type: client.MessageInput, Content: string([]byte{fs.key})}
+		}
+		return client.StreamMessage{Type: client.MessageSignal, Content: fs.name}
+	}
+	return client.StreamMessage{Type: client.MessageSignal, Content: sig.String()}
+}
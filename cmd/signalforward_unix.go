@@ -0,0 +1,30 @@
+//go:build !windows
+
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"syscall"
+)
+
+// forwardedSignals are Ctrl+C and Ctrl+Z
+var forwardedSignals = []forwardedSignal{
+	{signal: os.Interrupt, name: "SIGINT", key: 0x03},
+	{signal: syscall.SIGTSTP, name: "SIGTSTP", key: 0x1a},
+}
@@ -0,0 +1,268 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// ssoClientID identifies the CLI to the Fleeks OAuth server
+const ssoClientID = "fleeks-cli"
+
+// ssoLoginTimeout is how long 'auth login --sso' waits for the user to
+// finish signing in, in the browser or with a device code
+const ssoLoginTimeout = 5 * time.Minute
+
+// Grant types sent to the token endpoint
+const (
+	grantAuthorizationCode = "authorization_code"
+	grantDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// DeviceCodeResponse is the server's answer to a device authorization request
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// ssoLogin signs the user in through their identity provider and returns the
+// tokens issued for the CLI. A browser is opened on a local callback when one
+// is available; otherwise the user is given a code to enter on another device.
+func ssoLogin(apiClient *client.APIClient, baseURL string, noBrowser bool) (*AuthResponse, error) {
+	if !noBrowser && browserAvailable() {
+		tokens, err := browserLogin(apiClient, baseURL)
+		if !errors.Is(err, errBrowserUnavailable) {
+			return tokens, err
+		}
+		fmt.Printf("%s Couldn't open a browser; signing in with a device code instead\n", ui.Yellow("⚠️"))
+	}
+	return deviceCodeLogin(apiClient)
+}
+
+// errBrowserUnavailable means the browser flow couldn't start, so the device
+// code flow should be used instead
+var errBrowserUnavailable = errors.New("no browser available")
+
+// browserAvailable reports whether a browser can likely be opened. On Linux
+// and the BSDs that needs a graphical session (or WSL); SSH sessions and
+// containers usually have none.
+func browserAvailable() bool {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		return true
+	}
+	for _, name := range []string{"DISPLAY", "WAYLAND_DISPLAY", "WSL_DISTRO_NAME"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// browserLogin runs the authorization code flow with PKCE: the login page is
+// opened in the browser, which redirects back to a listener on localhost
+// with a code that is then exchanged for tokens
+func browserLogin(apiClient *client.APIClient, baseURL string) (*AuthResponse, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the login callback listener: %w", err)
+	}
+	defer listener.Close()
+
+	state, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr())
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", ssoClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	loginURL := strings.TrimRight(baseURL, "/") + "/api/v1/auth/oauth/authorize?" + query.Encode()
+
+	codes := make(chan string, 1)
+	failures := make(chan error, 1)
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+			params := r.URL.Query()
+			switch {
+			case params.Get("state") != state:
+				// Not our request; ignore it rather than abort the login
+				http.Error(w, "Invalid login state.", http.StatusBadRequest)
+				return
+			case params.Get("error") != "":
+				msg := params.Get("error")
+				if desc := params.Get("error_description"); desc != "" {
+					msg += ": " + desc
+				}
+				fmt.Fprintln(w, "Sign-in failed. You can close this window and return to the terminal.")
+				select {
+				case failures <- fmt.Errorf("sign-in failed: %s", msg):
+				default:
+				}
+			case params.Get("code") == "":
+				http.Error(w, "Missing authorization code.", http.StatusBadRequest)
+				return
+			default:
+				fmt.Fprintln(w, "Signed in to Fleeks. You can close this window and return to the terminal.")
+				select {
+				case codes <- params.Get("code"):
+				default:
+				}
+			}
+		}),
+	}
+	go server.Serve(listener)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+
+	if err := openURL(loginURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", errBrowserUnavailable, err)
+	}
+	fmt.Printf("%s Opened your browser to sign in. If it didn't open, visit:\n  %s\n",
+		ui.Cyan("🌐"), ui.Cyan(loginURL))
+	fmt.Printf("%s Waiting for sign-in to finish...\n", ui.Cyan("⏳"))
+
+	var code string
+	select {
+	case code = <-codes:
+	case err := <-failures:
+		return nil, err
+	case <-time.After(ssoLoginTimeout):
+		return nil, fmt.Errorf("timed out after %s waiting for sign-in", ssoLoginTimeout)
+	}
+
+	return exchangeToken(apiClient, map[string]string{
+		"grant_type":    grantAuthorizationCode,
+		"client_id":     ssoClientID,
+		"code":          code,
+		"code_verifier": verifier,
+		"redirect_uri":  redirectURI,
+	})
+}
+
+// deviceCodeLogin runs the device authorization flow: the user opens a URL
+// on any device and enters the code shown, while the CLI polls for tokens
+func deviceCodeLogin(apiClient *client.APIClient) (*AuthResponse, error) {
+	var device DeviceCodeResponse
+	if err := apiClient.POST("/api/v1/auth/oauth/device/code", map[string]string{
+		"client_id": ssoClientID,
+	}, &device); err != nil {
+		return nil, fmt.Errorf("failed to start device sign-in: %w", err)
+	}
+
+	fmt.Printf("\nTo sign in, open %s and enter the code:\n\n    %s\n\n",
+		ui.Cyan(device.VerificationURI), ui.New(color.Bold).Sprint(device.UserCode))
+	if device.VerificationURIComplete != "" {
+		fmt.Printf("Or open this link directly:\n  %s\n\n", ui.Cyan(device.VerificationURIComplete))
+	}
+	fmt.Printf("%s Waiting for sign-in to finish...\n", ui.Cyan("⏳"))
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	expiresIn := time.Duration(device.ExpiresIn) * time.Second
+	if expiresIn <= 0 || expiresIn > ssoLoginTimeout {
+		expiresIn = ssoLoginTimeout
+	}
+	deadline := time.Now().Add(expiresIn)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+		tokens, err := exchangeToken(apiClient, map[string]string{
+			"grant_type":  grantDeviceCode,
+			"client_id":   ssoClientID,
+			"device_code": device.DeviceCode,
+		})
+		var apiErr *client.ErrorResponse
+		if errors.As(err, &apiErr) {
+			switch apiErr.Message {
+			case "authorization_pending":
+				continue
+			case "slow_down":
+				interval += 5 * time.Second
+				continue
+			case "access_denied":
+				return nil, fmt.Errorf("sign-in was denied")
+			case "expired_token":
+				return nil, fmt.Errorf("the sign-in code expired; run 'fleeks auth login --sso' again")
+			}
+		}
+		return tokens, err
+	}
+	return nil, fmt.Errorf("the sign-in code expired; run 'fleeks auth login --sso' again")
+}
+
+// exchangeToken posts a grant to the token endpoint
+func exchangeToken(apiClient *client.APIClient, grant map[string]string) (*AuthResponse, error) {
+	var tokens AuthResponse
+	if err := apiClient.POST("/api/v1/auth/oauth/token", grant, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to exchange the sign-in code: %w", err)
+	}
+	if tokens.AccessToken == "" {
+		return nil, fmt.Errorf("the server returned no access token")
+	}
+	return &tokens, nil
+}
+
+// randomToken returns n random bytes, base64url-encoded
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate a random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
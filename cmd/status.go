@@ -0,0 +1,148 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"golang.org/x/term"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// statusReporter shows progress through a multi-step operation. Each step
+// runs behind a spinner and ends with a check or a cross:
+//
+//	✓ Workspace created
+//	✓ Local directory ready
+//	⏳ Waiting for container...
+//
+// Without a terminal it prints plain lines instead, and --quiet or JSON
+// output hides it entirely. Steps can be nested under a Group heading.
+type statusReporter struct {
+	out    io.Writer
+	tty    bool
+	quiet  bool
+	indent string
+
+	spinner *spinner.Spinner
+	label   string
+}
+
+func newStatusReporter() *statusReporter {
+	return &statusReporter{
+		out:   os.Stdout,
+		tty:   term.IsTerminal(int(os.Stdout.Fd())),
+		quiet: IsQuiet() || isJSONOutput() || isNDJSONOutput(),
+	}
+}
+
+// Group prints a heading, finishing any running step, and returns a
+// reporter for the steps nested under it
+func (r *statusReporter) Group(label string) *statusReporter {
+	if r.label != "" {
+		r.Done("")
+	}
+	if !r.quiet {
+		fmt.Fprintf(r.out, "%s%s %s\n", r.indent, ui.Cyan("▸"), label)
+	}
+	return &statusReporter{out: r.out, tty: r.tty, quiet: r.quiet, indent: r.indent + "  "}
+}
+
+// Start begins a step, finishing any step still running as done
+func (r *statusReporter) Start(label string) {
+	if r.label != "" {
+		r.Done("")
+	}
+	r.label = label
+	if r.quiet {
+		return
+	}
+	if !r.tty {
+		fmt.Fprintf(r.out, "%s- %s...\n", r.indent, label)
+		return
+	}
+	r.spinner = spinner.New(spinner.CharSets[14], 100*time.Millisecond, spinner.WithWriter(r.out))
+	r.spinner.Prefix = r.indent
+	r.spinner.Suffix = " " + label + "..."
+	r.spinner.Start()
+}
+
+// Update shows progress detail for the running step
+func (r *statusReporter) Update(detail string) {
+	if r.spinner != nil {
+		r.spinner.Lock()
+		r.spinner.Suffix = fmt.Sprintf(" %s... %s", r.label, detail)
+		r.spinner.Unlock()
+	}
+}
+
+// Done marks the running step as succeeded. A non-empty result replaces
+// the step label in the final line.
+func (r *statusReporter) Done(result string) {
+	r.finish(ui.Green("✓"), result)
+}
+
+// Skip marks the running step as not needed
+func (r *statusReporter) Skip(reason string) {
+	label := r.label
+	if reason != "" {
+		label = fmt.Sprintf("%s (%s)", label, reason)
+	}
+	r.finish(ui.Muted("-"), ui.Muted(label))
+}
+
+// Warn marks the running step as failed without failing the operation
+func (r *statusReporter) Warn(message string) {
+	r.finish(ui.Yellow("!"), fmt.Sprintf("%s: %s", r.label, message))
+}
+
+// Fail marks the running step as failed and returns err for convenience
+func (r *statusReporter) Fail(err error) error {
+	r.finish(ui.Red("✗"), fmt.Sprintf("%s: %v", r.label, err))
+	return err
+}
+
+// Stop ends a step left running by an early return, without a mark
+func (r *statusReporter) Stop() {
+	if r.spinner != nil {
+		r.spinner.Stop()
+		r.spinner = nil
+	}
+	r.label = ""
+}
+
+func (r *statusReporter) finish(mark, text string) {
+	if text == "" {
+		text = r.label
+	}
+	if r.spinner != nil {
+		r.spinner.Stop()
+		r.spinner = nil
+	}
+	label := r.label
+	r.label = ""
+	if r.quiet || label == "" {
+		return
+	}
+	fmt.Fprintf(r.out, "%s%s %s\n", r.indent, mark, strings.TrimSpace(text))
+}
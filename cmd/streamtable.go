@@ -0,0 +1,99 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// streamTable prints rows as they arrive, one page at a time, for listings
+// too large to collect into a listTable. Column widths are measured on the
+// first page and kept for the rest. On a terminal, later cells that are
+// wider are truncated rather than shifting the columns; piped output is never
+// truncated.
+type streamTable struct {
+	cmd      *cobra.Command
+	record   interface{} // zero value of the listed type, for --columns
+	headers  []string
+	widths   []int
+	truncate bool
+	rows     int
+}
+
+func newStreamTable(cmd *cobra.Command, record interface{}, headers ...string) *streamTable {
+	return &streamTable{cmd: cmd, record: record, headers: headers}
+}
+
+// WritePage prints a page of rows, each with the record it was built from.
+// The header is printed with the first page.
+func (t *streamTable) WritePage(rows [][]string, records []interface{}) error {
+	page := newListTable(t.headers...)
+	if err := page.SelectColumns(t.cmd, t.record); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		var record interface{}
+		if i < len(records) {
+			record = records[i]
+		}
+		page.AppendRecord(row, record)
+	}
+	page.applyColumns()
+
+	if t.widths == nil {
+		t.widths = page.columnWidths()
+		if page.width > 0 {
+			page.fitColumns(t.widths)
+			t.truncate = true
+		}
+		t.printRow(page.headers, ui.New(color.Bold, color.FgHiCyan))
+	}
+	for _, row := range page.rows {
+		if t.truncate {
+			row = fitRow(row, t.widths)
+		}
+		t.printRow(row, nil)
+	}
+	t.rows += len(page.rows)
+	return nil
+}
+
+// Rows returns the number of rows printed so far
+func (t *streamTable) Rows() int {
+	return t.rows
+}
+
+func (t *streamTable) printRow(row []string, style *color.Color) {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		// The last column isn't padded, so lines carry no trailing spaces
+		if i < len(row)-1 && i < len(t.widths) {
+			cell += strings.Repeat(" ", max(t.widths[i]-displayWidth(cell), 0))
+		}
+		if style != nil {
+			cell = style.Sprint(cell)
+		}
+		cells[i] = cell
+	}
+	fmt.Println(strings.Join(cells, "  "))
+}
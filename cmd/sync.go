@@ -0,0 +1,455 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// syncDeleteLimit is the most files 'workspace sync --delete' removes without
+// --force, as a guard against syncing from the wrong directory
+const syncDeleteLimit = 25
+
+// Sync directions for 'workspace sync --direction'
+const (
+	syncPush = "push" // local to cloud
+	syncPull = "pull" // cloud to local
+	syncBoth = "both" // whichever side changed since the last sync
+)
+
+// workspaceSyncer syncs a local workspace directory with the root of its
+// cloud workspace
+type workspaceSyncer struct {
+	cfg       *config.Config
+	apiClient *client.APIClient
+	projectID string
+	localRoot string
+	excludes  []string
+}
+
+// excluded reports whether a path relative to the workspace root is skipped,
+// either by workspace.ignore_patterns or by --exclude
+func (s *workspaceSyncer) excluded(relPath string) bool {
+	if s.cfg.ShouldIgnoreFile(relPath) {
+		return true
+	}
+	for _, pattern := range s.excludes {
+		if ok, _ := path.Match(pattern, relPath); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(relPath)); ok {
+			return true
+		}
+		// A pattern naming a directory excludes everything under it
+		if strings.HasPrefix(relPath, strings.TrimSuffix(pattern, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// localFiles maps the remote path of every synced local file to its local path
+func (s *workspaceSyncer) localFiles() (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.Walk(s.localRoot, func(localPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(s.localRoot, localPath)
+		if err != nil || relPath == "." {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if s.excluded(relPath) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			files["/"+relPath] = localPath
+		}
+		return nil
+	})
+	return files, err
+}
+
+// remoteFiles lists the remote path of every synced file in the cloud
+// workspace, leaving out anything excluded from the sync
+func (s *workspaceSyncer) remoteFiles() (map[string]bool, error) {
+	files := make(map[string]bool)
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s?path=%s&recursive=true", s.projectID, url.QueryEscape("/"))
+	err := listFilePages(s.apiClient, endpoint, defaultFilePageSize, func(page []FileInfo) error {
+		for _, entry := range page {
+			if entry.Type == "directory" {
+				continue
+			}
+			remotePath := "/" + strings.TrimPrefix(entry.Path, "/")
+			if !s.excluded(strings.TrimPrefix(remotePath, "/")) {
+				files[remotePath] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote files: %w", err)
+	}
+	return files, nil
+}
+
+// staleRemoteFiles lists remote files that no longer exist locally
+func (s *workspaceSyncer) staleRemoteFiles(local map[string]string) ([]string, error) {
+	remote, err := s.remoteFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for remotePath := range remote {
+		if _, ok := local[remotePath]; !ok {
+			stale = append(stale, remotePath)
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// localPath returns where a remote file lives in the local workspace
+func (s *workspaceSyncer) localPath(remotePath string) string {
+	return filepath.Join(s.localRoot, filepath.FromSlash(strings.TrimPrefix(remotePath, "/")))
+}
+
+func (s *workspaceSyncer) deleteRemote(remotePath string) error {
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/delete?path=%s", s.projectID, url.QueryEscape(remotePath))
+	return s.apiClient.DELETE(endpoint, nil)
+}
+
+// push uploads changed local files, skipping unchanged ones
+func (s *workspaceSyncer) push(local map[string]string) (*deltaUploader, *BulkResult) {
+	uploader := newDeltaUploader(s.apiClient, s.projectID, true)
+
+	sp := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
+	sp.Suffix = fmt.Sprintf(" Syncing %d files...", len(local))
+	sp.Start()
+	defer sp.Stop()
+	uploader.progress = func(status string) {
+		sp.Lock()
+		sp.Suffix = " " + status
+		sp.Unlock()
+	}
+
+	remotePaths := make([]string, 0, len(local))
+	for remotePath := range local {
+		remotePaths = append(remotePaths, remotePath)
+	}
+	sort.Strings(remotePaths)
+
+	result := newBulkResult("file", "sync")
+	for _, remotePath := range remotePaths {
+		result.Record(remotePath, uploader.upload(local[remotePath], remotePath))
+	}
+
+	// Keep whatever was recorded even if a later file failed
+	if saveErr := uploader.state.save(); saveErr != nil && IsVerbose() {
+		fmt.Printf("%s Could not save sync state: %v\n", ui.Yellow("⚠️"), saveErr)
+	}
+	return uploader, result
+}
+
+// preview reports which local files differ from the workspace without
+// uploading them, for --dry-run
+func (s *workspaceSyncer) preview(local map[string]string) error {
+	entries := make([]ChecksumEntry, 0, len(local))
+	for remotePath, localPath := range local {
+		entry, err := hashLocalFile(localPath, remotePath)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	report, err := compareEntries(s.apiClient, s.projectID, entries)
+	if err != nil {
+		return err
+	}
+	printChecksumReport(report)
+	return nil
+}
+
+// syncDeletions removes remote files deleted locally, after the guard rails
+// of confirmDeletions
+func (s *workspaceSyncer) syncDeletions(local map[string]string, dryRun, force, assumeYes bool) error {
+	stale, err := s.staleRemoteFiles(local)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		fmt.Printf("%s No remote files to delete\n", ui.Green("✅"))
+		return nil
+	}
+
+	fmt.Printf("\n%s %d remote file(s) no longer present locally:\n", ui.Red("🗑️"), len(stale))
+	if len(local) == 0 && !force {
+		return fmt.Errorf("local workspace %s is empty; refusing to delete every remote file without --force", s.localRoot)
+	}
+	if ok, err := confirmDeletions(stale, "remote", dryRun, force, assumeYes); !ok || err != nil {
+		return err
+	}
+
+	state := loadSyncState(s.projectID)
+	defer state.save()
+
+	deleted := 0
+	for _, remotePath := range stale {
+		if err := s.deleteRemote(remotePath); err != nil {
+			return fmt.Errorf("deleted %d of %d files; failed to delete %s: %w", deleted, len(stale), remotePath, err)
+		}
+		state.forget(remotePath)
+		deleted++
+	}
+	fmt.Printf("%s Deleted %d remote file(s)\n", ui.Green("✅"), deleted)
+	return nil
+}
+
+// syncLocalDeletions removes local files deleted from the cloud workspace,
+// for --direction pull, after the guard rails of confirmDeletions
+func (s *workspaceSyncer) syncLocalDeletions(local map[string]string, remote map[string]bool, dryRun, force, assumeYes bool) error {
+	var stale []string
+	for remotePath := range local {
+		if !remote[remotePath] {
+			stale = append(stale, remotePath)
+		}
+	}
+	sort.Strings(stale)
+	if len(stale) == 0 {
+		fmt.Printf("%s No local files to delete\n", ui.Green("✅"))
+		return nil
+	}
+
+	fmt.Printf("\n%s %d local file(s) no longer present in the workspace:\n", ui.Red("🗑️"), len(stale))
+	if len(remote) == 0 && !force {
+		return fmt.Errorf("cloud workspace %s is empty; refusing to delete every local file without --force", s.projectID)
+	}
+	if ok, err := confirmDeletions(stale, "local", dryRun, force, assumeYes); !ok || err != nil {
+		return err
+	}
+
+	state := loadSyncState(s.projectID)
+	defer state.save()
+
+	deleted := 0
+	for _, remotePath := range stale {
+		if err := os.Remove(local[remotePath]); err != nil {
+			return fmt.Errorf("deleted %d of %d files; failed to delete %s: %w", deleted, len(stale), local[remotePath], err)
+		}
+		state.forget(remotePath)
+		deleted++
+	}
+	fmt.Printf("%s Deleted %d local file(s)\n", ui.Green("✅"), deleted)
+	return nil
+}
+
+// confirmDeletions lists the files about to be deleted on one side of a sync
+// and reports whether to go ahead: never for --dry-run, more than
+// syncDeleteLimit deletions needs --force, and anything else is confirmed
+// unless --yes.
+func confirmDeletions(paths []string, side string, dryRun, force, assumeYes bool) (bool, error) {
+	for _, p := range paths {
+		fmt.Printf("  %s %s\n", ui.Red("-"), ui.Cyan(p))
+	}
+	if dryRun {
+		fmt.Printf("\nWould delete %d file(s) (dry run, nothing deleted)\n", len(paths))
+		return false, nil
+	}
+
+	if !force && len(paths) > syncDeleteLimit {
+		return false, fmt.Errorf("refusing to delete %d %s files (more than %d) without --force", len(paths), side, syncDeleteLimit)
+	}
+
+	if !assumeYes {
+		fmt.Printf("\n%s Delete these %d %s file(s)? [y/N] ", ui.Red("⚠️"), len(paths), side)
+
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println("Deletion cancelled.")
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// syncPlan is what a pull or two-way sync will change
+type syncPlan struct {
+	uploads   []string // remote paths to push
+	downloads []string // remote paths to pull
+	conflicts []string // changed on both sides since the last sync
+}
+
+// plan compares the local and remote workspaces for --direction pull or
+// both. Pulling takes every remote file that is missing or differs locally.
+// A two-way sync uses the versions recorded at the last sync to tell which
+// side changed: files changed on both sides are conflicts and are left
+// alone.
+func (s *workspaceSyncer) plan(direction string, local map[string]string, remote map[string]bool) (*syncPlan, error) {
+	entries := make([]ChecksumEntry, 0, len(local))
+	for remotePath, localPath := range local {
+		entry, err := hashLocalFile(localPath, remotePath)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	report, err := compareEntries(s.apiClient, s.projectID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	state := loadSyncState(s.projectID)
+	plan := &syncPlan{}
+	for _, entry := range report.Files {
+		switch {
+		case entry.Status == checksumUnchanged:
+		case entry.Status == checksumAdded:
+			if direction == syncBoth {
+				plan.uploads = append(plan.uploads, entry.RemotePath)
+			}
+		case direction == syncPull:
+			plan.downloads = append(plan.downloads, entry.RemotePath)
+		default:
+			last, ok := state.get(entry.RemotePath)
+			switch {
+			case ok && last.SHA256 == entry.RemoteSHA256:
+				plan.uploads = append(plan.uploads, entry.RemotePath)
+			case ok && last.SHA256 == entry.LocalSHA256:
+				plan.downloads = append(plan.downloads, entry.RemotePath)
+			default:
+				plan.conflicts = append(plan.conflicts, entry.RemotePath)
+			}
+		}
+	}
+
+	var remoteOnly []string
+	for remotePath := range remote {
+		if _, ok := local[remotePath]; !ok {
+			remoteOnly = append(remoteOnly, remotePath)
+		}
+	}
+	sort.Strings(remoteOnly)
+	plan.downloads = append(plan.downloads, remoteOnly...)
+	sort.Strings(plan.downloads)
+	return plan, nil
+}
+
+// print lists the changes of a plan, for --dry-run
+func (p *syncPlan) print() {
+	for _, remotePath := range p.uploads {
+		fmt.Printf("%s %s\n", ui.Green("%-9s", "PUSH"), ui.Cyan(remotePath))
+	}
+	for _, remotePath := range p.downloads {
+		fmt.Printf("%s %s\n", ui.Blue("%-9s", "PULL"), ui.Cyan(remotePath))
+	}
+	p.printConflicts()
+	if len(p.uploads)+len(p.downloads) == 0 && len(p.conflicts) == 0 {
+		fmt.Printf("%s Local and cloud workspaces match\n", ui.Green("✅"))
+		return
+	}
+	fmt.Printf("\nWould push %d and pull %d file(s) (dry run, nothing changed)\n", len(p.uploads), len(p.downloads))
+}
+
+func (p *syncPlan) printConflicts() {
+	for _, remotePath := range p.conflicts {
+		fmt.Printf("%s %s\n", ui.Red("%-9s", "CONFLICT"), ui.Cyan(remotePath))
+	}
+	if len(p.conflicts) > 0 {
+		fmt.Printf("\n%s %d file(s) changed on both sides and are left alone; use --direction push or pull to choose which copy wins\n",
+			ui.Yellow("⚠️"), len(p.conflicts))
+	}
+}
+
+// apply carries out a plan. Downloaded files are recorded in the sync state
+// like uploaded ones, so the next two-way sync knows both sides agree. A file
+// that fails is recorded in the returned result and the rest still sync.
+func (s *workspaceSyncer) apply(plan *syncPlan, local map[string]string) (*deltaUploader, *BulkResult) {
+	uploader := newDeltaUploader(s.apiClient, s.projectID, true)
+
+	sp := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
+	sp.Suffix = fmt.Sprintf(" Syncing %d files...", len(plan.uploads)+len(plan.downloads))
+	sp.Start()
+	uploader.progress = func(status string) {
+		sp.Lock()
+		sp.Suffix = " " + status
+		sp.Unlock()
+	}
+
+	result := newBulkResult("file", "sync")
+	for _, remotePath := range plan.uploads {
+		if err := uploader.upload(local[remotePath], remotePath); err != nil {
+			result.Record(remotePath, fmt.Errorf("push: %w", err))
+			continue
+		}
+		result.Record(remotePath, nil)
+	}
+	pulled := 0
+	for _, remotePath := range plan.downloads {
+		sp.Lock()
+		sp.Suffix = " Pulling " + remotePath
+		sp.Unlock()
+		err := s.pull(uploader, remotePath)
+		result.Record(remotePath, err)
+		if err == nil {
+			pulled++
+		}
+	}
+	sp.Stop()
+
+	// Keep whatever was recorded even if a later file failed
+	if saveErr := uploader.state.save(); saveErr != nil && IsVerbose() {
+		fmt.Printf("%s Could not save sync state: %v\n", ui.Yellow("⚠️"), saveErr)
+	}
+
+	fmt.Printf("%s Pulled %d file(s)\n", ui.Cyan("📥"), pulled)
+	plan.printConflicts()
+	return uploader, result
+}
+
+// pull downloads one file and records it in the sync state
+func (s *workspaceSyncer) pull(uploader *deltaUploader, remotePath string) error {
+	localPath := s.localPath(remotePath)
+	if err := downloadSingleFile(s.apiClient, s.projectID, remotePath, localPath); err != nil {
+		return fmt.Errorf("pull: %w", err)
+	}
+	content, err := os.ReadFile(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+	uploader.record(remotePath, content, sha256Hex(content))
+	return nil
+}
@@ -0,0 +1,368 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/state"
+)
+
+const (
+	// deltaBlockSize is the fixed block size used to diff file versions
+	deltaBlockSize = 64 * 1024
+	// deltaMinSize is the smallest file worth sending as a delta
+	deltaMinSize = 1024 * 1024
+)
+
+// fileNotFoundError is the error code the API returns for a path that
+// doesn't exist, as opposed to a missing workspace or endpoint
+const fileNotFoundError = "file_not_found"
+
+// fileSyncState records what was last uploaded for a remote path
+type fileSyncState struct {
+	SHA256 string   `json:"sha256"`
+	Size   int64    `json:"size"`
+	Blocks []string `json:"blocks,omitempty"`
+}
+
+// syncStore holds the record of uploaded file versions, keyed by project
+// and remote path
+var syncStore = state.Open("sync")
+
+// syncState is one project's record of uploaded file versions. It is kept
+// in memory during an upload and only the paths that changed are written
+// back, so concurrent uploads to the same project don't undo each other.
+type syncState struct {
+	mu        sync.Mutex
+	projectID string
+	files     map[string]fileSyncState
+	changed   map[string]bool
+	legacy    string // pre-store state file to remove once migrated
+}
+
+// loadSyncState reads the sync state for a project, starting empty if none exists
+func loadSyncState(projectID string) *syncState {
+	s := &syncState{
+		projectID: projectID,
+		files:     make(map[string]fileSyncState),
+		changed:   make(map[string]bool),
+	}
+	values, err := syncStore.Project(projectID)
+	if err == nil {
+		for remotePath, raw := range values {
+			var entry fileSyncState
+			if json.Unmarshal(raw, &entry) == nil {
+				s.files[remotePath] = entry
+			}
+		}
+	}
+	if len(s.files) == 0 {
+		s.loadLegacy()
+	}
+	return s
+}
+
+// loadLegacy picks up the ~/.fleeks/sync/<project-id>.json file written
+// before the state store existed; it is removed after the next save
+func (s *syncState) loadLegacy() {
+	path := filepath.Join(config.GetStateDir(), "sync", s.projectID+".json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var legacy struct {
+		Files map[string]fileSyncState `json:"files"`
+	}
+	if json.Unmarshal(content, &legacy) != nil {
+		return
+	}
+	for remotePath, entry := range legacy.Files {
+		s.files[remotePath] = entry
+		s.changed[remotePath] = true
+	}
+	s.legacy = path
+}
+
+func (s *syncState) get(remotePath string) (fileSyncState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.files[remotePath]
+	return entry, ok
+}
+
+func (s *syncState) set(remotePath string, entry fileSyncState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[remotePath] = entry
+	s.changed[remotePath] = true
+}
+
+func (s *syncState) forget(remotePath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, remotePath)
+	s.changed[remotePath] = true
+}
+
+// save writes the paths changed since the last save to the store
+func (s *syncState) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.changed) == 0 {
+		return nil
+	}
+	err := syncStore.Update(func(tx *state.Tx) error {
+		for remotePath := range s.changed {
+			entry, ok := s.files[remotePath]
+			if !ok {
+				tx.Delete(s.projectID, remotePath)
+				continue
+			}
+			if err := tx.Set(s.projectID, remotePath, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.changed = make(map[string]bool)
+	if s.legacy != "" {
+		os.Remove(s.legacy)
+		s.legacy = ""
+	}
+	return nil
+}
+
+// FileHashResponse is the server's view of a remote file's content
+type FileHashResponse struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// FileDeltaBlock carries one changed block of a delta upload
+type FileDeltaBlock struct {
+	Index   int    `json:"index"`
+	Content string `json:"content"` // base64 encoded
+}
+
+// FileDeltaRequest updates a remote file by replacing only changed blocks
+type FileDeltaRequest struct {
+	Path       string           `json:"path"`
+	BaseSHA256 string           `json:"base_sha256"`
+	SHA256     string           `json:"sha256"`
+	Size       int64            `json:"size"`
+	BlockSize  int              `json:"block_size"`
+	Blocks     []FileDeltaBlock `json:"blocks"`
+	Overwrite  bool             `json:"overwrite"`
+}
+
+// deltaUploader uploads files incrementally: unchanged files are skipped,
+// large changed files are sent as block deltas when the server supports it,
+// and everything else falls back to a full upload.
+type deltaUploader struct {
+	apiClient *client.APIClient
+	projectID string
+	overwrite bool
+	state     *syncState
+	progress  func(string)
+
+	mu         sync.Mutex
+	Uploaded   int
+	Deltas     int
+	Skipped    int
+	BytesSent  int64
+	BytesSaved int64
+}
+
+func newDeltaUploader(apiClient *client.APIClient, projectID string, overwrite bool) *deltaUploader {
+	return &deltaUploader{
+		apiClient: apiClient,
+		projectID: projectID,
+		overwrite: overwrite,
+		state:     loadSyncState(projectID),
+	}
+}
+
+// upload sends localPath to remotePath using the cheapest safe method
+func (u *deltaUploader) upload(localPath, remotePath string) error {
+	content, err := os.ReadFile(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	sum := sha256Hex(content)
+	size := int64(len(content))
+
+	remote, remoteErr := u.remoteHash(remotePath)
+	previous, known := u.state.get(remotePath)
+
+	switch {
+	case remoteErr == nil && remote == nil:
+		// The file doesn't exist remotely; any local record is stale
+		u.state.forget(remotePath)
+		known = false
+	case remoteErr == nil && remote.SHA256 == sum:
+		u.record(remotePath, content, sum)
+		u.count(func() { u.Skipped++; u.BytesSaved += size })
+		return nil
+	case remoteErr != nil && known && previous.SHA256 == sum:
+		// Server can't tell us; trust our record of the last upload
+		u.count(func() { u.Skipped++; u.BytesSaved += size })
+		return nil
+	}
+
+	// Only diff against our record if it still matches what the server has
+	baseMatches := known && (remoteErr != nil || remote.SHA256 == previous.SHA256)
+	if size >= deltaMinSize && baseMatches && len(previous.Blocks) > 0 {
+		sent, err := u.uploadDelta(remotePath, content, sum, previous)
+		if err == nil {
+			u.record(remotePath, content, sum)
+			u.count(func() { u.Deltas++; u.BytesSent += sent; u.BytesSaved += size - sent })
+			return nil
+		}
+		// Fall through to a full upload on any delta failure
+	}
+
+	if err := uploadSingleFile(u.apiClient, u.projectID, localPath, remotePath, u.overwrite, u.progress); err != nil {
+		return err
+	}
+	u.record(remotePath, content, sum)
+	u.count(func() { u.Uploaded++; u.BytesSent += size })
+	return nil
+}
+
+// remoteHash asks the server for the file's hash. It returns (nil, nil) when
+// the file doesn't exist and an error when the server can't answer. Only a
+// 404 that names the file as missing counts; one for a missing workspace
+// is an error like any other.
+func (u *deltaUploader) remoteHash(remotePath string) (*FileHashResponse, error) {
+	var response FileHashResponse
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/hash?path=%s", u.projectID, url.QueryEscape(remotePath))
+	if err := u.apiClient.GET(endpoint, &response); err != nil {
+		var apiErr *client.ErrorResponse
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound && apiErr.Message == fileNotFoundError {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &response, nil
+}
+
+// uploadDelta sends the blocks that differ from the previous version and
+// returns the number of content bytes sent.
+func (u *deltaUploader) uploadDelta(remotePath string, content []byte, sum string, previous fileSyncState) (int64, error) {
+	blocks := blockHashes(content)
+
+	var changed []FileDeltaBlock
+	var sent int64
+	for i, hash := range blocks {
+		if i < len(previous.Blocks) && previous.Blocks[i] == hash {
+			continue
+		}
+		start := i * deltaBlockSize
+		end := start + deltaBlockSize
+		if end > len(content) {
+			end = len(content)
+		}
+		changed = append(changed, FileDeltaBlock{
+			Index:   i,
+			Content: base64.StdEncoding.EncodeToString(content[start:end]),
+		})
+		sent += int64(end - start)
+	}
+
+	// A delta that rewrites most of the file isn't worth it
+	if sent > int64(len(content))/2 {
+		return 0, fmt.Errorf("delta too large")
+	}
+
+	request := FileDeltaRequest{
+		Path:       remotePath,
+		BaseSHA256: previous.SHA256,
+		SHA256:     sum,
+		Size:       int64(len(content)),
+		BlockSize:  deltaBlockSize,
+		Blocks:     changed,
+		Overwrite:  u.overwrite,
+	}
+	endpoint := fmt.Sprintf("/api/v1/sdk/files/%s/delta", u.projectID)
+	if err := u.apiClient.POST(endpoint, request, nil); err != nil {
+		return 0, err
+	}
+	return sent, nil
+}
+
+func (u *deltaUploader) record(remotePath string, content []byte, sum string) {
+	u.state.set(remotePath, newFileSyncState(content, sum))
+}
+
+// newFileSyncState describes an uploaded version of a file
+func newFileSyncState(content []byte, sum string) fileSyncState {
+	entry := fileSyncState{SHA256: sum, Size: int64(len(content))}
+	if len(content) >= deltaMinSize {
+		entry.Blocks = blockHashes(content)
+	}
+	return entry
+}
+
+func (u *deltaUploader) count(update func()) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	update()
+}
+
+// Summary describes what the uploader did, e.g. for a final status line
+func (u *deltaUploader) Summary() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return fmt.Sprintf("%d uploaded, %d delta, %d unchanged; sent %s, saved %s",
+		u.Uploaded, u.Deltas, u.Skipped, formatFileSize(u.BytesSent), formatFileSize(u.BytesSaved))
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// blockHashes returns the SHA-256 of each deltaBlockSize block of content
+func blockHashes(content []byte) []string {
+	hashes := make([]string, 0, (len(content)+deltaBlockSize-1)/deltaBlockSize)
+	for start := 0; start < len(content); start += deltaBlockSize {
+		end := start + deltaBlockSize
+		if end > len(content) {
+			end = len(content)
+		}
+		hashes = append(hashes, sha256Hex(content[start:end]))
+	}
+	return hashes
+}
@@ -0,0 +1,245 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+	"github.com/olekukonko/tablewriter"
+	"golang.org/x/term"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+const (
+	// minColumnWidth is the narrowest a column is truncated to (or its
+	// header's width, if wider) before the table falls back to a vertical
+	// key-value layout
+	minColumnWidth = 8
+	// truncationTail marks a cell that was shortened to fit
+	truncationTail = "..."
+)
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// terminalWidth returns the width of the terminal stdout is attached to, or 0
+// when stdout isn't a terminal (output is never truncated when piped). On a
+// terminal the COLUMNS environment variable takes precedence, as it does for
+// most tools.
+func terminalWidth() int {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// displayWidth is the number of terminal cells s occupies, ignoring colors
+func displayWidth(s string) int {
+	return runewidth.StringWidth(ansiEscape.ReplaceAllString(s, ""))
+}
+
+// truncateText shortens s to at most width terminal cells, marking the cut
+// with "...". Colors are dropped from truncated text so a cut never leaves an
+// escape sequence open.
+func truncateText(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	plain := ansiEscape.ReplaceAllString(s, "")
+	if width <= len(truncationTail) {
+		return runewidth.Truncate(plain, width, "")
+	}
+	return runewidth.Truncate(plain, width, truncationTail)
+}
+
+// listTable collects rows for a tablewriter table and fits them to the
+// terminal when rendered: the widest columns are truncated first, and if the
+// table still doesn't fit, each row is printed as a block of key-value lines.
+type listTable struct {
+	headers      []string
+	headerColors []tablewriter.Colors
+	rows         [][]string
+	footer       []string
+	alignment    int
+	width        int
+
+	records []interface{} // the record behind each row, for --columns
+	columns []tableColumn // set by SelectColumns
+}
+
+func newListTable(headers ...string) *listTable {
+	return &listTable{
+		headers:   headers,
+		alignment: tablewriter.ALIGN_DEFAULT,
+		width:     terminalWidth(),
+	}
+}
+
+// SetHeaderColor sets the color of each header cell
+func (t *listTable) SetHeaderColor(colors ...tablewriter.Colors) {
+	t.headerColors = colors
+}
+
+// SetAlignment sets the alignment of every column
+func (t *listTable) SetAlignment(alignment int) {
+	t.alignment = alignment
+}
+
+// SetFooter sets a footer row, e.g. totals
+func (t *listTable) SetFooter(footer []string) {
+	t.footer = footer
+}
+
+// Append adds a row
+func (t *listTable) Append(row []string) {
+	t.rows = append(t.rows, row)
+}
+
+// Render prints the table to stdout
+func (t *listTable) Render() {
+	t.applyColumns()
+	widths := t.columnWidths()
+	if t.width > 0 && !t.fitColumns(widths) {
+		t.renderVertical()
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(t.headers)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(t.alignment)
+	if len(t.headerColors) > 0 {
+		table.SetHeaderColor(t.headerColors...)
+	}
+	for _, row := range t.rows {
+		table.Append(fitRow(row, widths))
+	}
+	if t.footer != nil {
+		table.SetFooter(fitRow(t.footer, widths))
+	}
+	table.Render()
+}
+
+// columnWidths measures the natural width of each column
+func (t *listTable) columnWidths() []int {
+	widths := make([]int, len(t.headers))
+	measure := func(row []string) {
+		for i, cell := range row {
+			if i < len(widths) && displayWidth(cell) > widths[i] {
+				widths[i] = displayWidth(cell)
+			}
+		}
+	}
+	measure(t.headers)
+	for _, row := range t.rows {
+		measure(row)
+	}
+	measure(t.footer)
+	return widths
+}
+
+// fitColumns narrows the widest columns until the table fits the terminal.
+// It reports false if the table can't fit without going below minColumnWidth.
+func (t *listTable) fitColumns(widths []int) bool {
+	// Each column is padded by a space on both sides and followed by a
+	// border, plus the leading border
+	available := t.width - 3*len(widths) - 1
+
+	// Headers are never truncated
+	floors := make([]int, len(widths))
+	total := 0
+	for i, w := range widths {
+		floors[i] = max(min(w, minColumnWidth), displayWidth(t.headers[i]))
+		total += w
+	}
+	for total > available {
+		widest := -1
+		for i, w := range widths {
+			if w > floors[i] && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return false
+		}
+		widths[widest]--
+		total--
+	}
+	return true
+}
+
+// renderVertical prints each row as "Header: value" lines, for terminals too
+// narrow for a table
+func (t *listTable) renderVertical() {
+	labelWidth := 0
+	for _, header := range t.headers {
+		if displayWidth(header) > labelWidth {
+			labelWidth = displayWidth(header)
+		}
+	}
+	valueWidth := t.width - labelWidth - 2
+	label := ui.New(color.Bold)
+
+	printRecord := func(row []string) {
+		for i, header := range t.headers {
+			value := ""
+			if i < len(row) {
+				value = row[i]
+			}
+			if valueWidth > 0 {
+				value = truncateText(value, valueWidth)
+			}
+			fmt.Printf("%s %s\n", label.Sprintf("%-*s", labelWidth+1, header+":"), value)
+		}
+	}
+
+	for i, row := range t.rows {
+		if i > 0 {
+			fmt.Println()
+		}
+		printRecord(row)
+	}
+	if t.footer != nil {
+		fmt.Println(strings.Repeat("─", min(t.width, labelWidth+20)))
+		printRecord(t.footer)
+	}
+}
+
+func fitRow(row []string, widths []int) []string {
+	fitted := make([]string, len(row))
+	for i, cell := range row {
+		if i < len(widths) {
+			cell = truncateText(cell, widths[i])
+		}
+		fitted[i] = cell
+	}
+	return fitted
+}
@@ -17,8 +17,9 @@ limitations under the License.
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -26,11 +27,12 @@ import (
 
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/fleeks-inc/fleeks-cli/internal/client"
 	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 // terminalCmd represents the terminal command
@@ -69,6 +71,9 @@ Intelligent terminal operations with context preservation:
 Examples:
   # Execute command in workspace
   fleeks terminal exec my-project "npm run build"
+
+  # Execute a multiline script exactly as written
+  fleeks terminal exec my-project --command-file ./deploy.sh
   
   # Run interactive shell
   fleeks terminal shell my-project
@@ -92,10 +97,52 @@ var terminalExecCmd = &cobra.Command{
 The command runs with full context of the workspace including:
 - Environment variables
 - Working directory
-- Installed packages and dependencies`,
-	Args: cobra.ExactArgs(2),
+- Installed packages and dependencies
+
+Use --command-file to read the exact command from a file (or "-" for stdin),
+preserving quotes and newlines in multiline scripts.
+
+Use --detach to start the command as a background job instead (the same as
+'terminal run'), or --detach-after to stream output as usual but leave the
+command running as a background job once it exceeds the given duration.
+--timeout applies to the job as well, and a job that finishes in time exits
+with its own exit code:
+
+  fleeks terminal exec my-project "npm run build" --detach-after 30s
+
+Use --json-stream (or --output ndjson) to emit each output frame as a line of
+JSON with stdout and stderr tagged separately, for editors and other tools:
+
+  {"type":"stdout","content":"ok\n","exit_code":null,"timestamp":"..."}
+  {"type":"exit","content":"","exit_code":0,"timestamp":"..."}
+
+While output streams, Ctrl+C and Ctrl+Z are sent to the remote command
+instead of stopping fleeks, as with docker and kubectl exec. Press Ctrl+C
+twice in quick succession to quit fleeks; the command may keep running.
+
+Use --tty to run the command on a pseudo-terminal, for tools that change
+their output when piped (npm, progress bars, colored output). Output is
+streamed regardless of --stream, the remote terminal follows the size of
+your window, and keystrokes (including Ctrl+C) go to the command. --tty
+needs a terminal locally, so it can't be used when output is redirected:
+
+  fleeks terminal exec my-project "npm install" --tty
+
+Use --retry to run a flaky command again when it fails, waiting
+--retry-delay before the first retry and twice as long before each next
+one. --retry-on-exit limits retries to specific exit codes. Only the final
+attempt's output is shown (earlier attempts are held until they finish and
+dropped if retried); --show-all-attempts shows every attempt. When the
+final attempt still fails, fleeks exits non-zero:
+
+  fleeks terminal exec my-project "npm ci" --retry 3 --retry-on-exit 1,7`,
+	Args: commandArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return executeCommand(args[0], args[1], cmd)
+		command, err := resolveCommandArg(cmd, args[1:])
+		if err != nil {
+			return err
+		}
+		return executeCommand(args[0], command, cmd)
 	},
 }
 
@@ -108,13 +155,33 @@ Provides full terminal access with:
 - Persistent session state
 - Real-time input/output
 - Environment preservation
-- Command history`,
+- Command history
+
+The shell runs in a server-side PTY that outlives the connection. If the
+network drops, fleeks reconnects to the same session with its scrollback and
+running processes intact. Ctrl+C and Ctrl+Z go to the shell, even when input
+is piped. Press Ctrl+] (or Ctrl+C twice when input is piped) to detach and
+leave the shell running, then pick it up again later:
+
+  fleeks terminal sessions my-project
+  fleeks terminal shell my-project --attach <session-id>`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return startShellSession(args[0], cmd)
 	},
 }
 
+var terminalSessionsCmd = &cobra.Command{
+	Use:   "sessions [project-id]",
+	Short: "List shell sessions",
+	Long: `List the shell sessions in a workspace that can be re-attached with
+'fleeks terminal shell --attach'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listShellSessions(args[0])
+	},
+}
+
 var terminalRunCmd = &cobra.Command{
 	Use:   "run [project-id] [command]",
 	Short: "Run command as background job",
@@ -124,7 +191,11 @@ Useful for long-running processes like:
 - Development servers
 - Build processes
 - Test suites
-- Monitoring scripts`,
+- Monitoring scripts
+
+With --wait, the job's output is followed until it finishes and the command
+exits with the job's exit code, so scripts can start a job and check its
+result. The job keeps running if --timeout passes first.`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runBackgroundJob(args[0], args[1], cmd)
@@ -151,7 +222,17 @@ var terminalOutputCmd = &cobra.Command{
 Supports:
 - Real-time output streaming
 - Historical output retrieval
-- Filtered output (stdout/stderr)`,
+- Filtered output (stdout/stderr)
+
+Use --grep and --grep-v to keep only lines matching (or not matching) a
+regular expression; --grep matches are highlighted. With --lines, the last N
+lines are fetched first and then filtered. For example, to find errors:
+
+  fleeks terminal output my-project job-123 --filter stderr --grep "(?i)error"
+
+Use --since-last to see only the lines produced since you last checked the
+job with --since-last. The position is remembered per job in
+~/.fleeks/state.`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return getJobOutput(args[0], args[1], cmd)
@@ -174,6 +255,7 @@ func init() {
 	// Add subcommands
 	terminalCmd.AddCommand(terminalExecCmd)
 	terminalCmd.AddCommand(terminalShellCmd)
+	terminalCmd.AddCommand(terminalSessionsCmd)
 	terminalCmd.AddCommand(terminalRunCmd)
 	terminalCmd.AddCommand(terminalJobsCmd)
 	terminalCmd.AddCommand(terminalOutputCmd)
@@ -184,10 +266,35 @@ func init() {
 	terminalExecCmd.Flags().StringArrayP("env", "E", []string{}, "Environment variables (KEY=VALUE)")
 	terminalExecCmd.Flags().DurationP("timeout", "t", 30*time.Minute, "Command timeout")
 	terminalExecCmd.Flags().BoolP("stream", "s", true, "Stream output in real-time")
+	terminalExecCmd.Flags().String("command-file", "", "Read the command verbatim from a file (\"-\" for stdin)")
+	terminalExecCmd.Flags().BoolP("detach", "d", false, "Run the command as a background job and print its job ID")
+	terminalExecCmd.Flags().Duration("detach-after", 0, "Detach to a background job if the command runs longer than this (0 = never)")
+	terminalExecCmd.Flags().Bool("json-stream", false, "Emit output frames as NDJSON with stdout/stderr tagged (same as --output ndjson)")
+	terminalExecCmd.MarkFlagsMutuallyExclusive("detach", "detach-after")
+	terminalExecCmd.MarkFlagsMutuallyExclusive("json-stream", "detach")
+	terminalExecCmd.MarkFlagsMutuallyExclusive("json-stream", "detach-after")
+	terminalExecCmd.Flags().Bool("tty", false, "Run the command on a pseudo-terminal (needs a local terminal; always streams)")
+	terminalExecCmd.MarkFlagsMutuallyExclusive("tty", "detach")
+	terminalExecCmd.MarkFlagsMutuallyExclusive("tty", "detach-after")
+	terminalExecCmd.MarkFlagsMutuallyExclusive("tty", "json-stream")
+	terminalExecCmd.Flags().Int("retry", 0, "Run the command again up to this many times when it exits non-zero")
+	terminalExecCmd.Flags().IntSlice("retry-on-exit", nil, "Only retry on these exit codes (comma-separated; default: any non-zero)")
+	terminalExecCmd.Flags().Duration("retry-delay", 2*time.Second, "Wait before the first retry; doubles for each next one")
+	terminalExecCmd.Flags().Bool("show-all-attempts", false, "Show the output of every attempt, not just the final one")
+	markFlagRequires(terminalExecCmd, "retry-on-exit", "retry")
+	markFlagRequires(terminalExecCmd, "retry-delay", "retry")
+	markFlagRequires(terminalExecCmd, "show-all-attempts", "retry")
+	for _, flag := range []string{"detach", "detach-after", "tty", "json-stream"} {
+		terminalExecCmd.MarkFlagsMutuallyExclusive("retry", flag)
+	}
 
 	// Shell command flags
 	terminalShellCmd.Flags().StringP("shell", "s", "bash", "Shell type (bash, zsh, fish)")
+	registerFlagCompletion(terminalShellCmd, "shell", completeValues(shellValues...))
 	terminalShellCmd.Flags().StringP("workdir", "w", "/workspace", "Working directory")
+	terminalShellCmd.Flags().String("attach", "", "Re-attach to an existing shell session by ID")
+	terminalShellCmd.MarkFlagsMutuallyExclusive("attach", "shell")
+	terminalShellCmd.MarkFlagsMutuallyExclusive("attach", "workdir")
 
 	// Run command flags
 	terminalRunCmd.Flags().StringP("name", "n", "", "Job name")
@@ -195,15 +302,36 @@ func init() {
 	terminalRunCmd.Flags().StringArrayP("env", "E", []string{}, "Environment variables (KEY=VALUE)")
 	terminalRunCmd.Flags().IntP("cpu", "c", 1, "CPU limit (cores)")
 	terminalRunCmd.Flags().StringP("memory", "m", "512Mi", "Memory limit")
+	terminalRunCmd.Flags().String("id-file", "", idFileFlagUsage)
+	terminalRunCmd.Flags().Bool("wait", false, "Follow the job's output until it finishes and exit with its exit code")
+	terminalRunCmd.Flags().Duration("timeout", 0, "Maximum time to wait with --wait (0 = no limit)")
+	markFlagRequires(terminalRunCmd, "timeout", "wait")
 
 	// Jobs command flags
 	terminalJobsCmd.Flags().StringP("status", "s", "", "Filter by status (running, completed, failed)")
+	registerFlagCompletion(terminalJobsCmd, "status", completeValues(jobStatusValues...))
 	terminalJobsCmd.Flags().BoolP("all", "a", false, "Show all jobs (including completed)")
+	terminalJobsCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
 
 	// Output command flags
 	terminalOutputCmd.Flags().BoolP("follow", "f", false, "Follow output (tail -f)")
 	terminalOutputCmd.Flags().IntP("lines", "n", 100, "Number of lines to show")
 	terminalOutputCmd.Flags().StringP("filter", "", "", "Filter output (stdout, stderr)")
+	terminalOutputCmd.Flags().String("grep", "", "Only show lines matching this regular expression")
+	terminalOutputCmd.Flags().String("grep-v", "", "Hide lines matching this regular expression")
+	terminalOutputCmd.Flags().Bool("since-last", false, "Show only output produced since the last --since-last run")
+	terminalOutputCmd.MarkFlagsMutuallyExclusive("since-last", "follow")
+
+	// Accept --project in place of the [project-id] argument
+	acceptProjectFlag(
+		terminalExecCmd,
+		terminalShellCmd,
+		terminalSessionsCmd,
+		terminalRunCmd,
+		terminalJobsCmd,
+		terminalOutputCmd,
+		terminalStopCmd,
+	)
 }
 
 // CommandRequest represents command execution request
@@ -213,6 +341,7 @@ type CommandRequest struct {
 	Environment map[string]string `json:"environment,omitempty"`
 	Timeout     int               `json:"timeout_seconds,omitempty"`
 	Stream      bool              `json:"stream"`
+	TTY         bool              `json:"tty,omitempty"`
 }
 
 // CommandResponse represents command execution response
@@ -250,6 +379,22 @@ type JobResources struct {
 	MemoryLimit string  `json:"memory_limit"`
 }
 
+// ExecFrame is one NDJSON line emitted by 'terminal exec --json-stream'.
+// Type is stdout, stderr or exit; ExitCode is only set on the exit frame.
+type ExecFrame struct {
+	Type      string `json:"type"`
+	Content   string `json:"content"`
+	ExitCode  *int   `json:"exit_code"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Exec frame types
+const (
+	execFrameStdout = "stdout"
+	execFrameStderr = "stderr"
+	execFrameExit   = "exit"
+)
+
 // JobOutput represents job output
 type JobOutput struct {
 	JobID     string    `json:"job_id"`
@@ -274,6 +419,50 @@ func executeCommand(projectID, command string, cmd *cobra.Command) error {
 	envVars, _ := cmd.Flags().GetStringArray("env")
 	timeout, _ := cmd.Flags().GetDuration("timeout")
 	stream, _ := cmd.Flags().GetBool("stream")
+	detach, _ := cmd.Flags().GetBool("detach")
+	detachAfter, _ := cmd.Flags().GetDuration("detach-after")
+	jsonStream, _ := cmd.Flags().GetBool("json-stream")
+	tty, _ := cmd.Flags().GetBool("tty")
+	retries, _ := cmd.Flags().GetInt("retry")
+	retryCodes, _ := cmd.Flags().GetIntSlice("retry-on-exit")
+	retryDelay, _ := cmd.Flags().GetDuration("retry-delay")
+	showAllAttempts, _ := cmd.Flags().GetBool("show-all-attempts")
+
+	if retries < 0 {
+		return fmt.Errorf("--retry cannot be negative")
+	}
+	if retryDelay < 0 {
+		return fmt.Errorf("--retry-delay cannot be negative")
+	}
+	policy := execRetryPolicy{retries: retries, delay: retryDelay, showAll: showAllAttempts}
+	for _, code := range retryCodes {
+		if code <= 0 {
+			return fmt.Errorf("--retry-on-exit codes must be positive, got %d", code)
+		}
+		if policy.codes == nil {
+			policy.codes = make(map[int]bool)
+		}
+		policy.codes[code] = true
+	}
+
+	if tty {
+		if isJSONOutput() || isNDJSONOutput() {
+			return fmt.Errorf("--tty cannot be combined with --output json or ndjson")
+		}
+		if !term.IsTerminal(int(os.Stdout.Fd())) {
+			return fmt.Errorf("--tty needs a terminal, but output is redirected; drop --tty when piping or saving output")
+		}
+	}
+	if jsonStream && isJSONOutput() {
+		return fmt.Errorf("--json-stream cannot be combined with --output %s", outputJSON)
+	}
+	jsonStream = jsonStream || isNDJSONOutput()
+	if jsonStream && (detach || detachAfter > 0) {
+		return fmt.Errorf("--json-stream cannot be combined with --detach or --detach-after")
+	}
+	if jsonStream && retries > 0 {
+		return fmt.Errorf("--retry cannot be combined with --json-stream or --output %s", outputNDJSON)
+	}
 
 	// Parse environment variables
 	environment := make(map[string]string)
@@ -288,35 +477,215 @@ func executeCommand(projectID, command string, cmd *cobra.Command) error {
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
+	if detach || detachAfter > 0 {
+		jobRequest := map[string]interface{}{
+			"name":            fmt.Sprintf("exec-%d", time.Now().Unix()),
+			"command":         command,
+			"working_dir":     workdir,
+			"environment":     environment,
+			"timeout_seconds": int(timeout.Seconds()),
+		}
+		cmd.SilenceUsage = true
+		return executeDetachableCommand(apiClient, projectID, jobRequest, detach, detachAfter)
+	}
+
 	// Prepare request
 	request := CommandRequest{
 		Command:     command,
 		WorkingDir:  workdir,
 		Environment: environment,
 		Timeout:     int(timeout.Seconds()),
-		Stream:      stream,
+		Stream:      stream || tty,
+		TTY:         tty,
+	}
+
+	// The command is valid from here on; failures are about running it
+	cmd.SilenceUsage = true
+
+	if tty {
+		return executeTTYCommand(apiClient, projectID, request)
+	}
+
+	if jsonStream {
+		if stream {
+			return streamCommandFrames(apiClient, projectID, request)
+		}
+		return printCommandFrames(apiClient, projectID, request)
 	}
 
 	fmt.Printf("%s Executing command in %s:\n%s\n\n",
-		color.CyanString("🖥️"),
-		color.YellowString(projectID),
-		color.WhiteString(command))
+		ui.Cyan("🖥️"),
+		ui.Yellow(projectID),
+		ui.White(command))
+
+	attempt := func(out io.Writer) (int, error) {
+		if stream {
+			return executeStreamingCommand(apiClient, projectID, request, out)
+		}
+		return executeBlockingCommand(apiClient, projectID, request, out)
+	}
+	if retries == 0 {
+		_, err := attempt(os.Stdout)
+		return err
+	}
+
+	fmt.Printf("%s Retrying up to %d time(s) on %s\n\n",
+		ui.Cyan("🔁"), retries, policy.describeCodes())
+	return runWithRetries(policy, attempt)
+}
+
+// printExecFrame writes one --json-stream frame
+func printExecFrame(frameType, content string, exitCode *int, timestamp time.Time) error {
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	return printNDJSON(ExecFrame{
+		Type:      frameType,
+		Content:   content,
+		ExitCode:  exitCode,
+		Timestamp: timestamp.UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// streamCommandFrames is the --json-stream form of executeStreamingCommand:
+// each output message becomes a stdout or stderr frame, followed by an exit
+// frame once the command completes
+func streamCommandFrames(apiClient *client.APIClient, projectID string, request CommandRequest) error {
+	streamPath := fmt.Sprintf("/ws/terminal/%s/exec", projectID)
+	stream, err := apiClient.NewStreamReader(streamPath)
+	if err != nil {
+		return fmt.Errorf("failed to create command stream: %w", err)
+	}
+	defer stream.Close()
+	defer reportDroppedMessages(stream)
 
-	if stream {
-		return executeStreamingCommand(apiClient, projectID, request)
+	for {
+		select {
+		case msg, ok := <-stream.Messages():
+			if !ok {
+				return nil
+			}
+
+			if output, exists := msg.Metadata["output"]; exists {
+				frameType := execFrameStdout
+				if msg.Metadata["stream"] == execFrameStderr || msg.Type == client.MessageError {
+					frameType = execFrameStderr
+				}
+				if err := printExecFrame(frameType, fmt.Sprintf("%v", output), nil, msg.Timestamp); err != nil {
+					return err
+				}
+			}
+
+			if status, exists := msg.Metadata["status"]; exists && status == "completed" {
+				code, _ := strconv.Atoi(fmt.Sprintf("%v", msg.Metadata["exit_code"]))
+				return printExecFrame(execFrameExit, "", &code, msg.Timestamp)
+			}
+
+		case err, ok := <-stream.Errors():
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("stream error: %w", err)
+		}
+	}
+}
+
+// printCommandFrames is the --json-stream form of executeBlockingCommand
+func printCommandFrames(apiClient *client.APIClient, projectID string, request CommandRequest) error {
+	var response CommandResponse
+	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/exec", projectID)
+
+	if err := apiClient.POST(endpoint, request, &response); err != nil {
+		return fmt.Errorf("failed to execute command: %w", err)
+	}
+
+	if response.Stdout != "" {
+		if err := printExecFrame(execFrameStdout, response.Stdout, nil, time.Time{}); err != nil {
+			return err
+		}
+	}
+	if response.Stderr != "" {
+		if err := printExecFrame(execFrameStderr, response.Stderr, nil, time.Time{}); err != nil {
+			return err
+		}
+	}
+	return printExecFrame(execFrameExit, "", &response.ExitCode, time.Time{})
+}
+
+// commandArgs accepts [project-id] followed by up to maxWords command
+// arguments (0 for no limit), or the project ID alone with --command-file.
+// Requiring the command up front is what lets --project tell a project ID
+// from a command.
+func commandArgs(maxWords int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("requires a project ID")
+		}
+		words := len(args) - 1
+		if commandFile, _ := cmd.Flags().GetString("command-file"); commandFile != "" {
+			if words > 0 {
+				return fmt.Errorf("cannot use a command argument together with --command-file")
+			}
+			return nil
+		}
+		if words == 0 {
+			return fmt.Errorf("a command argument or --command-file is required")
+		}
+		if maxWords > 0 && words > maxWords {
+			return fmt.Errorf("accepts at most %d command argument(s), received %d; quote the command", maxWords, words)
+		}
+		return nil
+	}
+}
+
+// resolveCommandArg returns the command to execute, either from the --command-file
+// flag or from the remaining positional arguments joined by spaces. Commands read
+// from a file are passed through untouched so quoting and newlines survive.
+func resolveCommandArg(cmd *cobra.Command, args []string) (string, error) {
+	commandFile, _ := cmd.Flags().GetString("command-file")
+	if commandFile == "" {
+		if len(args) == 0 {
+			return "", fmt.Errorf("a command argument or --command-file is required")
+		}
+		return strings.Join(args, " "), nil
+	}
+
+	if len(args) > 0 {
+		return "", fmt.Errorf("cannot use a command argument together with --command-file")
+	}
+
+	commandFile, err := expandPath(commandFile)
+	if err != nil {
+		return "", err
+	}
+
+	var content []byte
+	if commandFile == "-" {
+		content, err = io.ReadAll(os.Stdin)
 	} else {
-		return executeBlockingCommand(apiClient, projectID, request)
+		content, err = os.ReadFile(commandFile)
 	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read command file: %w", err)
+	}
+
+	if strings.TrimSpace(string(content)) == "" {
+		return "", fmt.Errorf("command file is empty")
+	}
+	return string(content), nil
 }
 
-func executeStreamingCommand(apiClient *client.APIClient, projectID string, request CommandRequest) error {
+// executeStreamingCommand streams the command's output to out and returns
+// its exit code, or -1 if the stream ended without one
+func executeStreamingCommand(apiClient *client.APIClient, projectID string, request CommandRequest, out io.Writer) (int, error) {
 	// Create stream for command execution
 	streamPath := fmt.Sprintf("/ws/terminal/%s/exec", projectID)
 	stream, err := apiClient.NewStreamReader(streamPath)
 	if err != nil {
-		return fmt.Errorf("failed to create command stream: %w", err)
+		return -1, fmt.Errorf("failed to create command stream: %w", err)
 	}
 	defer stream.Close()
+	defer reportDroppedMessages(stream)
 
 	// Send command request via WebSocket (in a real implementation)
 	// For now, simulate streaming output
@@ -330,76 +699,159 @@ func executeStreamingCommand(apiClient *client.APIClient, projectID string, requ
 	time.Sleep(1 * time.Second)
 	s.Stop()
 
-	fmt.Printf("%s Command started, streaming output:\n\n", color.GreenString("✅"))
+	fmt.Fprintf(out, "%s Command started, streaming output:\n\n", ui.Green("✅"))
+
+	// Ctrl+C and Ctrl+Z go to the command; a double Ctrl+C quits
+	signals := forwardSignals()
+	defer signals.Stop()
 
 	// Stream command output
 	for {
 		select {
+		case sig := <-signals.C():
+			if signals.Quit(sig) {
+				return -1, errInterrupted
+			}
+			if err := stream.Send(signals.Message(sig, false)); err != nil {
+				return -1, fmt.Errorf("failed to forward %s: %w", sig, err)
+			}
+
 		case msg, ok := <-stream.Messages():
 			if !ok {
-				fmt.Printf("\n%s Command execution completed\n", color.GreenString("✅"))
-				return nil
+				fmt.Fprintf(out, "\n%s Command execution completed\n", ui.Green("✅"))
+				return -1, nil
 			}
 
 			// Process output message
 			if output, exists := msg.Metadata["output"]; exists {
-				fmt.Print(output)
+				fmt.Fprint(out, output)
 			}
 
 			// Check for completion
 			if status, exists := msg.Metadata["status"]; exists && status == "completed" {
-				if exitCode, exists := msg.Metadata["exit_code"]; exists {
-					code, _ := strconv.Atoi(fmt.Sprintf("%v", exitCode))
-					if code == 0 {
-						fmt.Printf("\n%s Command completed successfully (exit code: %d)\n",
-							color.GreenString("✅"), code)
-					} else {
-						fmt.Printf("\n%s Command failed (exit code: %d)\n",
-							color.RedString("❌"), code)
-					}
+				exitCode, exists := msg.Metadata["exit_code"]
+				if !exists {
+					return -1, nil
 				}
-				return nil
+				code, _ := strconv.Atoi(fmt.Sprintf("%v", exitCode))
+				if code == 0 {
+					fmt.Fprintf(out, "\n%s Command completed successfully (exit code: %d)\n",
+						ui.Green("✅"), code)
+				} else {
+					fmt.Fprintf(out, "\n%s Command failed (exit code: %d)\n",
+						ui.Red("❌"), code)
+				}
+				return code, nil
 			}
 
 		case err, ok := <-stream.Errors():
 			if !ok {
-				return nil
+				return -1, nil
 			}
-			return fmt.Errorf("stream error: %w", err)
+			return -1, fmt.Errorf("stream error: %w", err)
+		}
+	}
+}
+
+// executeDetachableCommand runs the command through the background job endpoint.
+// When detach is set the job ID is printed straight away; otherwise output is
+// followed until the job finishes, exiting with its exit code like a plain
+// exec, or until detachAfter elapses, at which point the job is left running
+// in the background.
+func executeDetachableCommand(apiClient *client.APIClient, projectID string, jobRequest map[string]interface{}, detach bool, detachAfter time.Duration) error {
+	jobID, err := startJob(apiClient, projectID, jobRequest)
+	if err != nil {
+		return err
+	}
+
+	if detach {
+		fmt.Printf("%s Command detached as background job %s\n",
+			ui.Green("🚀"), ui.Cyan(jobID))
+		fmt.Printf("\nUse 'fleeks terminal output %s %s' to view output\n", projectID, jobID)
+		return nil
+	}
+
+	fmt.Printf("%s Command started as job %s, streaming output (detaching after %s):\n\n",
+		ui.Green("✅"), ui.Cyan(jobID), detachAfter)
+
+	streamPath := fmt.Sprintf("/ws/terminal/%s/jobs/%s/output", projectID, jobID)
+	stream, err := apiClient.NewStreamReader(streamPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output stream: %w", err)
+	}
+	defer stream.Close()
+	defer reportDroppedMessages(stream)
+
+	timer := time.NewTimer(detachAfter)
+	defer timer.Stop()
+	detached := func() error {
+		fmt.Printf("\n%s Command still running after %s, detached as background job %s\n",
+			ui.Yellow("⏳"), detachAfter, ui.Cyan(jobID))
+		fmt.Printf("\nUse 'fleeks terminal output %s %s --follow' to keep watching\n", projectID, jobID)
+		return nil
+	}
+
+	errs := stream.Errors()
+	for {
+		select {
+		case <-timer.C:
+			return detached()
+
+		case msg, ok := <-stream.Messages():
+			if !ok {
+				fmt.Println()
+				return finishJob(apiClient, projectID, jobID, timer.C, detached)
+			}
+			if output, exists := msg.Metadata["output"]; exists {
+				fmt.Print(output)
+			}
+
+		case err, ok := <-errs:
+			if !ok {
+				// Messages is closed next
+				errs = nil
+				continue
+			}
+			// The job status, not the output stream, decides the result
+			fmt.Fprintf(os.Stderr, "\n%s Lost the output stream (%v); waiting for the job to finish\n",
+				ui.Yellow("⚠️"), err)
+			return finishJob(apiClient, projectID, jobID, timer.C, detached)
 		}
 	}
 }
 
-func executeBlockingCommand(apiClient *client.APIClient, projectID string, request CommandRequest) error {
+// executeBlockingCommand runs the command to completion, writes its output
+// to out and returns its exit code
+func executeBlockingCommand(apiClient *client.APIClient, projectID string, request CommandRequest, out io.Writer) (int, error) {
 	// Execute command and wait for completion
 	var response CommandResponse
 	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/exec", projectID)
 
 	if err := apiClient.POST(endpoint, request, &response); err != nil {
-		return fmt.Errorf("failed to execute command: %w", err)
+		return -1, fmt.Errorf("failed to execute command: %w", err)
 	}
 
 	// Display output
 	if response.Stdout != "" {
-		fmt.Printf("%s Output:\n%s\n", color.CyanString("📤"), response.Stdout)
+		fmt.Fprintf(out, "%s Output:\n%s\n", ui.Cyan("📤"), response.Stdout)
 	}
 
 	if response.Stderr != "" {
-		fmt.Printf("%s Error Output:\n%s\n", color.RedString("⚠️"), response.Stderr)
+		fmt.Fprintf(out, "%s Error Output:\n%s\n", ui.Red("⚠️"), response.Stderr)
 	}
 
 	// Display result
 	if response.ExitCode == 0 {
-		fmt.Printf("%s Command completed successfully (exit code: %d)\n",
-			color.GreenString("✅"), response.ExitCode)
+		fmt.Fprintf(out, "%s Command completed successfully (exit code: %d)\n",
+			ui.Green("✅"), response.ExitCode)
 	} else {
-		fmt.Printf("%s Command failed (exit code: %d)\n",
-			color.RedString("❌"), response.ExitCode)
+		fmt.Fprintf(out, "%s Command failed (exit code: %d)\n",
+			ui.Red("❌"), response.ExitCode)
 	}
 
-	fmt.Printf("Duration: %s\n", color.MagentaString(fmt.Sprintf("%dms", response.Duration)))
+	fmt.Fprintf(out, "Duration: %s\n", ui.Magenta(fmt.Sprintf("%dms", response.Duration)))
 
-	return nil
+	return response.ExitCode, nil
 }
 
 func startShellSession(projectID string, cmd *cobra.Command) error {
@@ -414,84 +866,103 @@ func startShellSession(projectID string, cmd *cobra.Command) error {
 
 	shellType, _ := cmd.Flags().GetString("shell")
 	workdir, _ := cmd.Flags().GetString("workdir")
-
-	fmt.Printf("%s Starting interactive shell session in %s\n",
-		color.CyanString("🐚"), color.YellowString(projectID))
-	fmt.Printf("Shell: %s, Working Directory: %s\n\n",
-		color.GreenString(shellType), color.BlueString(workdir))
+	attachID, _ := cmd.Flags().GetString("attach")
 
 	// Create API client
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
-	// Create interactive shell stream
-	streamPath := fmt.Sprintf("/ws/terminal/%s/shell", projectID)
-	stream, err := apiClient.NewStreamReader(streamPath)
-	if err != nil {
-		return fmt.Errorf("failed to create shell stream: %w", err)
+	request := ShellSessionRequest{SessionID: attachID}
+	if attachID == "" {
+		request.Shell = shellType
+		request.WorkingDir = workdir
+	}
+	if cols, rows, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		request.Cols, request.Rows = cols, rows
 	}
-	defer stream.Close()
-
-	// Start interactive session
-	fmt.Printf("%s Connected to workspace shell. Type 'exit' to quit.\n\n",
-		color.GreenString("🔗"))
-
-	// Create input scanner
-	scanner := bufio.NewScanner(os.Stdin)
-
-	// Handle shell interaction
-	for {
-		fmt.Print(color.CyanString("fleeks> "))
-
-		if !scanner.Scan() {
-			break
-		}
 
-		input := strings.TrimSpace(scanner.Text())
-		if input == "" {
-			continue
-		}
+	session, err := openShellSession(apiClient, projectID, request)
+	if err != nil {
+		return err
+	}
 
-		if input == "exit" || input == "quit" {
-			break
-		}
+	if attachID != "" {
+		fmt.Printf("%s Re-attaching to shell session %s in %s\n",
+			ui.Cyan("🐚"), ui.Cyan(session.SessionID), ui.Yellow(projectID))
+	} else {
+		fmt.Printf("%s Starting interactive shell session in %s\n",
+			ui.Cyan("🐚"), ui.Yellow(projectID))
+		fmt.Printf("Shell: %s, Working Directory: %s\n",
+			ui.Green(shellType), ui.Blue(workdir))
+	}
+	fmt.Printf("%s Session %s. Type 'exit' to quit, or Ctrl+] to detach and leave it running.\n\n",
+		ui.Green("🔗"), ui.Cyan(session.SessionID))
 
-		// Execute command in shell context
-		err := executeShellCommand(apiClient, projectID, input, workdir)
-		if err != nil {
-			fmt.Printf("%s Error: %v\n", color.RedString("❌"), err)
-		}
+	cmd.SilenceUsage = true
+	if err := runShellSession(apiClient, projectID, session); err != nil {
+		return err
 	}
 
-	fmt.Printf("\n%s Shell session ended\n", color.GreenString("👋"))
+	fmt.Printf("\n%s Shell session ended\n", ui.Green("👋"))
 	return nil
 }
 
-func executeShellCommand(apiClient *client.APIClient, projectID, command, workdir string) error {
-	request := CommandRequest{
-		Command:    command,
-		WorkingDir: workdir,
-		Stream:     true,
+func listShellSessions(projectID string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// In a real implementation, this would send the command via WebSocket
-	// and stream the response. For now, we'll simulate the execution.
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
 
-	var response CommandResponse
-	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/exec", projectID)
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
 
-	if err := apiClient.POST(endpoint, request, &response); err != nil {
-		return err
+	var sessions []ShellSession
+	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/shell/sessions", projectID)
+	if err := apiClient.GET(endpoint, &sessions); err != nil {
+		return fmt.Errorf("failed to list shell sessions: %w", err)
 	}
 
-	if response.Stdout != "" {
-		fmt.Print(response.Stdout)
+	if isJSONOutput() {
+		return printJSON(sessions)
 	}
 
-	if response.Stderr != "" {
-		fmt.Print(color.RedString(response.Stderr))
+	if len(sessions) == 0 {
+		fmt.Printf("%s No shell sessions in %s\n",
+			ui.Yellow("🐚"), ui.Cyan(projectID))
+		return nil
+	}
+
+	table := newListTable("Session ID", "Shell", "Working Dir", "Status", "Created", "Last Active")
+	for _, session := range sessions {
+		status := session.Status
+		switch status {
+		case "attached":
+			status = ui.Green("ATTACHED")
+		case "detached":
+			status = ui.Yellow("DETACHED")
+		case "exited":
+			status = ui.Muted("EXITED")
+		}
+		table.Append([]string{
+			session.SessionID,
+			session.Shell,
+			session.WorkingDir,
+			status,
+			session.CreatedAt.Format("2006-01-02 15:04"),
+			session.LastActiveAt.Format("2006-01-02 15:04"),
+		})
 	}
 
+	fmt.Printf("\n%s %s\n\n",
+		ui.New(color.Bold).Sprint("🐚 Shell Sessions:"), ui.Cyan(projectID))
+	table.Render()
+	fmt.Printf("\nReattach with: %s\n",
+		ui.Cyan(fmt.Sprintf("fleeks terminal shell %s --attach <session-id>", projectID)))
 	return nil
 }
 
@@ -511,6 +982,8 @@ func runBackgroundJob(projectID, command string, cmd *cobra.Command) error {
 	envVars, _ := cmd.Flags().GetStringArray("env")
 	cpuLimit, _ := cmd.Flags().GetInt("cpu")
 	memoryLimit, _ := cmd.Flags().GetString("memory")
+	wait, _ := cmd.Flags().GetBool("wait")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
 
 	if name == "" {
 		name = fmt.Sprintf("job-%d", time.Now().Unix())
@@ -540,24 +1013,44 @@ func runBackgroundJob(projectID, command string, cmd *cobra.Command) error {
 	}
 
 	// Start background job
-	var jobResponse map[string]interface{}
-	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/jobs", projectID)
-
-	if err := apiClient.POST(endpoint, jobRequest, &jobResponse); err != nil {
-		return fmt.Errorf("failed to start background job: %w", err)
+	jobID, err := startJob(apiClient, projectID, jobRequest)
+	if err != nil {
+		return err
+	}
+	if err := writeIDFile(cmd, jobID); err != nil {
+		return err
 	}
 
-	jobID := jobResponse["job_id"].(string)
+	fmt.Printf("%s Background job started successfully\n", ui.Green("🚀"))
+	fmt.Printf("Job ID: %s\n", ui.Cyan(jobID))
+	fmt.Printf("Name: %s\n", ui.Yellow(name))
+	fmt.Printf("Command: %s\n", ui.White(command))
 
-	fmt.Printf("%s Background job started successfully\n", color.GreenString("🚀"))
-	fmt.Printf("Job ID: %s\n", color.CyanString(jobID))
-	fmt.Printf("Name: %s\n", color.YellowString(name))
-	fmt.Printf("Command: %s\n", color.WhiteString(command))
+	if wait {
+		fmt.Println()
+		return waitForJob(apiClient, projectID, jobID, timeout)
+	}
 	fmt.Printf("\nUse 'fleeks terminal output %s %s' to view output\n", projectID, jobID)
 
 	return nil
 }
 
+// startJob submits a background job and returns its ID
+func startJob(apiClient *client.APIClient, projectID string, jobRequest map[string]interface{}) (string, error) {
+	var jobResponse map[string]interface{}
+	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/jobs", projectID)
+
+	if err := apiClient.POST(endpoint, jobRequest, &jobResponse); err != nil {
+		return "", fmt.Errorf("failed to start background job: %w", err)
+	}
+
+	jobID, ok := jobResponse["job_id"].(string)
+	if !ok || jobID == "" {
+		return "", fmt.Errorf("failed to start background job: response did not include a job ID")
+	}
+	return jobID, nil
+}
+
 func listJobs(projectID string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -598,34 +1091,36 @@ func listJobs(projectID string, cmd *cobra.Command) error {
 
 	if len(jobs) == 0 {
 		fmt.Printf("%s No jobs found in %s\n",
-			color.YellowString("📋"), color.CyanString(projectID))
+			ui.Yellow("📋"), ui.Cyan(projectID))
 		return nil
 	}
 
 	// Create table
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Name", "Status", "Command", "Duration", "CPU", "Memory"})
+	table := newListTable("ID", "Name", "Status", "Command", "Duration", "CPU", "Memory")
 	table.SetHeaderColor(
-		tablewriter.Colors{tablewriter.FgHiCyanColor},
-		tablewriter.Colors{tablewriter.FgHiYellowColor},
-		tablewriter.Colors{tablewriter.FgHiGreenColor},
-		tablewriter.Colors{tablewriter.FgHiWhiteColor},
-		tablewriter.Colors{tablewriter.FgHiMagentaColor},
-		tablewriter.Colors{tablewriter.FgHiBlueColor},
-		tablewriter.Colors{tablewriter.FgHiRedColor},
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiGreen),
+		ui.TableColors(color.FgHiWhite),
+		ui.TableColors(color.FgHiMagenta),
+		ui.TableColors(color.FgHiBlue),
+		ui.TableColors(color.FgHiRed),
 	)
+	if err := table.SelectColumns(cmd, JobInfo{}); err != nil {
+		return err
+	}
 
 	for _, job := range jobs {
 		status := job.Status
 		switch status {
 		case "running":
-			status = color.GreenString("RUNNING")
+			status = ui.Green("RUNNING")
 		case "completed":
-			status = color.BlueString("COMPLETED")
+			status = ui.Blue("COMPLETED")
 		case "failed":
-			status = color.RedString("FAILED")
+			status = ui.Red("FAILED")
 		case "cancelled":
-			status = color.YellowString("CANCELLED")
+			status = ui.Yellow("CANCELLED")
 		}
 
 		duration := "-"
@@ -633,29 +1128,23 @@ func listJobs(projectID string, cmd *cobra.Command) error {
 			duration = fmt.Sprintf("%dms", *job.Duration)
 		}
 
-		// Truncate command if too long
-		command := job.Command
-		if len(command) > 30 {
-			command = command[:27] + "..."
-		}
-
-		table.Append([]string{
+		table.AppendRecord([]string{
 			job.ID[:8], // Short ID
 			job.Name,
 			status,
-			command,
+			job.Command,
 			duration,
 			fmt.Sprintf("%.1f%%", job.Resources.CPUUsage),
 			formatMemoryUsage(job.Resources.MemoryUsage),
-		})
+		}, job)
 	}
 
 	fmt.Printf("\n%s %s\n\n",
-		color.New(color.Bold).Sprint("📋 Background Jobs:"), color.CyanString(projectID))
+		ui.New(color.Bold).Sprint("📋 Background Jobs:"), ui.Cyan(projectID))
 
 	table.Render()
 
-	fmt.Printf("\nTotal: %s jobs\n", color.GreenString(fmt.Sprintf("%d", len(jobs))))
+	fmt.Printf("\nTotal: %s jobs\n", ui.Green(fmt.Sprintf("%d", len(jobs))))
 	return nil
 }
 
@@ -673,19 +1162,32 @@ func getJobOutput(projectID, jobID string, cmd *cobra.Command) error {
 	follow, _ := cmd.Flags().GetBool("follow")
 	lines, _ := cmd.Flags().GetInt("lines")
 	filter, _ := cmd.Flags().GetString("filter")
+	grep, _ := cmd.Flags().GetString("grep")
+	grepV, _ := cmd.Flags().GetString("grep-v")
+	sinceLast, _ := cmd.Flags().GetBool("since-last")
+
+	lineFilter, err := newLineFilter(grep, grepV)
+	if err != nil {
+		return err
+	}
 
 	// Create API client
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
 	if follow {
-		return followJobOutput(apiClient, projectID, jobID, filter)
+		return followJobOutput(apiClient, projectID, jobID, filter, lineFilter, nil)
 	} else {
-		return getJobOutputHistory(apiClient, projectID, jobID, lines, filter)
+		return getJobOutputHistory(apiClient, projectID, jobID, lines, filter, lineFilter, sinceLast)
 	}
 }
 
-func followJobOutput(apiClient *client.APIClient, projectID, jobID, filter string) error {
+// errFollowTimedOut is returned by followJobOutput when its deadline passes
+var errFollowTimedOut = errors.New("timed out following job output")
+
+// followJobOutput streams a job's output until the stream ends, or until
+// deadline fires when it isn't nil
+func followJobOutput(apiClient *client.APIClient, projectID, jobID, filter string, lineFilter *lineFilter, deadline <-chan time.Time) error {
 	// Create stream for job output
 	streamPath := fmt.Sprintf("/ws/terminal/%s/jobs/%s/output", projectID, jobID)
 	stream, err := apiClient.NewStreamReader(streamPath)
@@ -693,16 +1195,22 @@ func followJobOutput(apiClient *client.APIClient, projectID, jobID, filter strin
 		return fmt.Errorf("failed to create output stream: %w", err)
 	}
 	defer stream.Close()
+	defer reportDroppedMessages(stream)
 
 	fmt.Printf("%s Following output for job %s (Press Ctrl+C to stop)\n\n",
-		color.CyanString("📺"), color.YellowString(jobID))
+		ui.Cyan("📺"), ui.Yellow(jobID))
 
 	// Stream job output
 	for {
 		select {
+		case <-deadline:
+			fmt.Print(lineFilter.flush())
+			return errFollowTimedOut
+
 		case msg, ok := <-stream.Messages():
 			if !ok {
-				fmt.Printf("\n%s Output stream ended\n", color.GreenString("✅"))
+				fmt.Print(lineFilter.flush())
+				fmt.Printf("\n%s Output stream ended\n", ui.Green("✅"))
 				return nil
 			}
 
@@ -710,7 +1218,7 @@ func followJobOutput(apiClient *client.APIClient, projectID, jobID, filter strin
 			if output, exists := msg.Metadata["output"]; exists {
 				outputType := msg.Metadata["type"]
 				if filter == "" || filter == fmt.Sprintf("%v", outputType) {
-					fmt.Print(output)
+					fmt.Print(lineFilter.feed(fmt.Sprintf("%v", output)))
 				}
 			}
 
@@ -723,13 +1231,23 @@ func followJobOutput(apiClient *client.APIClient, projectID, jobID, filter strin
 	}
 }
 
-func getJobOutputHistory(apiClient *client.APIClient, projectID, jobID string, lines int, filter string) error {
+func getJobOutputHistory(apiClient *client.APIClient, projectID, jobID string, lines int, filter string, lineFilter *lineFilter, sinceLast bool) error {
+	cursorResource := "job-output/" + jobID
+	var cursor logCursor
+	hasCursor := false
+	if sinceLast {
+		cursor, hasCursor = loadLogCursor(projectID, cursorResource)
+	}
+
 	// Build query parameters
 	params := make([]string, 0)
 	params = append(params, fmt.Sprintf("lines=%d", lines))
 	if filter != "" {
 		params = append(params, "type="+filter)
 	}
+	if hasCursor {
+		params = append(params, "since="+cursor.Timestamp.UTC().Format(time.RFC3339Nano))
+	}
 
 	endpoint := fmt.Sprintf("/api/v1/sdk/terminal/%s/jobs/%s/output", projectID, jobID)
 	if len(params) > 0 {
@@ -742,25 +1260,68 @@ func getJobOutputHistory(apiClient *client.APIClient, projectID, jobID string, l
 		return fmt.Errorf("failed to get job output: %w", err)
 	}
 
+	if sinceLast {
+		outputs = outputsAfterCursor(outputs, cursor, hasCursor)
+		if len(outputs) > 0 {
+			last := outputs[len(outputs)-1]
+			if err := saveLogCursor(projectID, cursorResource, logCursor{Line: last.LineNum, Timestamp: last.Timestamp}); err != nil && IsVerbose() {
+				fmt.Fprintf(os.Stderr, "%s Could not save output cursor: %v\n", ui.Yellow("⚠️"), err)
+			}
+		} else if hasCursor {
+			fmt.Printf("%s No new output for job %s since %s\n",
+				ui.Yellow("📄"), ui.Cyan(jobID), cursor.Timestamp.Local().Format("15:04:05"))
+			return nil
+		}
+	}
+
+	if lineFilter != nil {
+		kept := outputs[:0]
+		for _, output := range outputs {
+			if lineFilter.match(output.Content) {
+				output.Content = lineFilter.highlight(output.Content)
+				kept = append(kept, output)
+			}
+		}
+		if len(kept) == 0 && len(outputs) > 0 {
+			scope := fmt.Sprintf("the last %d", lines)
+			if hasCursor {
+				scope = "the new output"
+			}
+			fmt.Printf("%s No lines in %s match the filter for job %s\n",
+				ui.Yellow("📄"), scope, ui.Cyan(jobID))
+			return nil
+		}
+		outputs = kept
+	}
+
 	if len(outputs) == 0 {
 		fmt.Printf("%s No output found for job %s\n",
-			color.YellowString("📄"), color.CyanString(jobID))
+			ui.Yellow("📄"), ui.Cyan(jobID))
 		return nil
 	}
 
-	fmt.Printf("%s Output for job %s (last %d lines):\n\n",
-		color.CyanString("📄"), color.YellowString(jobID), lines)
+	defer startPager().Close()
+
+	if !IsQuiet() {
+		if hasCursor {
+			fmt.Printf("%s New output for job %s since %s:\n\n",
+				ui.Cyan("📄"), ui.Yellow(jobID), cursor.Timestamp.Local().Format("15:04:05"))
+		} else {
+			fmt.Printf("%s Output for job %s (last %d lines):\n\n",
+				ui.Cyan("📄"), ui.Yellow(jobID), lines)
+		}
+	}
 
 	// Display output
 	for _, output := range outputs {
 		timestamp := output.Timestamp.Format("15:04:05")
-		typeColor := color.WhiteString("stdout")
+		typeColor := ui.White("stdout")
 		if output.Type == "stderr" {
-			typeColor = color.RedString("stderr")
+			typeColor = ui.Red("stderr")
 		}
 
 		fmt.Printf("[%s %s] %s",
-			color.MagentaString(timestamp),
+			ui.Magenta(timestamp),
 			typeColor,
 			output.Content)
 	}
@@ -768,6 +1329,25 @@ func getJobOutputHistory(apiClient *client.APIClient, projectID, jobID string, l
 	return nil
 }
 
+// outputsAfterCursor drops the lines already shown by a previous
+// --since-last run, by line number when the server provides one
+func outputsAfterCursor(outputs []JobOutput, cursor logCursor, hasCursor bool) []JobOutput {
+	if !hasCursor {
+		return outputs
+	}
+	kept := outputs[:0]
+	for _, output := range outputs {
+		if cursor.Line > 0 && output.LineNum > 0 {
+			if output.LineNum > cursor.Line {
+				kept = append(kept, output)
+			}
+		} else if output.Timestamp.After(cursor.Timestamp) {
+			kept = append(kept, output)
+		}
+	}
+	return kept
+}
+
 func stopJob(projectID, jobID string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -789,7 +1369,7 @@ func stopJob(projectID, jobID string, cmd *cobra.Command) error {
 	}
 
 	fmt.Printf("%s Job %s stopped successfully\n",
-		color.GreenString("🛑"), color.CyanString(jobID))
+		ui.Green("🛑"), ui.Cyan(jobID))
 
 	return nil
 }
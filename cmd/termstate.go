@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// terminalGuard owns a terminal that has been put into raw mode and makes
+// sure its original state comes back however the command ends: a normal
+// return (via a deferred Restore), a panic (via a deferred RestoreOnPanic) or
+// SIGINT/SIGTERM/SIGQUIT. Interactive commands should use it instead of
+// calling term.MakeRaw directly.
+//
+//	guard, err := enterRawMode(int(os.Stdin.Fd()))
+//	if err != nil {
+//		return err
+//	}
+//	defer guard.RestoreOnPanic()
+//	defer guard.Restore()
+type terminalGuard struct {
+	fd    int
+	state *term.State
+
+	once    sync.Once
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+// enterRawMode saves the state of fd, switches it to raw mode and installs
+// the signal handler that restores it.
+func enterRawMode(fd int) (*terminalGuard, error) {
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put terminal into raw mode: %w", err)
+	}
+
+	guard := &terminalGuard{
+		fd:      fd,
+		state:   state,
+		signals: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+	signal.Notify(guard.signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	go guard.watchSignals()
+
+	return guard, nil
+}
+
+func (g *terminalGuard) watchSignals() {
+	select {
+	case sig := <-g.signals:
+		g.Restore()
+		code := 1
+		if s, ok := sig.(syscall.Signal); ok {
+			code = 128 + int(s)
+		}
+		os.Exit(code)
+	case <-g.done:
+	}
+}
+
+// Restore puts the terminal back into its saved state. It is safe to call
+// more than once and from several goroutines; only the first call acts.
+func (g *terminalGuard) Restore() {
+	g.once.Do(func() {
+		signal.Stop(g.signals)
+		close(g.done)
+		term.Restore(g.fd, g.state)
+	})
+}
+
+// RestoreOnPanic restores the terminal if the surrounding function is
+// panicking, then re-panics so the stack trace is printed on a sane terminal.
+// It must be deferred directly.
+func (g *terminalGuard) RestoreOnPanic() {
+	if r := recover(); r != nil {
+		g.Restore()
+		panic(r)
+	}
+}
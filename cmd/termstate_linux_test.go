@@ -0,0 +1,149 @@
+//go:build linux
+
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// rawModeHelperEnv makes the test binary act as a command holding a
+// terminal in raw mode, see TestRawModeHelper
+const rawModeHelperEnv = "FLEEKS_TEST_RAW_MODE_HELPER"
+
+// TestRawModeHelper is not a test: run as a child of
+// TestRawModeRestoredOnSignal, it puts its stdin terminal into raw mode,
+// reports that on stdout and waits for the signal.
+func TestRawModeHelper(t *testing.T) {
+	if os.Getenv(rawModeHelperEnv) == "" {
+		t.Skip("only runs as a child of TestRawModeRestoredOnSignal")
+	}
+	guard, err := enterRawMode(int(os.Stdin.Fd()))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	defer guard.Restore()
+	fmt.Println("raw")
+	time.Sleep(time.Minute)
+	os.Exit(3)
+}
+
+func TestRawModeRestoredOnSignal(t *testing.T) {
+	for _, sig := range []syscall.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT} {
+		t.Run(sig.String(), func(t *testing.T) {
+			ptmx, pts := openPty(t)
+
+			before := termios(t, pts)
+			if before.Lflag&syscall.ICANON == 0 || before.Lflag&syscall.ECHO == 0 {
+				t.Fatalf("new pty is not in canonical mode: lflag %#x", before.Lflag)
+			}
+
+			child := exec.Command(os.Args[0], "-test.run=^TestRawModeHelper$")
+			child.Env = append(os.Environ(), rawModeHelperEnv+"=1")
+			child.Stdin = pts
+			child.Stderr = os.Stderr
+			stdout, err := child.StdoutPipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := child.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer child.Process.Kill()
+
+			line, err := bufio.NewReader(stdout).ReadString('\n')
+			if err != nil || line != "raw\n" {
+				t.Fatalf("helper did not enter raw mode: %q, %v", line, err)
+			}
+			if raw := termios(t, pts); raw.Lflag&syscall.ICANON != 0 {
+				t.Fatalf("terminal is not in raw mode: lflag %#x", raw.Lflag)
+			}
+
+			if err := child.Process.Signal(sig); err != nil {
+				t.Fatal(err)
+			}
+			err = child.Wait()
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("helper exited with %v, want exit status %d", err, 128+int(sig))
+			}
+			if code := exitErr.ExitCode(); code != 128+int(sig) {
+				t.Errorf("exit status = %d, want %d", code, 128+int(sig))
+			}
+
+			after := termios(t, pts)
+			if after.Lflag != before.Lflag || after.Iflag != before.Iflag || after.Oflag != before.Oflag {
+				t.Errorf("terminal state not restored: lflag %#x iflag %#x oflag %#x, want %#x %#x %#x",
+					after.Lflag, after.Iflag, after.Oflag, before.Lflag, before.Iflag, before.Oflag)
+			}
+			ptmx.Close()
+		})
+	}
+}
+
+// openPty opens a new pseudo-terminal pair. Both ends are closed when the
+// test finishes.
+func openPty(t *testing.T) (ptmx, pts *os.File) {
+	t.Helper()
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminals available: %v", err)
+	}
+	t.Cleanup(func() { ptmx.Close() })
+
+	var unlock int32
+	if err := ioctl(ptmx, syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		t.Fatalf("failed to unlock pty: %v", err)
+	}
+	var n uint32
+	if err := ioctl(ptmx, syscall.TIOCGPTN, unsafe.Pointer(&n)); err != nil {
+		t.Fatalf("failed to get pty number: %v", err)
+	}
+	pts, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Fatalf("failed to open pty: %v", err)
+	}
+	t.Cleanup(func() { pts.Close() })
+	return ptmx, pts
+}
+
+// termios reads the current settings of a terminal
+func termios(t *testing.T, f *os.File) syscall.Termios {
+	t.Helper()
+	var state syscall.Termios
+	if err := ioctl(f, syscall.TCGETS, unsafe.Pointer(&state)); err != nil {
+		t.Fatalf("failed to read terminal state: %v", err)
+	}
+	return state
+}
+
+func ioctl(f *os.File, req uint, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(req), uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
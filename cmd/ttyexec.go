@@ -0,0 +1,172 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// ttyResizeInterval is how often 'terminal exec --tty' checks the local
+// terminal size so the remote PTY follows window resizes
+const ttyResizeInterval = 250 * time.Millisecond
+
+// executeTTYCommand runs a command on a server-side PTY over the exec
+// stream, for 'terminal exec --tty'. Tools that check for a terminal (npm,
+// progress bars, colored output) then behave as they do locally. Keystrokes
+// are forwarded while stdin is a terminal, including Ctrl+C, which reaches
+// the remote command rather than stopping the CLI.
+func executeTTYCommand(apiClient *client.APIClient, projectID string, request CommandRequest) error {
+	stream, err := apiClient.NewStreamReader(fmt.Sprintf("/ws/terminal/%s/exec", projectID))
+	if err != nil {
+		return fmt.Errorf("failed to create command stream: %w", err)
+	}
+	defer stream.Close()
+	defer reportDroppedMessages(stream)
+
+	outFd := int(os.Stdout.Fd())
+	cols, rows, _ := term.GetSize(outFd)
+	start := client.StreamMessage{
+		Type:    client.MessageExec,
+		Content: request.Command,
+		Metadata: map[string]interface{}{
+			"working_dir":     request.WorkingDir,
+			"environment":     request.Environment,
+			"timeout_seconds": request.Timeout,
+			"tty":             request.TTY,
+			"cols":            cols,
+			"rows":            rows,
+		},
+	}
+	if err := stream.Send(start); err != nil {
+		return fmt.Errorf("failed to start command: %w", err)
+	}
+
+	var input chan []byte
+	var signals *signalForwarder
+	raw := false
+	inFd := int(os.Stdin.Fd())
+	if !term.IsTerminal(inFd) {
+		// Ctrl+C arrives as a signal rather than a keystroke
+		signals = forwardSignals()
+		defer signals.Stop()
+	} else {
+		guard, err := enterRawMode(inFd)
+		if err != nil {
+			return err
+		}
+		defer guard.RestoreOnPanic()
+		defer guard.Restore()
+		raw = true
+		input = make(chan []byte, 16)
+		go readShellInput(os.Stdin, input)
+	}
+
+	resize := time.NewTicker(ttyResizeInterval)
+	defer resize.Stop()
+
+	exitCode := -1
+	err = func() error {
+		for {
+			select {
+			case chunk, ok := <-input:
+				if !ok {
+					input = nil
+					continue
+				}
+				if err := stream.Send(client.StreamMessage{Type: client.MessageInput, Content: string(chunk)}); err != nil {
+					return err
+				}
+
+			case sig := <-signals.C():
+				if signals.Quit(sig) {
+					return errInterrupted
+				}
+				if err := stream.Send(signals.Message(sig, true)); err != nil {
+					return err
+				}
+
+			case <-resize.C:
+				newCols, newRows, err := term.GetSize(outFd)
+				if err != nil || (newCols == cols && newRows == rows) {
+					continue
+				}
+				cols, rows = newCols, newRows
+				stream.Send(client.StreamMessage{
+					Type:     client.MessageResize,
+					Metadata: map[string]interface{}{"cols": cols, "rows": rows},
+				})
+
+			case msg, ok := <-stream.Messages():
+				if !ok {
+					// The reader closes both channels when it stops; an
+					// error left behind means the connection dropped
+					select {
+					case err, ok := <-stream.Errors():
+						if ok {
+							return fmt.Errorf("stream error: %w", err)
+						}
+					default:
+					}
+					return nil
+				}
+				switch msg.KnownType() {
+				case client.MessageOutput:
+					os.Stdout.WriteString(msg.Content)
+				case client.MessageError:
+					os.Stdout.WriteString(ui.Red(msg.Content) + "\r\n")
+				case client.MessageExit, client.MessageComplete:
+					if code, ok := msg.Metadata["exit_code"]; ok {
+						exitCode, _ = strconv.Atoi(fmt.Sprintf("%v", code))
+					}
+					return nil
+				default:
+					logUnknownMessage("exec", msg)
+				}
+
+			case err, ok := <-stream.Errors():
+				if !ok {
+					return nil
+				}
+				return fmt.Errorf("stream error: %w", err)
+			}
+		}
+	}()
+
+	// Status lines need a carriage return while the terminal is raw
+	newline := "\n"
+	if raw {
+		newline = "\r\n"
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case exitCode == 0:
+		fmt.Printf("%s%s Command completed successfully (exit code: 0)%s", newline, ui.Green("✅"), newline)
+	case exitCode > 0:
+		fmt.Printf("%s%s Command failed (exit code: %d)%s", newline, ui.Red("❌"), exitCode, newline)
+	}
+	return nil
+}
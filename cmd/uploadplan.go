@@ -0,0 +1,196 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// Actions in an upload plan
+const (
+	uploadActionNew       = "new"
+	uploadActionOverwrite = "overwrite"
+	uploadActionConflict  = "conflict"  // differs, but exists and --overwrite wasn't given
+	uploadActionResend    = "resend"    // unchanged, but --full or an archive sends it anyway
+	uploadActionUnchanged = "unchanged" // skipped
+)
+
+// UploadPlanEntry is what 'files upload --dry-run' would do with one file
+type UploadPlanEntry struct {
+	LocalPath  string `json:"local_path"`
+	RemotePath string `json:"remote_path"`
+	Action     string `json:"action"`
+	Size       int64  `json:"size"`
+}
+
+// UploadPlan is the transfer 'files upload' would make
+type UploadPlan struct {
+	Files     []UploadPlanEntry `json:"files"`
+	New       int               `json:"new"`
+	Overwrite int               `json:"overwrite"`
+	Unchanged int               `json:"unchanged"`
+	Conflicts int               `json:"conflicts"` // differing files left alone without --overwrite
+	Sent      int               `json:"sent"`      // files that would be transferred
+	Bytes     int64             `json:"bytes"`     // their combined size
+	Archive   bool              `json:"archive"`
+}
+
+// Changes is the number of remote files the upload would create or replace
+func (p *UploadPlan) Changes() int {
+	return p.New + p.Overwrite
+}
+
+// planUpload works out what uploading localPath to remotePath would send,
+// comparing local hashes with the workspace's. Directories are walked the
+// way the upload walks them: an archive leaves out
+// workspace.ignore_patterns, a file-by-file upload does not. Files
+// that already match are skipped unless full is set or the directory would
+// go as one archive, which always carries complete contents. Without
+// overwrite, a file that differs from the existing remote copy is a
+// conflict: the upload would refuse it rather than replace it.
+func planUpload(cfg *config.Config, apiClient *client.APIClient, projectID, localPath, remotePath string, isDir, full, archive, overwrite bool) (*UploadPlan, error) {
+	plan := &UploadPlan{}
+	var entries []ChecksumEntry
+	sizes := make(map[string]int64)
+
+	if isDir {
+		files, _, err := collectArchiveEntries(cfg, localPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", localPath, err)
+		}
+		plan.Archive = archive || len(files) >= archiveUploadMinFiles
+		if !plan.Archive {
+			// File by file, the upload sends everything under the directory
+			if files, err = collectUploadFiles(localPath); err != nil {
+				return nil, fmt.Errorf("failed to scan %s: %w", localPath, err)
+			}
+		}
+		for _, file := range files {
+			entry, err := hashLocalFile(file.localPath, joinRemotePath(remotePath, file.name))
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+			sizes[file.localPath] = file.info.Size()
+		}
+	} else {
+		info, err := os.Stat(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("local file not found: %w", err)
+		}
+		entry, err := hashLocalFile(localPath, remotePath)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+		sizes[localPath] = info.Size()
+	}
+
+	report, err := compareEntries(apiClient, projectID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range report.Files {
+		planned := UploadPlanEntry{LocalPath: entry.LocalPath, RemotePath: entry.RemotePath, Size: sizes[entry.LocalPath]}
+		switch {
+		case entry.Status == checksumAdded:
+			planned.Action = uploadActionNew
+			plan.New++
+		case entry.Status == checksumModified && !overwrite:
+			planned.Action = uploadActionConflict
+			plan.Conflicts++
+		case entry.Status == checksumModified:
+			planned.Action = uploadActionOverwrite
+			plan.Overwrite++
+		default:
+			planned.Action = uploadActionUnchanged
+			if full || plan.Archive {
+				planned.Action = uploadActionResend
+			}
+			plan.Unchanged++
+		}
+		if planned.Action != uploadActionUnchanged && planned.Action != uploadActionConflict {
+			plan.Sent++
+			plan.Bytes += planned.Size
+		}
+		plan.Files = append(plan.Files, planned)
+	}
+	return plan, nil
+}
+
+// print lists the files the upload would send and the totals
+func (p *UploadPlan) print() {
+	for _, entry := range p.Files {
+		var label string
+		switch entry.Action {
+		case uploadActionNew:
+			label = ui.Green("%-10s", "NEW")
+		case uploadActionOverwrite:
+			label = ui.Yellow("%-10s", "OVERWRITE")
+		case uploadActionResend:
+			label = ui.Muted("%-10s", "RESEND")
+		case uploadActionConflict:
+			label = ui.Red("%-10s", "CONFLICT")
+		default:
+			continue
+		}
+		fmt.Printf("%s %s %s\n", label, ui.Cyan(entry.RemotePath), ui.Muted("(%s)", formatBytes(entry.Size)))
+	}
+
+	if p.Conflicts > 0 {
+		fmt.Printf("\n%s %d file(s) differ from an existing remote copy and would not be replaced; add --overwrite to replace them\n",
+			ui.Yellow("⚠️"), p.Conflicts)
+	}
+	if p.Sent == 0 {
+		if p.Conflicts == 0 {
+			fmt.Printf("%s All %d file(s) match the workspace; nothing would be uploaded\n", ui.Green("✅"), len(p.Files))
+		}
+		return
+	}
+
+	how := ""
+	if p.Archive {
+		how = " in one compressed archive"
+	}
+	fmt.Printf("\n%s Would upload %d file(s), %s%s: %d new, %d overwritten, %d unchanged (dry run, nothing changed)\n",
+		ui.Yellow("📊"), p.Sent, formatBytes(p.Bytes), how, p.New, p.Overwrite, p.Unchanged)
+}
+
+// collectUploadFiles lists the files under localDir the way uploadDirectory
+// walks them
+func collectUploadFiles(localDir string) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	err := filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, archiveEntry{localPath: path, name: filepath.ToSlash(relPath), info: info})
+		return nil
+	})
+	return entries, err
+}
@@ -0,0 +1,230 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// uploadWatcher re-uploads a local file or directory as it changes, for
+// 'files upload --watch'. Changes are collected until none has arrived for
+// the debounce period, then each changed file is pushed once. It is a
+// one-way push: local deletions are not mirrored.
+type uploadWatcher struct {
+	apiClient  *client.APIClient
+	projectID  string
+	localRoot  string
+	remoteRoot string
+	isDir      bool
+	uploader   *deltaUploader // nil with --full
+	debounce   time.Duration
+
+	watcher *fsnotify.Watcher
+	pending map[string]bool
+}
+
+func newUploadWatcher(apiClient *client.APIClient, projectID, localRoot, remoteRoot string, isDir, full bool, debounce time.Duration) (*uploadWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start file watcher: %w", err)
+	}
+
+	w := &uploadWatcher{
+		apiClient:  apiClient,
+		projectID:  projectID,
+		localRoot:  filepath.Clean(localRoot),
+		remoteRoot: remoteRoot,
+		isDir:      isDir,
+		debounce:   debounce,
+		watcher:    watcher,
+		pending:    make(map[string]bool),
+	}
+	if !full {
+		// Changed files replace their previous upload
+		w.uploader = newDeltaUploader(apiClient, projectID, true)
+	}
+
+	if isDir {
+		err = w.watchTree(w.localRoot)
+	} else {
+		// Editors often replace a file rather than write it in place, so
+		// watch its directory and pick out events for the file
+		err = watcher.Add(filepath.Dir(w.localRoot))
+	}
+	if err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", localRoot, err)
+	}
+	return w, nil
+}
+
+// watchTree adds dir and every directory under it to the watcher, since
+// fsnotify does not watch recursively
+func (w *uploadWatcher) watchTree(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return w.watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// remotePath maps a local file to its place under the remote path
+func (w *uploadWatcher) remotePath(localPath string) string {
+	if !w.isDir {
+		return w.remoteRoot
+	}
+	relPath, err := filepath.Rel(w.localRoot, localPath)
+	if err != nil {
+		return ""
+	}
+	return strings.ReplaceAll(filepath.Join(w.remoteRoot, relPath), "\\", "/")
+}
+
+// handle queues the files affected by one event
+func (w *uploadWatcher) handle(event fsnotify.Event) {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+		return
+	}
+	name := filepath.Clean(event.Name)
+	if !w.isDir {
+		if name == w.localRoot {
+			w.pending[name] = true
+		}
+		return
+	}
+
+	info, err := os.Stat(name)
+	if err != nil {
+		return
+	}
+	if !info.IsDir() {
+		w.pending[name] = true
+		return
+	}
+
+	// A new directory may arrive with files already in it (e.g. a move)
+	if err := w.watchTree(name); err != nil && IsVerbose() {
+		fmt.Printf("%s Could not watch %s: %v\n", ui.Yellow("⚠️"), name, err)
+	}
+	filepath.Walk(name, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			w.pending[path] = true
+		}
+		return nil
+	})
+}
+
+// flush uploads every queued file. Failures are reported and the watch
+// carries on, so one bad write doesn't end the session.
+func (w *uploadWatcher) flush() {
+	paths := make([]string, 0, len(w.pending))
+	for path := range w.pending {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	w.pending = make(map[string]bool)
+
+	for _, localPath := range paths {
+		if info, err := os.Stat(localPath); err != nil || info.IsDir() {
+			continue // removed again before the upload
+		}
+		remotePath := w.remotePath(localPath)
+		if remotePath == "" {
+			continue
+		}
+
+		var err error
+		if w.uploader != nil {
+			err = w.uploader.upload(localPath, remotePath)
+		} else {
+			err = uploadSingleFile(w.apiClient, w.projectID, localPath, remotePath, true, nil)
+		}
+		timestamp := ui.New(color.FgHiBlack).Sprint(time.Now().Format("15:04:05"))
+		if err != nil {
+			fmt.Printf("%s %s Failed to upload %s: %v\n", timestamp, ui.Red("❌"), localPath, err)
+			continue
+		}
+		fmt.Printf("%s %s %s → %s\n", timestamp, ui.Green("📤"),
+			ui.Yellow(localPath), ui.Cyan(remotePath))
+	}
+
+	if w.uploader != nil {
+		if err := w.uploader.state.save(); err != nil && IsVerbose() {
+			fmt.Printf("%s Could not save sync state: %v\n", ui.Yellow("⚠️"), err)
+		}
+	}
+}
+
+// run pushes changes until Ctrl+C
+func (w *uploadWatcher) run() error {
+	defer w.watcher.Close()
+
+	fmt.Printf("\n%s Watching %s for changes (Press Ctrl+C to stop)\n",
+		ui.Cyan("👀"), ui.Yellow(w.localRoot))
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-sigChan:
+			if len(w.pending) > 0 {
+				w.flush()
+			}
+			if w.uploader != nil {
+				fmt.Printf("\n%s %s\n", ui.Cyan("📊"), w.uploader.Summary())
+			}
+			return nil
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil
+			}
+			w.handle(event)
+			if len(w.pending) > 0 {
+				timer.Reset(w.debounce)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("%s Watch error: %v\n", ui.Yellow("⚠️"), err)
+		case <-timer.C:
+			w.flush()
+		}
+	}
+}
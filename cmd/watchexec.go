@@ -0,0 +1,202 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// changeRunner runs a local command in response to remote file changes for
+// 'files watch --exec'. Changes are collected until no new one has arrived
+// for the debounce period; then the command runs once, or once per changed
+// path when it contains the {path} placeholder. Commands run in the
+// background so the watch keeps reading events: while one run is in flight,
+// further changes queue a single rerun that covers all of them.
+type changeRunner struct {
+	command  string
+	match    string
+	debounce time.Duration
+	timer    *time.Timer
+	out      io.Writer
+
+	mu      sync.Mutex
+	pending map[string]bool
+	running bool
+	queued  bool
+	wg      sync.WaitGroup
+}
+
+func newChangeRunner(command, match string, debounce time.Duration) (*changeRunner, error) {
+	if match != "" {
+		if _, err := path.Match(match, ""); err != nil {
+			return nil, fmt.Errorf("invalid --match pattern %q: %w", match, err)
+		}
+	}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	return &changeRunner{
+		command:  command,
+		match:    match,
+		debounce: debounce,
+		pending:  make(map[string]bool),
+		timer:    timer,
+		out:      os.Stdout,
+	}, nil
+}
+
+// matches reports whether a changed path passes the --match glob, which is
+// tried against both the full path and the base name
+func (r *changeRunner) matches(changedPath string) bool {
+	if r.match == "" {
+		return true
+	}
+	if ok, _ := path.Match(r.match, changedPath); ok {
+		return true
+	}
+	ok, _ := path.Match(r.match, path.Base(changedPath))
+	return ok
+}
+
+// add records a change and restarts the debounce timer
+func (r *changeRunner) add(changedPath string) {
+	if !r.matches(changedPath) {
+		return
+	}
+	r.mu.Lock()
+	r.pending[changedPath] = true
+	r.mu.Unlock()
+	r.timer.Reset(r.debounce)
+}
+
+// ready fires when the debounce period has passed without new changes
+func (r *changeRunner) ready() <-chan time.Time {
+	return r.timer.C
+}
+
+// run starts the command for everything collected so far, or queues a
+// rerun if it is already running
+func (r *changeRunner) run() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pending) == 0 {
+		return
+	}
+	if r.running {
+		r.queued = true
+		return
+	}
+	r.running = true
+	r.wg.Add(1)
+	go r.loop()
+}
+
+// loop runs the command, then again as long as a rerun was queued meanwhile
+func (r *changeRunner) loop() {
+	defer r.wg.Done()
+	for {
+		r.mu.Lock()
+		paths := make([]string, 0, len(r.pending))
+		for p := range r.pending {
+			paths = append(paths, p)
+		}
+		r.pending = make(map[string]bool)
+		r.queued = false
+		r.mu.Unlock()
+
+		sort.Strings(paths)
+		r.runPaths(paths)
+
+		r.mu.Lock()
+		if !r.queued {
+			r.running = false
+			r.mu.Unlock()
+			return
+		}
+		r.mu.Unlock()
+	}
+}
+
+// wait blocks until the running command and any queued rerun have finished
+func (r *changeRunner) wait() {
+	r.wg.Wait()
+}
+
+// runPaths executes the command for the given changed paths
+func (r *changeRunner) runPaths(paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	if !strings.Contains(r.command, "{path}") {
+		r.exec(r.command, fmt.Sprintf("%d change(s)", len(paths)))
+		return
+	}
+	for _, p := range paths {
+		r.exec(strings.ReplaceAll(r.command, "{path}", shellQuote(p)), p)
+	}
+}
+
+func (r *changeRunner) exec(command, reason string) {
+	fmt.Fprintf(r.out, "%s %s %s\n",
+		ui.Cyan("▶"),
+		ui.New(color.Bold).Sprint(command),
+		ui.New(color.FgHiBlack).Sprintf("(%s)", reason))
+
+	start := time.Now()
+	output, err := shellCommand(command).CombinedOutput()
+	if len(output) > 0 {
+		r.out.Write(output)
+		if !strings.HasSuffix(string(output), "\n") {
+			fmt.Fprintln(r.out)
+		}
+	}
+
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(r.out, "%s Command failed after %s: %v\n", ui.Red("❌"), elapsed, err)
+		return
+	}
+	fmt.Fprintf(r.out, "%s Command finished in %s\n", ui.Green("✅"), elapsed)
+}
+
+// shellCommand runs command through the platform shell
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// shellQuote quotes s as a single argument for the platform shell
+func shellQuote(s string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -17,18 +17,24 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
-	"github.com/olekukonko/tablewriter"
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/fleeks-inc/fleeks-cli/internal/client"
 	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
 )
 
 // workspaceCmd represents the workspace command
@@ -49,6 +55,12 @@ Examples:
   # Create new workspace with template
   fleeks workspace create my-api --template microservices
   
+  # See which templates are available
+  fleeks workspace templates
+
+  # Detect languages and template from the current directory
+  fleeks workspace create my-app --detect
+
   # Create local workspace, sync to cloud later
   fleeks workspace create my-app --local --template python
   
@@ -57,7 +69,13 @@ Examples:
   
   # Get workspace information
   fleeks workspace info my-api
+
+  # Get merged local + cloud workspace details as JSON
+  fleeks workspace info my-api --output json
   
+  # Show resource usage for the last week
+  fleeks workspace usage my-api --since 7d
+
   # Sync local workspace to cloud
   fleeks workspace sync my-app --watch
   
@@ -77,10 +95,37 @@ This command creates either:
 3. Both local and cloud workspaces simultaneously
 
 The workspace supports multiple programming languages and frameworks
-through intelligent template system.`,
-	Args: cobra.ExactArgs(1),
+through intelligent template system.
+
+Run with --interactive (or without a project ID in a terminal) for a
+guided wizard that walks through name, template, languages, location
+and description.
+
+Use --from to create a cloud workspace from an existing local project in one
+step: languages and template are detected from the directory, and once the
+container is ready its files are uploaded, skipping workspace.ignore_patterns.
+If the container doesn't come up, nothing is uploaded and the command exits
+non-zero after printing the 'files upload' command to retry with:
+
+  fleeks workspace create my-api --from .`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return createWorkspace(args[0], cmd)
+		projectID := ""
+		if len(args) > 0 {
+			projectID = args[0]
+		}
+		return createWorkspace(projectID, cmd)
+	},
+}
+
+var workspaceTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List available workspace templates",
+	Long: `List the workspace templates available for 'workspace create --template'.
+
+The list is cached locally for a few minutes; use --refresh to fetch it again.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listWorkspaceTemplates(cmd)
 	},
 }
 
@@ -103,13 +148,42 @@ var workspaceInfoCmd = &cobra.Command{
 - Active AI software engineers
 - File sync status
 - Template information
-- Usage metrics`,
+- Usage metrics
+
+With --tree (or --full) the container, active agents and recent file changes
+are fetched at the same time and shown together as one tree. A section that
+can't be loaded is marked unavailable and the rest is still shown.
+
+Examples:
+  fleeks workspace info my-project
+  fleeks workspace info my-project --tree
+  fleeks workspace info my-project --full --output json`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return getWorkspaceInfo(args[0], cmd)
 	},
 }
 
+var workspaceUsageCmd = &cobra.Command{
+	Use:   "usage [project-id]",
+	Short: "Show workspace resource usage",
+	Long: `Show aggregate resource consumption for a workspace over a time window:
+CPU-hours, memory GB-hours, storage GB-months, network egress and agent runs.
+
+--since and --until accept RFC3339 timestamps, dates (2006-01-02) or
+durations relative to now (90m, 24h, 7d). Without them the server's default
+window (usually the current billing period) is used.
+
+Examples:
+  fleeks workspace usage my-api
+  fleeks workspace usage my-api --since 7d
+  fleeks workspace usage my-api --since 2024-01-01 --until 2024-02-01 --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getWorkspaceUsage(args[0], cmd)
+	},
+}
+
 var workspaceSyncCmd = &cobra.Command{
 	Use:   "sync [project-id]",
 	Short: "Sync local workspace to cloud",
@@ -119,7 +193,18 @@ Features:
 - Smart sync (only changed files)
 - Real-time file watching
 - Conflict resolution
-- Bidirectional sync support`,
+- Bidirectional sync support
+
+--direction chooses which way files flow: push (local to cloud, the
+default), pull (cloud to local) or both. A two-way sync compares each file
+with the version recorded at the last sync to tell which side changed;
+files changed on both sides are reported as conflicts and left alone.
+
+Use --delete to mirror one side exactly by also removing files that were
+deleted from the other: cloud files with push, local files with pull
+(excluded paths are never touched). The deletions are listed and confirmed
+first; --dry-run shows what would change without changing anything, and
+deleting more than 25 files needs --force.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return syncWorkspace(args[0], cmd)
@@ -149,22 +234,64 @@ func init() {
 	workspaceCmd.AddCommand(workspaceInfoCmd)
 	workspaceCmd.AddCommand(workspaceSyncCmd)
 	workspaceCmd.AddCommand(workspaceDeleteCmd)
+	workspaceCmd.AddCommand(workspaceTemplatesCmd)
+	workspaceCmd.AddCommand(workspaceUsageCmd)
+
+	// Usage command flags
+	workspaceUsageCmd.Flags().String("since", "", "Start of the window (RFC3339, date, or duration ago like 7d)")
+	workspaceUsageCmd.Flags().String("until", "", "End of the window (RFC3339, date, or duration ago like 1d)")
+
+	// List command flags
+	workspaceListCmd.Flags().StringSlice("columns", nil, columnsFlagUsage)
+
+	// Templates command flags
+	workspaceTemplatesCmd.Flags().Bool("refresh", false, "Ignore the cached template list")
 
 	// Create command flags
 	workspaceCreateCmd.Flags().StringP("template", "t", "", "Workspace template (python, node, go, rust, microservices, etc.)")
+	registerFlagCompletion(workspaceCreateCmd, "template", completeWorkspaceTemplates)
 	workspaceCreateCmd.Flags().BoolP("local", "l", false, "Create local workspace only")
 	workspaceCreateCmd.Flags().BoolP("cloud", "c", false, "Create cloud workspace only")
 	workspaceCreateCmd.Flags().StringP("description", "d", "", "Workspace description")
 	workspaceCreateCmd.Flags().StringSliceP("languages", "", []string{}, "Programming languages to support")
+	workspaceCreateCmd.Flags().Bool("detect", false, "Detect languages and suggest a template from a local directory")
+	workspaceCreateCmd.Flags().String("from", "", "Local project directory to detect languages from and upload (implies --detect)")
+	workspaceCreateCmd.Flags().BoolP("yes", "y", false, "Accept detected settings without confirmation")
+	workspaceCreateCmd.Flags().BoolP("interactive", "i", false, "Walk through workspace settings interactively")
+	workspaceCreateCmd.Flags().String("id-file", "", idFileFlagUsage)
+	workspaceCreateCmd.MarkFlagsMutuallyExclusive("local", "cloud")
 
 	// Sync command flags
 	workspaceSyncCmd.Flags().BoolP("watch", "w", false, "Watch for file changes and sync continuously")
-	workspaceSyncCmd.Flags().BoolP("bidirectional", "b", false, "Enable bidirectional sync (cloud to local)")
-	workspaceSyncCmd.Flags().StringP("exclude", "e", "", "File patterns to exclude from sync")
+	workspaceSyncCmd.Flags().String("direction", syncPush, "Sync direction: push (local to cloud), pull (cloud to local) or both")
+	workspaceSyncCmd.Flags().BoolP("bidirectional", "b", false, "Sync in both directions (same as --direction both)")
+	workspaceSyncCmd.Flags().MarkDeprecated("bidirectional", "use --direction both instead")
+	workspaceSyncCmd.Flags().String("exclude", "", "Comma-separated file patterns to exclude from sync")
+	workspaceSyncCmd.Flags().Bool("delete", false, "Delete files removed from the other side (cloud files with push, local files with pull)")
+	workspaceSyncCmd.Flags().Bool("dry-run", false, "Show what would be synced and deleted without changing anything")
+	workspaceSyncCmd.Flags().Bool("force", false, "Allow --delete to remove more than 25 files")
+	workspaceSyncCmd.Flags().BoolP("yes", "y", false, "Delete without confirmation")
+	workspaceSyncCmd.MarkFlagsMutuallyExclusive("direction", "bidirectional")
+	workspaceSyncCmd.MarkFlagsMutuallyExclusive("watch", "delete")
+	workspaceSyncCmd.MarkFlagsMutuallyExclusive("watch", "dry-run")
+	markFlagRequires(workspaceSyncCmd, "force", "delete")
+	markFlagRequires(workspaceSyncCmd, "yes", "delete")
 
 	// Delete command flags
 	workspaceDeleteCmd.Flags().BoolP("force", "f", false, "Force delete without confirmation")
 	workspaceDeleteCmd.Flags().BoolP("keep-local", "", false, "Keep local files when deleting")
+
+	workspaceInfoCmd.Flags().Bool("tree", false, "Also show the container, active agents and recent file changes")
+	workspaceInfoCmd.Flags().Bool("full", false, "Same as --tree")
+
+	// Accept --project in place of the [project-id] argument
+	acceptProjectFlag(
+		workspaceCreateCmd,
+		workspaceInfoCmd,
+		workspaceUsageCmd,
+		workspaceSyncCmd,
+		workspaceDeleteCmd,
+	)
 }
 
 // WorkspaceCreateRequest represents the workspace creation request
@@ -195,6 +322,23 @@ type WorkspaceResponse struct {
 	} `json:"resource_usage,omitempty"`
 }
 
+// Sync status values reported in WorkspaceDetail
+const (
+	syncStatusCloudOnly    = "cloud-only"
+	syncStatusSynced       = "synced"
+	syncStatusLocalChanges = "local-changes"
+)
+
+// WorkspaceDetail combines a cloud workspace with information about its local copy
+type WorkspaceDetail struct {
+	WorkspaceResponse
+	LocalPath      string   `json:"local_path"`
+	LocalExists    bool     `json:"local_exists"`
+	LocalFileCount int      `json:"local_file_count"`
+	LocalErrors    []string `json:"local_errors,omitempty"`
+	SyncStatus     string   `json:"sync_status"`
+}
+
 func createWorkspace(projectID string, cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -207,24 +351,81 @@ func createWorkspace(projectID string, cmd *cobra.Command) error {
 
 	// Get flags
 	template, _ := cmd.Flags().GetString("template")
-	if template == "" {
-		template = cfg.Workspace.DefaultTemplate
-	}
-
 	description, _ := cmd.Flags().GetString("description")
 	languages, _ := cmd.Flags().GetStringSlice("languages")
 	localOnly, _ := cmd.Flags().GetBool("local")
 	cloudOnly, _ := cmd.Flags().GetBool("cloud")
+	detect, _ := cmd.Flags().GetBool("detect")
+	fromDir, _ := cmd.Flags().GetString("from")
+	assumeYes, _ := cmd.Flags().GetBool("yes")
+	interactive, _ := cmd.Flags().GetBool("interactive")
+
+	fromDir, err = expandPath(fromDir)
+	if err != nil {
+		return err
+	}
 
 	// Create API client
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
-	// Start spinner
-	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
-	s.Suffix = " Creating workspace..."
-	s.Start()
-	defer s.Stop()
+	// Without a project ID, fall back to the wizard when someone can answer it
+	if projectID == "" && !interactive {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("project-id is required (or run in a terminal for the interactive wizard)")
+		}
+		interactive = true
+	}
+
+	if interactive {
+		answers := workspaceWizardAnswers{
+			ProjectID:   projectID,
+			Template:    template,
+			Languages:   languages,
+			Description: description,
+			LocalOnly:   localOnly,
+			CloudOnly:   cloudOnly,
+		}
+		if err := runCreateWizard(apiClient, cfg, &answers); err != nil {
+			return err
+		}
+		projectID = answers.ProjectID
+		template = answers.Template
+		languages = answers.Languages
+		description = answers.Description
+		localOnly = answers.LocalOnly
+		cloudOnly = answers.CloudOnly
+	}
+
+	// Fill in languages and template from local project markers
+	if detect || fromDir != "" {
+		detected, suggested, err := detectProjectLanguages(fromDir)
+		if err != nil {
+			return err
+		}
+		languages, template, err = applyDetectedSettings(detected, suggested, languages, template, assumeYes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if template == "" && !cmd.Flags().Changed("template") {
+		template = cfg.Workspace.DefaultTemplate
+	}
+
+	// Catch template typos before the server does
+	if err := validateTemplate(apiClient, template); err != nil {
+		return err
+	}
+
+	// One key per logical create so retries can't produce duplicates
+	idempotencyKey := client.NewIdempotencyKey()
+	if IsVerbose() {
+		fmt.Printf("%s %s\n", ui.Muted("Idempotency-Key:"), idempotencyKey)
+	}
+
+	status := newStatusReporter()
+	defer status.Stop()
 
 	// Prepare request
 	request := WorkspaceCreateRequest{
@@ -237,69 +438,564 @@ func createWorkspace(projectID string, cmd *cobra.Command) error {
 	}
 
 	// Create workspace
+	status.Start("Creating workspace")
 	var response WorkspaceResponse
-	if err := apiClient.POST("/api/v1/sdk/workspaces", request, &response); err != nil {
-		s.Stop()
-		return fmt.Errorf("failed to create workspace: %w", err)
+	if err := apiClient.POSTIdempotent("/api/v1/sdk/workspaces", idempotencyKey, request, &response); err != nil {
+		return status.Fail(fmt.Errorf("failed to create workspace: %w", err))
 	}
+	status.Done("Workspace created")
+	invalidateListCache(apiClient, "/api/v1/sdk/workspaces")
 
-	s.Stop()
+	createdID := response.ProjectID
+	if createdID == "" {
+		createdID = projectID
+	}
+	if err := writeIDFile(cmd, createdID); err != nil {
+		return err
+	}
 
 	// Create local workspace directory if needed
 	if !cloudOnly {
+		status.Start("Creating local directory")
 		localPath := cfg.GetWorkspacePath(projectID)
 		if err := os.MkdirAll(localPath, 0755); err != nil {
-			fmt.Printf("%s Failed to create local directory: %v\n",
-				color.YellowString("âš ï¸"), err)
+			status.Warn(err.Error())
+		} else {
+			status.Done("Local workspace: " + localPath)
+		}
+	}
+
+	// The container may still be starting when the create call returns
+	containerReady := !localOnly && response.Status != "failed"
+	if !localOnly && isPendingWorkspaceStatus(response.Status) {
+		status.Start("Waiting for container")
+		if ready, err := waitForWorkspaceReady(apiClient, projectID, status); err != nil {
+			status.Warn(err.Error())
+			containerReady = false
 		} else {
-			fmt.Printf("%s Local workspace created: %s\n",
-				color.GreenString("ðŸ“"), localPath)
+			response.Status = ready.Status
+			if response.ContainerID == "" {
+				response.ContainerID = ready.ContainerID
+			}
+			status.Done("Container ready")
+		}
+	}
+
+	// Seed the cloud workspace from --from once its container is up. If it
+	// didn't come up, uploading would only fail file by file.
+	var seeded *BulkResult
+	seedSkipped := false
+	if fromDir != "" {
+		status.Start("Uploading " + fromDir)
+		switch {
+		case localOnly:
+			status.Skip("local-only workspace")
+		case !containerReady:
+			status.Warn("skipped, the container is not ready")
+			seedSkipped = true
+		default:
+			seeded = seedWorkspace(cfg, apiClient, createdID, fromDir, status)
 		}
 	}
 
 	// Success output with preview URLs
 	fmt.Println()
-	fmt.Println(color.GreenString("✅ Workspace '%s' created successfully!", projectID))
+	fmt.Println(ui.Green("✅ Workspace '%s' created successfully!", projectID))
 	fmt.Println()
-	fmt.Printf("📦 Container ID: %s\n", color.BlueString(response.ContainerID))
-	fmt.Printf("🏷️  Template: %s\n", color.YellowString(response.Template))
+	fmt.Printf("📦 Container ID: %s\n", ui.Blue(response.ContainerID))
+	fmt.Printf("🏷️  Template: %s\n", ui.Yellow(response.Template))
 	if response.PreviewURL != "" {
-		fmt.Printf("🌐 Preview URL: %s\n", color.CyanString(response.PreviewURL))
+		fmt.Printf("🌐 Preview URL: %s\n", ui.Cyan(response.PreviewURL))
 	}
 	if response.WebSocketURL != "" {
-		fmt.Printf("🔌 WebSocket URL: %s\n", color.CyanString(response.WebSocketURL))
+		fmt.Printf("🔌 WebSocket URL: %s\n", ui.Cyan(response.WebSocketURL))
 	}
 	fmt.Println()
-	fmt.Println(color.YellowString("💡 Start your application in the workspace:"))
+	fmt.Println(ui.Yellow("💡 Start your application in the workspace:"))
 	
 	// Template-specific examples
 	switch response.Template {
 	case "python":
-		fmt.Printf("   %s\n", color.CyanString(fmt.Sprintf("fleeks terminal exec %s \"python -m http.server 8080\"", projectID)))
+		fmt.Printf("   %s\n", ui.Cyan(fmt.Sprintf("fleeks terminal exec %s \"python -m http.server 8080\"", projectID)))
 	case "node", "nodejs":
-		fmt.Printf("   %s\n", color.CyanString(fmt.Sprintf("fleeks terminal exec %s \"npm start\"", projectID)))
+		fmt.Printf("   %s\n", ui.Cyan(fmt.Sprintf("fleeks terminal exec %s \"npm start\"", projectID)))
 	case "go":
-		fmt.Printf("   %s\n", color.CyanString(fmt.Sprintf("fleeks terminal exec %s \"go run main.go\"", projectID)))
+		fmt.Printf("   %s\n", ui.Cyan(fmt.Sprintf("fleeks terminal exec %s \"go run main.go\"", projectID)))
 	default:
-		fmt.Printf("   %s\n", color.CyanString(fmt.Sprintf("fleeks terminal exec %s \"<your-start-command>\"", projectID)))
+		fmt.Printf("   %s\n", ui.Cyan(fmt.Sprintf("fleeks terminal exec %s \"<your-start-command>\"", projectID)))
 	}
 	
 	fmt.Println()
 	if response.PreviewURL != "" {
-		fmt.Printf("🚀 Then access it at: %s\n", color.CyanString(response.PreviewURL))
+		fmt.Printf("🚀 Then access it at: %s\n", ui.Cyan(response.PreviewURL))
 		fmt.Println()
 	}
 
 	// Show next steps
-	fmt.Printf("%s\n", color.New(color.Bold).Sprint("🚀 Next steps:"))
-	fmt.Printf("  %s\n", color.CyanString("fleeks preview "+projectID))
-	fmt.Printf("  %s\n", color.CyanString("fleeks agent start --project "+projectID+" --task \"Build authentication system\""))
-	fmt.Printf("  %s\n", color.CyanString("fleeks workspace info "+projectID))
+	fmt.Printf("%s\n", ui.New(color.Bold).Sprint("🚀 Next steps:"))
+	fmt.Printf("  %s\n", ui.Cyan("fleeks preview "+projectID))
+	fmt.Printf("  %s\n", ui.Cyan("fleeks agent start --project "+projectID+" --task \"Build authentication system\""))
+	fmt.Printf("  %s\n", ui.Cyan("fleeks workspace info "+projectID))
 	fmt.Println()
 
+	if seedSkipped {
+		fmt.Printf("Upload %s once the container is running with %s\n", fromDir,
+			ui.Cyan(fmt.Sprintf("fleeks files upload %s %s / --recursive", createdID, fromDir)))
+		cmd.SilenceUsage = true
+		return fmt.Errorf("workspace created, but %s was not uploaded because the container is not ready", fromDir)
+	}
+	if seeded != nil && len(seeded.Failures()) > 0 {
+		seeded.PrintSummary()
+		fmt.Printf("Retry the failed files with %s\n",
+			ui.Cyan(fmt.Sprintf("fleeks files upload %s <file> <remote-path>", projectID)))
+		return seeded.Err(cmd)
+	}
+	return nil
+}
+
+// seedWorkspace uploads the files under dir to the root of a new cloud
+// workspace for 'workspace create --from', skipping the same files as
+// 'workspace sync'. Progress is shown on the running status step.
+func seedWorkspace(cfg *config.Config, apiClient *client.APIClient, projectID, dir string, status *statusReporter) *BulkResult {
+	result := newBulkResult("file", "upload")
+	syncer := &workspaceSyncer{cfg: cfg, apiClient: apiClient, projectID: projectID, localRoot: dir}
+	local, err := syncer.localFiles()
+	if err != nil {
+		status.Warn(err.Error())
+		result.Record(dir, err)
+		return result
+	}
+
+	remotePaths := make([]string, 0, len(local))
+	for remotePath := range local {
+		remotePaths = append(remotePaths, remotePath)
+	}
+	sort.Strings(remotePaths)
+
+	uploader := newDeltaUploader(apiClient, projectID, true)
+	for i, remotePath := range remotePaths {
+		status.Update(fmt.Sprintf("%d/%d %s", i+1, len(remotePaths), remotePath))
+		uploader.progress = func(detail string) {
+			status.Update(fmt.Sprintf("%d/%d %s", i+1, len(remotePaths), detail))
+		}
+		result.Record(remotePath, uploader.upload(local[remotePath], remotePath))
+	}
+	if err := uploader.state.save(); err != nil && IsVerbose() {
+		fmt.Printf("%s Could not save sync state: %v\n", ui.Yellow("⚠️"), err)
+	}
+
+	if failed := len(result.Failures()); failed > 0 {
+		status.Warn(fmt.Sprintf("%d of %d files failed", failed, result.Total()))
+	} else {
+		status.Done(fmt.Sprintf("Uploaded %d files from %s", result.Total(), dir))
+	}
+	return result
+}
+
+// workspaceReadyTimeout bounds how long 'workspace create' waits for the
+// container to come up
+const workspaceReadyTimeout = 2 * time.Minute
+
+// isPendingWorkspaceStatus reports whether a workspace is still coming up
+func isPendingWorkspaceStatus(status string) bool {
+	switch status {
+	case "creating", "pending", "provisioning", "starting":
+		return true
+	}
+	return false
+}
+
+// waitForWorkspaceReady polls a new workspace until its container leaves the
+// pending states, showing the current state on the running step
+func waitForWorkspaceReady(apiClient *client.APIClient, projectID string, status *statusReporter) (WorkspaceResponse, error) {
+	deadline := time.Now().Add(workspaceReadyTimeout)
+	endpoint := fmt.Sprintf("/api/v1/sdk/workspaces/%s", projectID)
+	for {
+		var workspace WorkspaceResponse
+		if err := apiClient.GET(endpoint, &workspace); err != nil {
+			return workspace, fmt.Errorf("failed to get workspace status: %w", err)
+		}
+		if !isPendingWorkspaceStatus(workspace.Status) {
+			if workspace.Status == "failed" {
+				return workspace, fmt.Errorf("container failed to start")
+			}
+			return workspace, nil
+		}
+		status.Update(workspace.Status)
+
+		if time.Now().After(deadline) {
+			return workspace, fmt.Errorf("still %s after %s; check 'fleeks workspace info %s'",
+				workspace.Status, workspaceReadyTimeout, projectID)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// WorkspaceTemplate describes a template available for workspace creation
+type WorkspaceTemplate struct {
+	Name        string   `json:"name"`
+	Languages   []string `json:"languages"`
+	Description string   `json:"description"`
+}
+
+// fetchTemplates returns the available templates, served from the local cache
+// when it is fresh unless refresh is set
+func fetchTemplates(apiClient *client.APIClient, refresh bool) ([]WorkspaceTemplate, error) {
+	var templates []WorkspaceTemplate
+	if err := cachedGET(apiClient, "/api/v1/sdk/templates", templateCacheTTL, refresh, &templates); err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return templates, nil
+}
+
+// validateTemplate checks the template against the server's list, suggesting
+// close matches for typos. If the list can't be fetched the server gets the
+// final say, so validation is skipped rather than blocking creation. An
+// empty name is rejected either way.
+func validateTemplate(apiClient *client.APIClient, template string) error {
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("unknown template %q. Run 'fleeks workspace templates' to see available templates", template)
+	}
+
+	templates, err := fetchTemplates(apiClient, false)
+	if errors.Is(err, client.ErrRawResponse) {
+		return err
+	}
+	if err != nil || len(templates) == 0 {
+		if IsVerbose() && err != nil {
+			fmt.Printf("%s Skipping template validation: %v\n", ui.Yellow("⚠️"), err)
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(templates))
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, template) {
+			return nil
+		}
+		names = append(names, t.Name)
+	}
+
+	if suggestions := closestMatches(template, names, 2); len(suggestions) > 0 {
+		return fmt.Errorf("unknown template %q. Did you mean: %s?", template, strings.Join(suggestions, ", "))
+	}
+	return fmt.Errorf("unknown template %q. Run 'fleeks workspace templates' to see available templates", template)
+}
+
+// closestMatches returns candidates within maxDistance edits of value, nearest first
+func closestMatches(value string, candidates []string, maxDistance int) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	for _, candidate := range candidates {
+		d := editDistance(strings.ToLower(value), strings.ToLower(candidate))
+		if d <= maxDistance || strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(value)) {
+			matches = append(matches, match{candidate, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+
+	result := make([]string, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, m.name)
+	}
+	return result
+}
+
+// editDistance computes the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func listWorkspaceTemplates(cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	refresh, _ := cmd.Flags().GetBool("refresh")
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	templates, err := fetchTemplates(apiClient, refresh)
+	if err != nil {
+		return err
+	}
+
+	if isJSONOutput() {
+		return printJSON(templates)
+	}
+
+	if len(templates) == 0 {
+		fmt.Printf("%s No templates available.\n", ui.Yellow("📭"))
+		return nil
+	}
+
+	// Create table
+	table := newListTable("Template", "Languages", "Description")
+	table.SetHeaderColor(
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiWhite),
+	)
+
+	for _, t := range templates {
+		table.Append([]string{t.Name, strings.Join(t.Languages, ", "), t.Description})
+	}
+
+	fmt.Printf("\n%s %s\n\n",
+		ui.New(color.Bold).Sprint("📦 Workspace Templates:"),
+		ui.Green(fmt.Sprintf("(%d total)", len(templates))))
+
+	table.Render()
+	return nil
+}
+
+// workspaceWizardAnswers holds the settings collected by the create wizard,
+// pre-filled from any flags that were given.
+type workspaceWizardAnswers struct {
+	ProjectID   string
+	Template    string
+	Languages   []string
+	Description string
+	LocalOnly   bool
+	CloudOnly   bool
+}
+
+// Workspace location choices offered by the wizard
+const (
+	wizardLocationBoth  = "Local and cloud"
+	wizardLocationCloud = "Cloud only"
+	wizardLocationLocal = "Local only"
+)
+
+// runCreateWizard prompts for each workspace setting in turn
+func runCreateWizard(apiClient *client.APIClient, cfg *config.Config, answers *workspaceWizardAnswers) error {
+	fmt.Printf("\n%s\n\n", ui.New(color.Bold).Sprint("🧙 Create a new workspace"))
+
+	// Project name
+	namePrompt := promptui.Prompt{
+		Label:   "Project name",
+		Default: answers.ProjectID,
+		Validate: func(input string) error {
+			input = strings.TrimSpace(input)
+			if input == "" {
+				return fmt.Errorf("project name cannot be empty")
+			}
+			if strings.ContainsAny(input, " /\\") {
+				return fmt.Errorf("project name cannot contain spaces or slashes")
+			}
+			return nil
+		},
+	}
+	name, err := namePrompt.Run()
+	if err != nil {
+		return fmt.Errorf("workspace creation cancelled")
+	}
+	answers.ProjectID = strings.TrimSpace(name)
+
+	// Template, from the server list when available
+	templates, err := fetchTemplates(apiClient, false)
+	if errors.Is(err, client.ErrRawResponse) {
+		return err
+	}
+	var selected *WorkspaceTemplate
+	if err == nil && len(templates) > 0 {
+		cursor := 0
+		for i, t := range templates {
+			if t.Name == answers.Template || (answers.Template == "" && t.Name == cfg.Workspace.DefaultTemplate) {
+				cursor = i
+			}
+		}
+		templateSelect := promptui.Select{
+			Label:     "Template",
+			Items:     templates,
+			CursorPos: cursor,
+			Size:      10,
+			Templates: &promptui.SelectTemplates{
+				Label:    "{{ . }}",
+				Active:   "▸ {{ .Name | cyan }} {{ .Description | faint }}",
+				Inactive: "  {{ .Name }} {{ .Description | faint }}",
+				Selected: "Template: {{ .Name | green }}",
+			},
+		}
+		index, _, err := templateSelect.Run()
+		if err != nil {
+			return fmt.Errorf("workspace creation cancelled")
+		}
+		selected = &templates[index]
+		answers.Template = selected.Name
+	} else {
+		defaultTemplate := answers.Template
+		if defaultTemplate == "" {
+			defaultTemplate = cfg.Workspace.DefaultTemplate
+		}
+		templatePrompt := promptui.Prompt{Label: "Template", Default: defaultTemplate}
+		value, err := templatePrompt.Run()
+		if err != nil {
+			return fmt.Errorf("workspace creation cancelled")
+		}
+		answers.Template = strings.TrimSpace(value)
+	}
+
+	// Languages, defaulting to what the template supports
+	defaultLanguages := answers.Languages
+	if len(defaultLanguages) == 0 && selected != nil {
+		defaultLanguages = selected.Languages
+	}
+	languagesPrompt := promptui.Prompt{
+		Label:   "Languages (comma separated)",
+		Default: strings.Join(defaultLanguages, ","),
+	}
+	value, err := languagesPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("workspace creation cancelled")
+	}
+	answers.Languages = nil
+	for _, language := range strings.Split(value, ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			answers.Languages = append(answers.Languages, language)
+		}
+	}
+
+	// Location
+	locations := []string{wizardLocationBoth, wizardLocationCloud, wizardLocationLocal}
+	cursor := 0
+	if answers.CloudOnly {
+		cursor = 1
+	} else if answers.LocalOnly {
+		cursor = 2
+	}
+	locationSelect := promptui.Select{Label: "Where should the workspace live", Items: locations, CursorPos: cursor}
+	_, location, err := locationSelect.Run()
+	if err != nil {
+		return fmt.Errorf("workspace creation cancelled")
+	}
+	answers.LocalOnly = location == wizardLocationLocal
+	answers.CloudOnly = location == wizardLocationCloud
+
+	// Description
+	descriptionPrompt := promptui.Prompt{Label: "Description (optional)", Default: answers.Description}
+	description, err := descriptionPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("workspace creation cancelled")
+	}
+	answers.Description = strings.TrimSpace(description)
+
+	fmt.Println()
 	return nil
 }
 
+// languageMarkers maps files found at a project root to the language they imply,
+// in the order used to pick a suggested template.
+var languageMarkers = []struct {
+	File     string
+	Language string
+}{
+	{"package.json", "node"},
+	{"go.mod", "go"},
+	{"requirements.txt", "python"},
+	{"pyproject.toml", "python"},
+	{"Pipfile", "python"},
+	{"setup.py", "python"},
+	{"Cargo.toml", "rust"},
+}
+
+// detectProjectLanguages scans dir (or the current directory) for language
+// marker files, returning the languages found and a suggested template.
+func detectProjectLanguages(dir string) ([]string, string, error) {
+	if dir == "" {
+		dir = "."
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot inspect %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, "", fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var languages []string
+	seen := make(map[string]bool)
+	for _, marker := range languageMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker.File)); err != nil {
+			continue
+		}
+		if !seen[marker.Language] {
+			seen[marker.Language] = true
+			languages = append(languages, marker.Language)
+		}
+	}
+
+	suggested := ""
+	switch {
+	case len(languages) == 1:
+		suggested = languages[0]
+	case len(languages) > 1:
+		suggested = "microservices"
+	}
+	return languages, suggested, nil
+}
+
+// applyDetectedSettings reports what was detected and, once confirmed, fills
+// in languages and template where the user didn't set them explicitly.
+func applyDetectedSettings(detected []string, suggested string, languages []string, template string, assumeYes bool) ([]string, string, error) {
+	if len(detected) == 0 {
+		fmt.Printf("%s No language markers found; using defaults\n", ui.Yellow("ℹ️"))
+		return languages, template, nil
+	}
+
+	if len(languages) == 0 {
+		languages = detected
+	}
+	if template == "" {
+		template = suggested
+	}
+
+	fmt.Printf("%s Detected languages: %s\n",
+		ui.Cyan("🔍"), ui.Green(strings.Join(detected, ", ")))
+	fmt.Printf("%s Using template %s with languages %s\n",
+		ui.Cyan("📦"), ui.Yellow(template), ui.Green(strings.Join(languages, ", ")))
+
+	if assumeYes {
+		return languages, template, nil
+	}
+
+	prompt := promptui.Prompt{
+		Label:     "Create workspace with these settings",
+		IsConfirm: true,
+	}
+	if _, err := prompt.Run(); err != nil {
+		return nil, "", fmt.Errorf("workspace creation cancelled")
+	}
+	return languages, template, nil
+}
+
 func listWorkspaces(cmd *cobra.Command) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -316,43 +1012,51 @@ func listWorkspaces(cmd *cobra.Command) error {
 
 	// Get workspaces
 	var workspaces []WorkspaceResponse
-	if err := apiClient.GET("/api/v1/sdk/workspaces", &workspaces); err != nil {
+	cacheAge, err := cachedGETAge(apiClient, "/api/v1/sdk/workspaces", workspaceListCacheTTL, false, &workspaces)
+	if err != nil {
 		return fmt.Errorf("failed to list workspaces: %w", err)
 	}
 
 	if len(workspaces) == 0 {
-		fmt.Printf("%s No workspaces found.\n", color.YellowString("ðŸ“­"))
+		fmt.Printf("%s No workspaces found.\n", ui.Yellow("ðŸ“­"))
 		fmt.Printf("Create one with: %s\n",
-			color.CyanString("fleeks workspace create my-project --template python"))
+			ui.Cyan("fleeks workspace create my-project --template python"))
 		return nil
 	}
 
 	// Create table
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Project ID", "Template", "Status", "CPU", "Memory", "Created"})
+	table := newListTable("Project ID", "Template", "Status", "CPU", "Memory", "Created")
 	table.SetHeaderColor(
-		tablewriter.Colors{tablewriter.FgHiCyanColor},
-		tablewriter.Colors{tablewriter.FgHiYellowColor},
-		tablewriter.Colors{tablewriter.FgHiGreenColor},
-		tablewriter.Colors{tablewriter.FgHiBlueColor},
-		tablewriter.Colors{tablewriter.FgHiMagentaColor},
-		tablewriter.Colors{tablewriter.FgHiWhiteColor},
+		ui.TableColors(color.FgHiCyan),
+		ui.TableColors(color.FgHiYellow),
+		ui.TableColors(color.FgHiGreen),
+		ui.TableColors(color.FgHiBlue),
+		ui.TableColors(color.FgHiMagenta),
+		ui.TableColors(color.FgHiWhite),
 	)
+	if err := table.SelectColumns(cmd, WorkspaceResponse{}); err != nil {
+		return err
+	}
 
 	for _, workspace := range workspaces {
-		table.Append([]string{
+		table.AppendRecord([]string{
 			workspace.ProjectID,
 			workspace.Template,
 			workspace.Status,
 			workspace.ResourceUsage.CPU,
 			workspace.ResourceUsage.Memory,
 			workspace.CreatedAt.Format("2006-01-02"),
-		})
+		}, workspace)
 	}
 
-	fmt.Printf("\n%s %s\n\n",
-		color.New(color.Bold).Sprint("ðŸ—ï¸  Workspaces:"),
-		color.GreenString(fmt.Sprintf("(%d total)", len(workspaces))))
+	fmt.Printf("\n%s %s",
+		ui.New(color.Bold).Sprint("ðŸ—ï¸  Workspaces:"),
+		ui.Green(fmt.Sprintf("(%d total)", len(workspaces))))
+	// Status and usage change on their own, so say when they may be stale
+	if cacheAge > 0 {
+		fmt.Printf(" %s", ui.Muted("(cached %s ago, --no-cache to refresh)", cacheAge.Round(time.Second)))
+	}
+	fmt.Print("\n\n")
 
 	table.Render()
 	return nil
@@ -372,6 +1076,12 @@ func getWorkspaceInfo(projectID string, cmd *cobra.Command) error {
 	apiClient := client.NewAPIClient()
 	apiClient.SetAPIKey(cfg.GetAPIKey())
 
+	tree, _ := cmd.Flags().GetBool("tree")
+	full, _ := cmd.Flags().GetBool("full")
+	if tree || full {
+		return showWorkspaceOverview(cfg, apiClient, projectID)
+	}
+
 	// Get workspace info
 	var workspace WorkspaceResponse
 	endpoint := fmt.Sprintf("/api/v1/sdk/workspaces/%s", projectID)
@@ -379,71 +1089,326 @@ func getWorkspaceInfo(projectID string, cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get workspace info: %w", err)
 	}
 
+	detail := inspectLocalWorkspace(cfg, workspace)
+	if isJSONOutput() {
+		return printJSON(detail)
+	}
+
 	// Display workspace information
 	fmt.Printf("\n%s %s\n\n",
-		color.New(color.Bold).Sprint("ðŸ—ï¸  Workspace Information:"),
-		color.CyanString(projectID))
+		ui.New(color.Bold).Sprint("ðŸ—ï¸  Workspace Information:"),
+		ui.Cyan(projectID))
 
-	fmt.Printf("%-15s %s\n", "Project ID:", color.CyanString(workspace.ProjectID))
-	fmt.Printf("%-15s %s\n", "Template:", color.YellowString(workspace.Template))
+	fmt.Printf("%-15s %s\n", "Project ID:", ui.Cyan(workspace.ProjectID))
+	fmt.Printf("%-15s %s\n", "Template:", ui.Yellow(workspace.Template))
 	fmt.Printf("%-15s %s\n", "Status:", getStatusColor(workspace.Status))
 	if workspace.Description != "" {
 		fmt.Printf("%-15s %s\n", "Description:", workspace.Description)
 	}
 	if workspace.ContainerID != "" {
-		fmt.Printf("%-15s %s\n", "Container ID:", color.BlueString(workspace.ContainerID))
+		fmt.Printf("%-15s %s\n", "Container ID:", ui.Blue(workspace.ContainerID))
 	}
-	fmt.Printf("%-15s %s\n", "Created:", color.MagentaString(workspace.CreatedAt.Format("2006-01-02 15:04:05")))
-	fmt.Printf("%-15s %s\n", "Updated:", color.MagentaString(workspace.UpdatedAt.Format("2006-01-02 15:04:05")))
+	fmt.Printf("%-15s %s\n", "Created:", ui.Magenta(workspace.CreatedAt.Format("2006-01-02 15:04:05")))
+	fmt.Printf("%-15s %s\n", "Updated:", ui.Magenta(workspace.UpdatedAt.Format("2006-01-02 15:04:05")))
 
 	// Resource usage
 	if workspace.ResourceUsage.CPU != "" {
-		fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("ðŸ“Š Resource Usage:"))
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("ðŸ“Š Resource Usage:"))
 		fmt.Printf("%-15s %s\n", "CPU:", workspace.ResourceUsage.CPU)
 		fmt.Printf("%-15s %s\n", "Memory:", workspace.ResourceUsage.Memory)
 		fmt.Printf("%-15s %s\n", "Disk:", workspace.ResourceUsage.Disk)
 	}
 
-	// Check local workspace
-	localPath := cfg.GetWorkspacePath(projectID)
-	if _, err := os.Stat(localPath); err == nil {
-		fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("ðŸ“ Local Workspace:"))
-		fmt.Printf("%-15s %s\n", "Path:", color.GreenString(localPath))
+	// Local workspace
+	if detail.LocalExists {
+		fmt.Printf("\n%s\n", ui.New(color.Bold).Sprint("ðŸ“ Local Workspace:"))
+		fmt.Printf("%-15s %s\n", "Path:", ui.Green(detail.LocalPath))
+		fmt.Printf("%-15s %s\n", "Files:", ui.Blue(fmt.Sprintf("%d", detail.LocalFileCount)))
+		fmt.Printf("%-15s %s\n", "Sync Status:", getStatusColor(detail.SyncStatus))
+		for _, walkErr := range detail.LocalErrors {
+			fmt.Printf("%s %s\n", ui.Yellow("⚠️"), walkErr)
+		}
+	}
+
+	return nil
+}
 
-		// Count files
-		fileCount := 0
-		filepath.Walk(localPath, func(path string, info os.FileInfo, err error) error {
-			if err == nil && !info.IsDir() {
-				fileCount++
+// inspectLocalWorkspace merges the cloud workspace with the state of its local copy.
+// Errors hit while walking the local tree are recorded on the result instead of
+// aborting, so a single unreadable directory doesn't hide the rest of the view.
+func inspectLocalWorkspace(cfg *config.Config, workspace WorkspaceResponse) WorkspaceDetail {
+	detail := WorkspaceDetail{
+		WorkspaceResponse: workspace,
+		LocalPath:         cfg.GetWorkspacePath(workspace.ProjectID),
+		SyncStatus:        syncStatusCloudOnly,
+	}
+
+	if _, err := os.Stat(detail.LocalPath); err != nil {
+		if !os.IsNotExist(err) {
+			detail.LocalErrors = append(detail.LocalErrors, err.Error())
+		}
+		return detail
+	}
+	detail.LocalExists = true
+
+	var lastModified time.Time
+	filepath.Walk(detail.LocalPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			detail.LocalErrors = append(detail.LocalErrors, err.Error())
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
 			}
 			return nil
-		})
-		fmt.Printf("%-15s %s\n", "Files:", color.BlueString(fmt.Sprintf("%d", fileCount)))
+		}
+		if !info.IsDir() {
+			detail.LocalFileCount++
+			if info.ModTime().After(lastModified) {
+				lastModified = info.ModTime()
+			}
+		}
+		return nil
+	})
+
+	// Without a sync journal the best signal available is whether anything
+	// local was touched after the cloud workspace last changed.
+	if lastModified.After(workspace.UpdatedAt) {
+		detail.SyncStatus = syncStatusLocalChanges
+	} else {
+		detail.SyncStatus = syncStatusSynced
+	}
+
+	return detail
+}
+
+// WorkspaceUsage is aggregate resource consumption for a workspace
+type WorkspaceUsage struct {
+	ProjectID       string    `json:"project_id"`
+	Since           time.Time `json:"since"`
+	Until           time.Time `json:"until"`
+	CPUHours        float64   `json:"cpu_hours"`
+	MemoryGBHours   float64   `json:"memory_gb_hours"`
+	StorageGBMonths float64   `json:"storage_gb_months"`
+	NetworkEgressGB float64   `json:"network_egress_gb"`
+	AgentRuns       int       `json:"agent_runs"`
+	EstimatedCost   float64   `json:"estimated_cost,omitempty"`
+	Currency        string    `json:"currency,omitempty"`
+}
+
+func getWorkspaceUsage(projectID string, cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+
+	now := time.Now()
+	params := url.Values{}
+	var since, until time.Time
+	if sinceFlag != "" {
+		if since, err = parseTimeFlag(sinceFlag, now); err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		params.Set("since", since.UTC().Format(time.RFC3339))
+	}
+	if untilFlag != "" {
+		if until, err = parseTimeFlag(untilFlag, now); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+		params.Set("until", until.UTC().Format(time.RFC3339))
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return fmt.Errorf("--since must be before --until")
+	}
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	var usage WorkspaceUsage
+	endpoint := fmt.Sprintf("/api/v1/sdk/workspaces/%s/usage", projectID)
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	if err := apiClient.GET(endpoint, &usage); err != nil {
+		return fmt.Errorf("failed to get workspace usage: %w", err)
+	}
+
+	if isJSONOutput() {
+		return printJSON(usage)
+	}
+
+	fmt.Printf("\n%s %s\n\n",
+		ui.New(color.Bold).Sprint("📊 Workspace Usage:"),
+		ui.Cyan(projectID))
+
+	if !usage.Since.IsZero() && !usage.Until.IsZero() {
+		fmt.Printf("%-18s %s to %s\n", "Period:",
+			ui.Magenta(usage.Since.Local().Format("2006-01-02 15:04")),
+			ui.Magenta(usage.Until.Local().Format("2006-01-02 15:04")))
+	}
+	fmt.Printf("%-18s %s\n", "CPU:", ui.Yellow("%.2f CPU-hours", usage.CPUHours))
+	fmt.Printf("%-18s %s\n", "Memory:", ui.Yellow("%.2f GB-hours", usage.MemoryGBHours))
+	fmt.Printf("%-18s %s\n", "Storage:", ui.Yellow("%.2f GB-months", usage.StorageGBMonths))
+	fmt.Printf("%-18s %s\n", "Network egress:", ui.Yellow("%.2f GB", usage.NetworkEgressGB))
+	fmt.Printf("%-18s %s\n", "Agent runs:", ui.Blue("%d", usage.AgentRuns))
+	if usage.EstimatedCost > 0 {
+		currency := usage.Currency
+		if currency == "" {
+			currency = "USD"
+		}
+		fmt.Printf("%-18s %s\n", "Estimated cost:", ui.Green("%.2f %s", usage.EstimatedCost, currency))
 	}
 
 	return nil
 }
 
+// parseTimeFlag parses an absolute (RFC3339 or 2006-01-02) or relative time.
+// Relative values are durations before now, with "d" accepted for days.
+func parseTimeFlag(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days < 0 {
+			return time.Time{}, fmt.Errorf("%q is not a time or duration", value)
+		}
+		return now.AddDate(0, 0, -days), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("%q is not a time or duration (e.g. 2024-01-01, 24h, 7d)", value)
+	}
+	return now.Add(-d), nil
+}
+
 func syncWorkspace(projectID string, cmd *cobra.Command) error {
 	watch, _ := cmd.Flags().GetBool("watch")
-	bidirectional, _ := cmd.Flags().GetBool("bidirectional")
-	_ = bidirectional // TODO: implement bidirectional sync
+	direction, _ := cmd.Flags().GetString("direction")
+	if bidirectional, _ := cmd.Flags().GetBool("bidirectional"); bidirectional {
+		direction = syncBoth
+	}
+	switch direction = strings.ToLower(direction); direction {
+	case syncPush, syncPull, syncBoth:
+	default:
+		return fmt.Errorf("unknown --direction %q (expected push, pull or both)", direction)
+	}
+	deleteFiles, _ := cmd.Flags().GetBool("delete")
+	if deleteFiles && direction == syncBoth {
+		return fmt.Errorf("--delete can't be used with --direction both; choose push or pull to decide which side is mirrored")
+	}
 
 	fmt.Printf("%s Syncing workspace %s...\n",
-		color.CyanString("ðŸ”„"), color.YellowString(projectID))
+		ui.Cyan("ðŸ”„"), ui.Yellow(projectID))
 
 	if watch {
 		fmt.Printf("%s Watching for file changes (Press Ctrl+C to stop)...\n",
-			color.BlueString("ðŸ‘€"))
+			ui.Blue("ðŸ‘€"))
 		// TODO: Implement file watching and sync
 		// For now, just simulate
 		fmt.Printf("%s File watching not yet implemented\n",
-			color.YellowString("âš ï¸"))
+			ui.Yellow("âš ï¸"))
 		return nil
 	}
 
 	// One-time sync
-	fmt.Printf("%s One-time sync completed\n", color.GreenString("âœ…"))
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.GetAPIKey() == "" {
+		return fmt.Errorf("API key not configured. Run 'fleeks auth login' first")
+	}
+
+	exclude, _ := cmd.Flags().GetString("exclude")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+	assumeYes, _ := cmd.Flags().GetBool("yes")
+
+	// Create API client
+	apiClient := client.NewAPIClient()
+	apiClient.SetAPIKey(cfg.GetAPIKey())
+
+	syncer := &workspaceSyncer{
+		cfg:       cfg,
+		apiClient: apiClient,
+		projectID: projectID,
+		localRoot: cfg.GetWorkspacePath(projectID),
+	}
+	for _, pattern := range strings.Split(exclude, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			syncer.excludes = append(syncer.excludes, pattern)
+		}
+	}
+
+	if info, err := os.Stat(syncer.localRoot); err != nil || !info.IsDir() {
+		return fmt.Errorf("local workspace not found at %s", syncer.localRoot)
+	}
+	local, err := syncer.localFiles()
+	if err != nil {
+		return fmt.Errorf("failed to scan local workspace: %w", err)
+	}
+
+	var remote map[string]bool
+	switch {
+	case direction == syncPush && dryRun:
+		if err := syncer.preview(local); err != nil {
+			return err
+		}
+	case direction == syncPush:
+		uploader, result := syncer.push(local)
+		fmt.Printf("%s %s\n", ui.Cyan("📊"), uploader.Summary())
+		result.PrintSummary()
+		if err := result.Err(cmd); err != nil {
+			return err
+		}
+	default:
+		if remote, err = syncer.remoteFiles(); err != nil {
+			return err
+		}
+		plan, err := syncer.plan(direction, local, remote)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			plan.print()
+			break
+		}
+		uploader, result := syncer.apply(plan, local)
+		if len(plan.uploads) > 0 {
+			fmt.Printf("%s %s\n", ui.Cyan("📊"), uploader.Summary())
+		}
+		result.PrintSummary()
+		if err := result.Err(cmd); err != nil {
+			return err
+		}
+	}
+
+	if deleteFiles {
+		if direction == syncPull {
+			err = syncer.syncLocalDeletions(local, remote, dryRun, force, assumeYes)
+		} else {
+			err = syncer.syncDeletions(local, dryRun, force, assumeYes)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if !dryRun {
+		fmt.Printf("%s One-time sync completed\n", ui.Green("âœ…"))
+	}
 	return nil
 }
 
@@ -453,7 +1418,7 @@ func deleteWorkspace(projectID string, cmd *cobra.Command) error {
 
 	if !force {
 		fmt.Printf("%s Are you sure you want to delete workspace '%s'? [y/N] ",
-			color.RedString("âš ï¸"), projectID)
+			ui.Red("âš ï¸"), projectID)
 
 		var response string
 		fmt.Scanln(&response)
@@ -481,6 +1446,7 @@ func deleteWorkspace(projectID string, cmd *cobra.Command) error {
 	if err := apiClient.DELETE(endpoint, nil); err != nil {
 		return fmt.Errorf("failed to delete workspace: %w", err)
 	}
+	invalidateListCache(apiClient, "/api/v1/sdk/workspaces")
 
 	// Delete local files if requested
 	if !keepLocal {
@@ -488,28 +1454,28 @@ func deleteWorkspace(projectID string, cmd *cobra.Command) error {
 		if _, err := os.Stat(localPath); err == nil {
 			if err := os.RemoveAll(localPath); err != nil {
 				fmt.Printf("%s Failed to delete local files: %v\n",
-					color.YellowString("âš ï¸"), err)
+					ui.Yellow("âš ï¸"), err)
 			} else {
-				fmt.Printf("%s Local files deleted\n", color.GreenString("ðŸ—‘ï¸"))
+				fmt.Printf("%s Local files deleted\n", ui.Green("ðŸ—‘ï¸"))
 			}
 		}
 	}
 
 	fmt.Printf("%s Workspace '%s' deleted successfully\n",
-		color.GreenString("âœ…"), color.CyanString(projectID))
+		ui.Green("âœ…"), ui.Cyan(projectID))
 
 	return nil
 }
 
 func getStatusColor(status string) string {
 	switch status {
-	case "running", "ready":
-		return color.GreenString(status)
-	case "starting", "syncing":
-		return color.YellowString(status)
+	case "running", "ready", syncStatusSynced:
+		return ui.Green(status)
+	case "starting", "syncing", syncStatusLocalChanges, agentStatusProposalReady:
+		return ui.Yellow(status)
 	case "stopped", "failed":
-		return color.RedString(status)
+		return ui.Red(status)
 	default:
-		return color.WhiteString(status)
+		return ui.White(status)
 	}
 }
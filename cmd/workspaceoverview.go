@@ -0,0 +1,217 @@
+/*
+Copyright © 2025 Fleeks Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/client"
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+	"github.com/fleeks-inc/fleeks-cli/internal/ui"
+)
+
+// overviewFileEvents is how many recent file changes 'workspace info --tree'
+// shows
+const overviewFileEvents = 10
+
+// WorkspaceOverview is a workspace with its container, active agents and
+// recent file changes. A section that couldn't be fetched is left empty and
+// its error recorded in Errors, keyed by the section's JSON name.
+type WorkspaceOverview struct {
+	Workspace     WorkspaceDetail   `json:"workspace"`
+	Container     *ContainerInfo    `json:"container,omitempty"`
+	Agents        []AgentStatus     `json:"agents"`
+	RecentChanges []FileChangeEvent `json:"recent_changes"`
+	Errors        map[string]string `json:"errors,omitempty"`
+}
+
+// overviewSection is one branch of the 'workspace info --tree' output
+type overviewSection struct {
+	title string
+	lines []string
+	err   string
+}
+
+// showWorkspaceOverview fetches the workspace, its container, agents and
+// recent file changes at the same time and shows them together. Only the
+// workspace itself is required; the other sections report their own
+// failures so one unavailable service doesn't hide the rest.
+func showWorkspaceOverview(cfg *config.Config, apiClient *client.APIClient, projectID string) error {
+	var (
+		wg           sync.WaitGroup
+		workspace    WorkspaceResponse
+		workspaceErr error
+		container    ContainerInfo
+		containerErr error
+		agents       []AgentStatus
+		agentsErr    error
+		events       []FileChangeEvent
+		eventsErr    error
+	)
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		workspaceErr = apiClient.GET(fmt.Sprintf("/api/v1/sdk/workspaces/%s", projectID), &workspace)
+	}()
+	go func() {
+		defer wg.Done()
+		containerErr = apiClient.GET(fmt.Sprintf("/api/v1/sdk/containers/%s", projectID), &container)
+	}()
+	go func() {
+		defer wg.Done()
+		agentsErr = apiClient.GET("/api/v1/sdk/agents?project_id="+url.QueryEscape(projectID), &agents)
+	}()
+	go func() {
+		defer wg.Done()
+		eventsErr = apiClient.GET(fmt.Sprintf("/api/v1/sdk/files/%s/events?limit=%d", projectID, overviewFileEvents), &events)
+	}()
+	wg.Wait()
+
+	if workspaceErr != nil {
+		return fmt.Errorf("failed to get workspace info: %w", workspaceErr)
+	}
+
+	overview := WorkspaceOverview{
+		Workspace:     inspectLocalWorkspace(cfg, workspace),
+		Agents:        []AgentStatus{},
+		RecentChanges: []FileChangeEvent{},
+		Errors:        make(map[string]string),
+	}
+	if containerErr != nil {
+		overview.Errors["container"] = containerErr.Error()
+	} else {
+		overview.Container = &container
+	}
+	if agentsErr != nil {
+		overview.Errors["agents"] = agentsErr.Error()
+	} else {
+		for _, agent := range agents {
+			if !isTerminalAgentStatus(agent.Status) {
+				overview.Agents = append(overview.Agents, agent)
+			}
+		}
+	}
+	if eventsErr != nil {
+		overview.Errors["recent_changes"] = eventsErr.Error()
+	} else if events != nil {
+		overview.RecentChanges = events
+	}
+
+	if isJSONOutput() {
+		return printJSON(overview)
+	}
+	overview.print()
+	return nil
+}
+
+// print renders the overview as a tree with one branch per section
+func (o *WorkspaceOverview) print() {
+	detail := o.Workspace
+	fmt.Printf("\n%s %s %s\n", ui.New(color.Bold).Sprint("🏗️  Workspace:"),
+		ui.Cyan(detail.ProjectID), getStatusColor(detail.Status))
+
+	workspace := overviewSection{title: "Workspace"}
+	workspace.lines = append(workspace.lines,
+		fmt.Sprintf("Template: %s", ui.Yellow(detail.Template)),
+		fmt.Sprintf("Created:  %s", ui.Magenta(detail.CreatedAt.Format("2006-01-02 15:04:05"))))
+	if detail.LocalExists {
+		workspace.lines = append(workspace.lines, fmt.Sprintf("Local:    %s (%d file(s), %s)",
+			ui.Green(detail.LocalPath), detail.LocalFileCount, getStatusColor(detail.SyncStatus)))
+	} else {
+		workspace.lines = append(workspace.lines, fmt.Sprintf("Local:    %s", ui.Muted("not synced")))
+	}
+
+	container := overviewSection{title: "Container", err: o.Errors["container"]}
+	if c := o.Container; c != nil {
+		status := getStatusColor(c.Status)
+		if c.Health.Status != "" {
+			status += ui.Muted(" (health: %s)", c.Health.Status)
+		}
+		container.lines = append(container.lines,
+			fmt.Sprintf("Status: %s", status),
+			fmt.Sprintf("CPU:    %s / %s", ui.Yellow(orDash(c.Resources.CPU)), orDash(c.Resources.CPULimit)),
+			fmt.Sprintf("Memory: %s / %s", ui.Blue(orDash(c.Resources.Memory)), orDash(c.Resources.MemLimit)))
+		if c.Image != "" {
+			container.lines = append(container.lines, fmt.Sprintf("Image:  %s", c.Image))
+		}
+	}
+
+	agents := overviewSection{title: fmt.Sprintf("Active agents (%d)", len(o.Agents)), err: o.Errors["agents"]}
+	if agents.err != "" {
+		agents.title = "Active agents"
+	}
+	for _, agent := range o.Agents {
+		agents.lines = append(agents.lines, fmt.Sprintf("%s %s %s %s", ui.Cyan(agent.AgentID),
+			getStatusColor(agent.Status), ui.Magenta("%d%%", agent.Progress), truncateText(agent.Task, 50)))
+	}
+	if agents.err == "" && len(o.Agents) == 0 {
+		agents.lines = append(agents.lines, ui.Muted("none running"))
+	}
+
+	changes := overviewSection{title: "Recent file changes", err: o.Errors["recent_changes"]}
+	for _, event := range o.RecentChanges {
+		actor := ""
+		if event.Actor != "" {
+			actor = ui.Muted(" by %s", event.Actor)
+		}
+		changes.lines = append(changes.lines, fmt.Sprintf("%s %-8s %s%s",
+			ui.Muted(event.Timestamp.Local().Format("2006-01-02 15:04:05")),
+			event.Type, ui.Cyan(event.Path), actor))
+	}
+	if changes.err == "" && len(o.RecentChanges) == 0 {
+		changes.lines = append(changes.lines, ui.Muted("no changes recorded"))
+	}
+
+	sections := []overviewSection{workspace, container, agents, changes}
+	for i, section := range sections {
+		branch, indent := "├── ", "│   "
+		if i == len(sections)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if section.err != "" {
+			fmt.Printf("%s%s %s\n", branch, ui.New(color.Bold).Sprint(section.title),
+				ui.Yellow("⚠️  unavailable: %s", section.err))
+			continue
+		}
+		fmt.Printf("%s%s\n", branch, ui.New(color.Bold).Sprint(section.title))
+		for j, line := range section.lines {
+			leaf := "├── "
+			if j == len(section.lines)-1 {
+				leaf = "└── "
+			}
+			fmt.Printf("%s%s%s\n", indent, leaf, line)
+		}
+	}
+
+	if len(o.Errors) > 0 {
+		fmt.Printf("\n%s %d section(s) could not be loaded; the rest is shown\n", ui.Yellow("⚠️"), len(o.Errors))
+	}
+}
+
+// orDash shows a missing value as "-"
+func orDash(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
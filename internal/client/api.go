@@ -2,17 +2,30 @@ package client
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/gorilla/websocket"
 	"github.com/spf13/viper"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
 )
 
+// OrganizationHeader scopes API requests to the active organization
+const OrganizationHeader = "X-Fleeks-Organization"
+
 // APIClient represents the Fleeks API client
 type APIClient struct {
 	client   *resty.Client
@@ -22,6 +35,72 @@ type APIClient struct {
 	wsDialer *websocket.Dialer
 }
 
+// insecureSkipVerify disables TLS verification for every client created in
+// this process. It is set from the --insecure flag and never persisted.
+var insecureSkipVerify bool
+
+// extraHeaders are added to every request and WebSocket handshake. They are
+// set from the --header flag and never persisted.
+var extraHeaders = http.Header{}
+
+// SetExtraHeaders parses KEY=VALUE pairs into headers applied to all requests.
+// Authorization cannot be overridden this way.
+func SetExtraHeaders(pairs []string) error {
+	headers := http.Header{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("invalid header %q (expected KEY=VALUE)", pair)
+		}
+		if strings.ContainsAny(key, " \t:") {
+			return fmt.Errorf("invalid header name %q", key)
+		}
+		if http.CanonicalHeaderKey(key) == "Authorization" {
+			return fmt.Errorf("the Authorization header cannot be set with --header")
+		}
+		headers.Add(key, value)
+	}
+	extraHeaders = headers
+	return nil
+}
+
+// legacyUserAgent is the fixed User-Agent older releases wrote into config
+// files as the api.user_agent default; it is treated as unset
+const legacyUserAgent = "fleeks-cli/1.0.0"
+
+// userAgent identifies the CLI version, platform and running command to the
+// API. It is set from the invoked command by SetUserAgent.
+var userAgent = buildUserAgent("dev", "")
+
+// SetUserAgent sets the User-Agent sent with every request and WebSocket
+// handshake to "fleeks-cli/{version} ({os}/{arch}) cmd/{command}"
+func SetUserAgent(version, command string) {
+	userAgent = buildUserAgent(version, command)
+}
+
+func buildUserAgent(version, command string) string {
+	agent := fmt.Sprintf("fleeks-cli/%s (%s/%s)", version, runtime.GOOS, runtime.GOARCH)
+	if command != "" {
+		agent += " cmd/" + command
+	}
+	return agent
+}
+
+// userAgentHeader returns the User-Agent to send: api.user_agent when it has
+// been customized, otherwise the one built by SetUserAgent
+func userAgentHeader() string {
+	if custom := viper.GetString("api.user_agent"); custom != "" && custom != legacyUserAgent {
+		return custom
+	}
+	return userAgent
+}
+
+// SetInsecureSkipVerify toggles TLS certificate verification for new clients
+func SetInsecureSkipVerify(skip bool) {
+	insecureSkipVerify = skip
+}
+
 // NewAPIClient creates a new Fleeks API client
 func NewAPIClient() *APIClient {
 	baseURL := viper.GetString("api.base_url")
@@ -38,18 +117,44 @@ func NewAPIClient() *APIClient {
 		SetBaseURL(baseURL).
 		SetTimeout(timeout).
 		SetHeader("Content-Type", "application/json").
-		SetHeader("User-Agent", "fleeks-cli/1.0.0")
+		SetHeader("User-Agent", userAgentHeader()).
+		SetHeader(AcceptVersionHeader, SchemaVersion)
+
+	// Scope requests to the organization selected with 'fleeks auth use-org'
+	if org := viper.GetString("auth.organization"); org != "" {
+		client.SetHeader(OrganizationHeader, org)
+	}
+
+	// Custom gateway headers from --header
+	for key, values := range extraHeaders {
+		client.Header[key] = append([]string(nil), values...)
+	}
 
 	// Configure TLS
 	client.SetTLSClientConfig(&tls.Config{
-		InsecureSkipVerify: false,
+		InsecureSkipVerify: insecureSkipVerify,
 	})
 
+	// Serve canned responses instead of hitting the network
+	if config.MockAPIsEnabled() {
+		client.SetTransport(mockTransport{})
+		printMockBanner()
+	}
+
+	// Record requests in the --log-file session log
+	client.OnAfterResponse(logResponse)
+	client.OnAfterResponse(checkVersionHeaders)
+	client.OnError(logRequestError)
+
+	// --raw prints GET responses and blocks everything else
+	client.OnBeforeRequest(rejectMutationsWhenRaw)
+	client.OnAfterResponse(printRawResponse)
+
 	// WebSocket dialer
 	wsDialer := &websocket.Dialer{
 		HandshakeTimeout: 10 * time.Second,
 		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: false,
+			InsecureSkipVerify: insecureSkipVerify,
 		},
 	}
 
@@ -67,6 +172,12 @@ func (c *APIClient) SetAPIKey(apiKey string) {
 	c.client.SetHeader("Authorization", fmt.Sprintf("Bearer %s", apiKey))
 }
 
+// SetTimeout overrides api.timeout for the requests this client makes
+func (c *APIClient) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.client.SetTimeout(timeout)
+}
+
 // APIResponse represents a standard API response
 type APIResponse struct {
 	Success bool        `json:"success"`
@@ -98,6 +209,30 @@ func (e *ErrorResponse) Error() string {
 	return fmt.Sprintf("API Error: %s", e.Message)
 }
 
+// responseError converts a failed response into an error. A 403 from a
+// plan-gated endpoint becomes a PlanRequiredError naming the plan needed.
+func (c *APIClient) responseError(resp *resty.Response, endpoint string) error {
+	errResp, ok := resp.Error().(*ErrorResponse)
+	if ok {
+		errResp.Code = resp.StatusCode()
+	}
+
+	if resp.StatusCode() == http.StatusForbidden {
+		base := errResp
+		if !ok {
+			base = &ErrorResponse{Message: "forbidden", Code: http.StatusForbidden}
+		}
+		if gated := c.planGateError(resp.Request.Method, endpoint, base); gated != nil {
+			return gated
+		}
+	}
+
+	if ok {
+		return errResp
+	}
+	return fmt.Errorf("request failed with status %d", resp.StatusCode())
+}
+
 // GET makes a GET request to the API
 func (c *APIClient) GET(endpoint string, result interface{}) error {
 	resp, err := c.client.R().
@@ -110,16 +245,47 @@ func (c *APIClient) GET(endpoint string, result interface{}) error {
 	}
 
 	if !resp.IsSuccess() {
-		if errResp, ok := resp.Error().(*ErrorResponse); ok {
-			errResp.Code = resp.StatusCode()
-			return errResp
-		}
-		return fmt.Errorf("request failed with status %d", resp.StatusCode())
+		return c.responseError(resp, endpoint)
 	}
 
 	return nil
 }
 
+// GETRaw makes a GET request and returns the undecoded response body
+func (c *APIClient) GETRaw(endpoint string) ([]byte, error) {
+	resp, err := c.client.R().
+		SetError(&ErrorResponse{}).
+		Get(endpoint)
+
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, c.responseError(resp, endpoint)
+	}
+
+	return resp.Body(), nil
+}
+
+// GETWithHeaders makes a GET request and returns the undecoded response body
+// along with the response headers, for callers that honor Cache-Control
+func (c *APIClient) GETWithHeaders(endpoint string) ([]byte, http.Header, error) {
+	resp, err := c.client.R().
+		SetError(&ErrorResponse{}).
+		Get(endpoint)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, nil, c.responseError(resp, endpoint)
+	}
+
+	return resp.Body(), resp.Header(), nil
+}
+
 // POST makes a POST request to the API
 func (c *APIClient) POST(endpoint string, body interface{}, result interface{}) error {
 	resp, err := c.client.R().
@@ -133,16 +299,100 @@ func (c *APIClient) POST(endpoint string, body interface{}, result interface{})
 	}
 
 	if !resp.IsSuccess() {
-		if errResp, ok := resp.Error().(*ErrorResponse); ok {
-			errResp.Code = resp.StatusCode()
-			return errResp
-		}
-		return fmt.Errorf("request failed with status %d", resp.StatusCode())
+		return c.responseError(resp, endpoint)
+	}
+
+	return nil
+}
+
+// POSTStream makes a POST request whose body is read from body while it is
+// sent, so large uploads never have to fit in memory. Such a request can't
+// be retried.
+func (c *APIClient) POSTStream(endpoint, contentType string, body io.Reader, result interface{}) error {
+	resp, err := c.client.R().
+		SetHeader("Content-Type", contentType).
+		SetBody(body).
+		SetResult(result).
+		SetError(&ErrorResponse{}).
+		Post(endpoint)
+
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return c.responseError(resp, endpoint)
 	}
 
 	return nil
 }
 
+// IdempotencyKeyHeader is the header the API uses to deduplicate retried mutations
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// NewIdempotencyKey generates a random UUID (v4) identifying one logical operation
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand never fails on supported platforms; fall back to a time-based key
+		return fmt.Sprintf("fleeks-%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// POSTIdempotent makes a POST request carrying an Idempotency-Key header.
+// Transport failures and retryable status codes are retried up to
+// api.retry_count times with the same key so the server can dedupe.
+func (c *APIClient) POSTIdempotent(endpoint string, idempotencyKey string, body interface{}, result interface{}) error {
+	retries := viper.GetInt("api.retry_count")
+	if retries < 0 {
+		retries = 0
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+
+		resp, err := c.client.R().
+			SetHeader(IdempotencyKeyHeader, idempotencyKey).
+			SetBody(body).
+			SetResult(result).
+			SetError(&ErrorResponse{}).
+			Post(endpoint)
+
+		if err != nil {
+			lastErr = fmt.Errorf("request failed: %w", err)
+			continue
+		}
+
+		if resp.IsSuccess() {
+			return nil
+		}
+
+		lastErr = c.responseError(resp, endpoint)
+
+		if !isRetryableStatus(resp.StatusCode()) {
+			return lastErr
+		}
+	}
+
+	return lastErr
+}
+
+// isRetryableStatus reports whether a failed request may succeed if repeated
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
 // PUT makes a PUT request to the API
 func (c *APIClient) PUT(endpoint string, body interface{}, result interface{}) error {
 	resp, err := c.client.R().
@@ -156,11 +406,7 @@ func (c *APIClient) PUT(endpoint string, body interface{}, result interface{}) e
 	}
 
 	if !resp.IsSuccess() {
-		if errResp, ok := resp.Error().(*ErrorResponse); ok {
-			errResp.Code = resp.StatusCode()
-			return errResp
-		}
-		return fmt.Errorf("request failed with status %d", resp.StatusCode())
+		return c.responseError(resp, endpoint)
 	}
 
 	return nil
@@ -178,16 +424,25 @@ func (c *APIClient) DELETE(endpoint string, result interface{}) error {
 	}
 
 	if !resp.IsSuccess() {
-		if errResp, ok := resp.Error().(*ErrorResponse); ok {
-			errResp.Code = resp.StatusCode()
-			return errResp
-		}
-		return fmt.Errorf("request failed with status %d", resp.StatusCode())
+		return c.responseError(resp, endpoint)
 	}
 
 	return nil
 }
 
+// BaseURL returns the API base URL requests are sent to
+func (c *APIClient) BaseURL() string {
+	return c.baseURL
+}
+
+// Fingerprint identifies the API, organization and key the client sends
+// requests as, without revealing the key, so data cached for one account is
+// never served to another
+func (c *APIClient) Fingerprint() string {
+	sum := sha256.Sum256([]byte(c.baseURL + "\n" + c.client.Header.Get(OrganizationHeader) + "\n" + c.apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
 // WebSocketURL converts HTTP(S) URL to WebSocket URL
 func (c *APIClient) WebSocketURL(path string) string {
 	u, _ := url.Parse(c.baseURL)
@@ -198,16 +453,73 @@ func (c *APIClient) WebSocketURL(path string) string {
 	return fmt.Sprintf("%s://%s%s", scheme, u.Host, path)
 }
 
-// ConnectWebSocket establishes a WebSocket connection
+// WebSocket authentication modes, selected with websocket.auth_mode
+const (
+	WSAuthHeader      = "header"
+	WSAuthQuery       = "query"
+	WSAuthSubprotocol = "subprotocol"
+)
+
+// wsAuthSubprotocol is offered alongside the token in subprotocol auth mode
+const wsAuthSubprotocol = "bearer"
+
+// ConnectWebSocket establishes a WebSocket connection. The token is sent in
+// the Authorization header by default; websocket.auth_mode can switch to a
+// ?token= query parameter or a Sec-WebSocket-Protocol value for proxies that
+// strip custom headers on upgrade.
 func (c *APIClient) ConnectWebSocket(path string) (*websocket.Conn, error) {
+	if config.MockAPIsEnabled() {
+		return nil, fmt.Errorf("streaming is not available in mock mode")
+	}
+
 	wsURL := c.WebSocketURL(path)
+	dialer := *c.wsDialer
 
 	headers := http.Header{}
+	headers.Set("User-Agent", userAgentHeader())
+	headers.Set(AcceptVersionHeader, SchemaVersion)
 	if c.apiKey != "" {
-		headers.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+		switch mode := viper.GetString("websocket.auth_mode"); mode {
+		case "", WSAuthHeader:
+			headers.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+		case WSAuthQuery:
+			u, err := url.Parse(wsURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid websocket URL: %w", err)
+			}
+			query := u.Query()
+			query.Set("token", c.apiKey)
+			u.RawQuery = query.Encode()
+			wsURL = u.String()
+		case WSAuthSubprotocol:
+			dialer.Subprotocols = []string{wsAuthSubprotocol, c.apiKey}
+		default:
+			return nil, fmt.Errorf("unknown websocket.auth_mode %q (expected %s, %s or %s)",
+				mode, WSAuthHeader, WSAuthQuery, WSAuthSubprotocol)
+		}
+	}
+
+	if org := viper.GetString("auth.organization"); org != "" {
+		headers.Set(OrganizationHeader, org)
+	}
+	for key, values := range extraHeaders {
+		headers[key] = append([]string(nil), values...)
 	}
 
-	conn, resp, err := c.wsDialer.Dial(wsURL, headers)
+	conn, resp, err := dialer.Dial(wsURL, headers)
+	if SessionLogEnabled() {
+		fields := map[string]interface{}{"url": redactURL(wsURL)}
+		if resp != nil {
+			fields["status"] = resp.StatusCode
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		LogEvent("websocket_connect", fields)
+	}
+	if resp != nil {
+		warnIfOutdated(resp.Header)
+	}
 	if err != nil {
 		if resp != nil {
 			return nil, fmt.Errorf("websocket dial failed with status %d: %w", resp.StatusCode, err)
@@ -220,19 +532,61 @@ func (c *APIClient) ConnectWebSocket(path string) (*websocket.Conn, error) {
 
 // StreamMessage represents a streaming message from WebSocket
 type StreamMessage struct {
-	Type      string                 `json:"type"`
+	ID        string                 `json:"id,omitempty"`
+	Type      MessageType            `json:"type"`
 	Content   string                 `json:"content"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 	Timestamp time.Time              `json:"timestamp"`
 }
 
+// EventID identifies the message for resuming a stream. The server-assigned
+// ID is preferred; the timestamp is used for servers that don't send one.
+func (m StreamMessage) EventID() string {
+	if m.ID != "" {
+		return m.ID
+	}
+	if !m.Timestamp.IsZero() {
+		return m.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	return ""
+}
+
+// ResumePath adds a last_event_id query parameter to a stream path so the
+// server resumes after that event. An empty ID returns path unchanged.
+func ResumePath(path, lastEventID string) string {
+	if lastEventID == "" {
+		return path
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "last_event_id=" + url.QueryEscape(lastEventID)
+}
+
+// Stream overflow policies, selected with streaming.overflow_policy. They
+// decide what happens when the consumer falls behind and the buffer of
+// streaming.buffer_size messages is full.
+const (
+	// StreamOverflowBlock stops reading from the socket until there is room
+	StreamOverflowBlock = "block"
+	// StreamOverflowDropOldest discards the oldest buffered message
+	StreamOverflowDropOldest = "drop-oldest"
+)
+
+// defaultStreamBufferSize is used when streaming.buffer_size is unset or invalid
+const defaultStreamBufferSize = 100
+
 // StreamReader handles streaming responses
 type StreamReader struct {
-	conn    *websocket.Conn
-	ctx     context.Context
-	cancel  context.CancelFunc
-	msgChan chan StreamMessage
-	errChan chan error
+	conn       *websocket.Conn
+	ctx        context.Context
+	cancel     context.CancelFunc
+	msgChan    chan StreamMessage
+	errChan    chan error
+	dropOldest bool
+	dropped    atomic.Int64
+	writeMu    sync.Mutex
 }
 
 // NewStreamReader creates a new stream reader
@@ -244,12 +598,18 @@ func (c *APIClient) NewStreamReader(path string) (*StreamReader, error) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	bufferSize := viper.GetInt("streaming.buffer_size")
+	if bufferSize <= 0 {
+		bufferSize = defaultStreamBufferSize
+	}
+
 	reader := &StreamReader{
-		conn:    conn,
-		ctx:     ctx,
-		cancel:  cancel,
-		msgChan: make(chan StreamMessage, 100),
-		errChan: make(chan error, 1),
+		conn:       conn,
+		ctx:        ctx,
+		cancel:     cancel,
+		msgChan:    make(chan StreamMessage, bufferSize),
+		errChan:    make(chan error, 1),
+		dropOldest: viper.GetString("streaming.overflow_policy") == StreamOverflowDropOldest,
 	}
 
 	// Start reading messages
@@ -277,11 +637,44 @@ func (sr *StreamReader) readLoop() {
 				return
 			}
 
-			sr.msgChan <- msg
+			if sr.dropOldest {
+				sr.pushDroppingOldest(msg)
+				continue
+			}
+
+			select {
+			case sr.msgChan <- msg:
+			case <-sr.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// pushDroppingOldest queues msg without blocking, evicting the oldest
+// buffered messages to make room. Only readLoop sends, so room made here
+// can't be taken by another sender.
+func (sr *StreamReader) pushDroppingOldest(msg StreamMessage) {
+	for {
+		select {
+		case sr.msgChan <- msg:
+			return
+		default:
+		}
+
+		select {
+		case <-sr.msgChan:
+			sr.dropped.Add(1)
+		default:
 		}
 	}
 }
 
+// Dropped returns how many messages were discarded by the drop-oldest policy
+func (sr *StreamReader) Dropped() int64 {
+	return sr.dropped.Load()
+}
+
 // Messages returns the message channel
 func (sr *StreamReader) Messages() <-chan StreamMessage {
 	return sr.msgChan
@@ -292,12 +685,40 @@ func (sr *StreamReader) Errors() <-chan error {
 	return sr.errChan
 }
 
+// Send writes a message to the server, for streams that take input such as
+// shell sessions. It is safe to call from several goroutines.
+func (sr *StreamReader) Send(msg StreamMessage) error {
+	sr.writeMu.Lock()
+	defer sr.writeMu.Unlock()
+	return sr.conn.WriteJSON(msg)
+}
+
 // Close closes the stream reader
 func (sr *StreamReader) Close() error {
 	sr.cancel()
 	return sr.conn.Close()
 }
 
+// Probe sends a GET to an absolute URL, which need not be on the API, with
+// this client's TLS settings, timeout and headers (including --header) and
+// returns the response status. None of the API response handling applies;
+// it is meant for connectivity checks.
+func (c *APIClient) Probe(rawURL string) (int, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header = c.client.Header.Clone()
+
+	resp, err := c.client.GetClient().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
 // HealthCheck performs a health check on the API
 func (c *APIClient) HealthCheck() error {
 	var result map[string]interface{}
@@ -0,0 +1,92 @@
+package client
+
+// MessageType identifies the kind of event carried by a StreamMessage
+type MessageType string
+
+// Known stream message types. Commands switch on these rather than on raw
+// strings so the protocol contract lives in one place.
+const (
+	// Agent events
+	MessageThought      MessageType = "thought"
+	MessageToolCall     MessageType = "tool_call"
+	MessageSkillLoaded  MessageType = "skill_loaded"
+	MessageTypeDetected MessageType = "type_detected"
+	MessageOutput       MessageType = "output"
+	MessageProgress     MessageType = "progress"
+	MessageComplete     MessageType = "complete"
+	MessageError        MessageType = "error"
+	MessageFileChange   MessageType = "file_change"
+	MessagePatch        MessageType = "patch"
+	MessageProposal     MessageType = "proposal_ready"
+
+	// Preview session events
+	MessageRequest MessageType = "request"
+	MessageStatus  MessageType = "status"
+
+	// Shell session events. Input and resize are sent by the CLI; output
+	// carries the PTY's bytes and exit ends the session.
+	MessageInput  MessageType = "input"
+	MessageResize MessageType = "resize"
+	MessageExit   MessageType = "exit"
+
+	// MessageExec is sent by the CLI to start a PTY-backed command on the
+	// exec stream; the stream then carries shell session events
+	MessageExec MessageType = "exec"
+
+	// MessageSignal is sent by the CLI to deliver a signal, named in the
+	// content (e.g. "SIGINT"), to a command that has no PTY
+	MessageSignal MessageType = "signal"
+
+	// MessageUnknown is reported for types this CLI version doesn't recognize
+	MessageUnknown MessageType = "unknown"
+)
+
+// AgentMessageTypes lists the events an agent stream carries
+var AgentMessageTypes = []MessageType{
+	MessageThought,
+	MessageToolCall,
+	MessageSkillLoaded,
+	MessageTypeDetected,
+	MessageOutput,
+	MessageProgress,
+	MessageComplete,
+	MessageError,
+	MessageFileChange,
+	MessagePatch,
+	MessageProposal,
+}
+
+// knownMessageTypes is the set of message types this CLI understands
+var knownMessageTypes = map[MessageType]bool{
+	MessageThought:      true,
+	MessageToolCall:     true,
+	MessageSkillLoaded:  true,
+	MessageTypeDetected: true,
+	MessageOutput:       true,
+	MessageProgress:     true,
+	MessageComplete:     true,
+	MessageError:        true,
+	MessageFileChange:   true,
+	MessagePatch:        true,
+	MessageProposal:     true,
+	MessageRequest:      true,
+	MessageStatus:       true,
+	MessageInput:        true,
+	MessageResize:       true,
+	MessageExit:         true,
+	MessageExec:         true,
+	MessageSignal:       true,
+}
+
+// IsKnown reports whether t is a message type this CLI understands
+func (t MessageType) IsKnown() bool {
+	return knownMessageTypes[t]
+}
+
+// KnownType returns the message type, or MessageUnknown if it isn't recognized
+func (m StreamMessage) KnownType() MessageType {
+	if m.Type.IsKnown() {
+		return m.Type
+	}
+	return MessageUnknown
+}
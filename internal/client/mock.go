@@ -0,0 +1,197 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// MockLabel marks every piece of canned data so it is never mistaken for real data
+const MockLabel = "[MOCK]"
+
+// mockRoute maps a method and path pattern to a canned response
+type mockRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	respond func(match []string, body map[string]interface{}) interface{}
+}
+
+// mockRoutes covers the main read/create flows; anything else returns 501
+var mockRoutes = []mockRoute{
+	{"GET", regexp.MustCompile(`^/health$`), func([]string, map[string]interface{}) interface{} {
+		return map[string]interface{}{"status": "ok", "mock": true}
+	}},
+	{"GET", regexp.MustCompile(`^/api/v1/auth/me$`), func([]string, map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"id":           "user-mock-0001",
+			"email":        "demo@example.com",
+			"name":         MockLabel + " Demo User",
+			"organization": MockLabel + " Demo Org",
+			"plan":         "pro",
+			"verified":     true,
+			"scopes":       []string{"workspaces", "agents", "containers"},
+			"created_at":   mockTime().Format(time.RFC3339),
+			"last_login":   time.Now().UTC().Format(time.RFC3339),
+		}
+	}},
+	{"GET", regexp.MustCompile(`^/api/v1/sdk/workspaces$`), func([]string, map[string]interface{}) interface{} {
+		return []interface{}{mockWorkspace("demo-api", "python"), mockWorkspace("demo-web", "node")}
+	}},
+	{"POST", regexp.MustCompile(`^/api/v1/sdk/workspaces$`), func(_ []string, body map[string]interface{}) interface{} {
+		return mockWorkspace(stringField(body, "project_id", "demo-api"), stringField(body, "template", "python"))
+	}},
+	{"GET", regexp.MustCompile(`^/api/v1/sdk/workspaces/([^/]+)$`), func(match []string, _ map[string]interface{}) interface{} {
+		return mockWorkspace(match[1], "python")
+	}},
+	{"GET", regexp.MustCompile(`^/api/v1/sdk/templates$`), func([]string, map[string]interface{}) interface{} {
+		return []interface{}{
+			map[string]interface{}{"name": "python", "languages": []string{"python"}, "description": MockLabel + " Python 3 workspace"},
+			map[string]interface{}{"name": "node", "languages": []string{"javascript", "typescript"}, "description": MockLabel + " Node.js workspace"},
+			map[string]interface{}{"name": "go", "languages": []string{"go"}, "description": MockLabel + " Go workspace"},
+		}
+	}},
+	{"GET", regexp.MustCompile(`^/api/v1/sdk/agents$`), func([]string, map[string]interface{}) interface{} {
+		return []interface{}{mockAgent("agent-mock-0001", "demo-api", MockLabel+" Build user authentication", "running")}
+	}},
+	{"POST", regexp.MustCompile(`^/api/v1/sdk/agents$`), func(_ []string, body map[string]interface{}) interface{} {
+		return mockAgent("agent-mock-0001", stringField(body, "project_id", "demo-api"),
+			MockLabel+" "+stringField(body, "task", "Demo task"), "running")
+	}},
+	{"GET", regexp.MustCompile(`^/api/v1/sdk/agents/([^/]+)$`), func(match []string, _ map[string]interface{}) interface{} {
+		return mockAgent(match[1], "demo-api", MockLabel+" Build user authentication", "running")
+	}},
+	{"GET", regexp.MustCompile(`^/api/v1/sdk/containers/([^/]+)$`), func(match []string, _ map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"container_id": "mock-container-" + match[1],
+			"project_id":   match[1],
+			"status":       "running",
+			"template":     "python",
+			"languages":    []string{"python"},
+			"created":      mockTime().Format(time.RFC3339),
+			"started":      mockTime().Format(time.RFC3339),
+			"image":        "fleeks/mock-python:latest",
+			"platform":     MockLabel + " linux/amd64",
+			"resources": map[string]interface{}{
+				"cpu": "12%", "memory": "256Mi", "disk": "1.2Gi",
+				"cpu_limit": "2", "mem_limit": "2Gi", "disk_limit": "10Gi",
+			},
+			"network": map[string]interface{}{
+				"ip_address": "10.0.0.2",
+				"ports":      map[string]string{"8080/tcp": "8080"},
+				"network":    "mock",
+			},
+			"mounts": []interface{}{
+				map[string]interface{}{"source": "workspace", "destination": "/workspace", "type": "volume", "read_only": false},
+			},
+			"environment": map[string]string{"FLEEKS_MOCK": "true"},
+			"health": map[string]interface{}{
+				"status": "healthy", "last_check": time.Now().UTC().Format(time.RFC3339),
+				"fail_count": 0, "description": MockLabel + " canned health check",
+			},
+		}
+	}},
+}
+
+// mockTransport serves mockRoutes in place of the network
+type mockTransport struct{}
+
+// RoundTrip implements http.RoundTripper
+func (mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body map[string]interface{}
+	if req.Body != nil {
+		content, _ := io.ReadAll(req.Body)
+		req.Body.Close()
+		json.Unmarshal(content, &body)
+	}
+
+	status := http.StatusNotImplemented
+	var payload interface{} = map[string]interface{}{
+		"error":   "not available in mock mode",
+		"message": fmt.Sprintf("%s %s has no canned response", req.Method, req.URL.Path),
+	}
+
+	for _, route := range mockRoutes {
+		if route.method != req.Method {
+			continue
+		}
+		if match := route.pattern.FindStringSubmatch(req.URL.Path); match != nil {
+			status = http.StatusOK
+			payload = route.respond(match, body)
+			break
+		}
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(content)),
+		Request:    req,
+	}, nil
+}
+
+var mockBannerOnce sync.Once
+
+// printMockBanner warns once per process that responses are not real
+func printMockBanner() {
+	mockBannerOnce.Do(func() {
+		fmt.Fprintf(os.Stderr, "%s Mock mode: responses are canned sample data, no backend is contacted.\n", MockLabel)
+	})
+}
+
+func mockTime() time.Time {
+	return time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+}
+
+func mockWorkspace(projectID, template string) map[string]interface{} {
+	return map[string]interface{}{
+		"project_id":    projectID,
+		"status":        "running",
+		"template":      template,
+		"description":   MockLabel + " sample workspace",
+		"container_id":  "mock-container-" + projectID,
+		"preview_url":   "https://" + projectID + ".preview.mock.invalid",
+		"websocket_url": "wss://" + projectID + ".ws.mock.invalid",
+		"created_at":    mockTime().Format(time.RFC3339),
+		"updated_at":    mockTime().Format(time.RFC3339),
+		"resource_usage": map[string]string{
+			"cpu": "12%", "memory": "256Mi", "disk": "1.2Gi",
+		},
+	}
+}
+
+func mockAgent(agentID, projectID, task, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"agent_id":             agentID,
+		"project_id":           projectID,
+		"status":               status,
+		"task":                 task,
+		"progress":             42,
+		"current_step":         MockLabel + " Writing handlers",
+		"detected_types":       []string{"web"},
+		"active_skills":        []string{"rest-api", "jwt-auth"},
+		"iterations_completed": 4,
+		"max_iterations":       10,
+		"started_at":           mockTime().Format(time.RFC3339),
+		"message":              MockLabel + " agent started",
+	}
+}
+
+// stringField returns body[key] as a string, or fallback if absent
+func stringField(body map[string]interface{}, key, fallback string) string {
+	if value, ok := body[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+// PlanCatalog describes the subscription plans and which endpoints each one
+// unlocks. It is served by /api/v1/plans so gating stays in sync with the
+// backend instead of being hardcoded in the CLI.
+type PlanCatalog struct {
+	UpgradeURL string     `json:"upgrade_url"`
+	Plans      []Plan     `json:"plans"`
+	Gates      []PlanGate `json:"gates"`
+}
+
+// Plan is a subscription plan and its limits
+type Plan struct {
+	ID     string         `json:"id"`
+	Name   string         `json:"name"`
+	Limits map[string]int `json:"limits,omitempty"`
+}
+
+// PlanGate ties an endpoint to the plan required to use it. Path is a glob
+// where * matches a single path segment, e.g. /api/v1/sdk/containers/*/scale.
+// An empty Method matches any method.
+type PlanGate struct {
+	Feature string `json:"feature"`
+	Method  string `json:"method,omitempty"`
+	Path    string `json:"path"`
+	Plan    string `json:"plan"`
+}
+
+// PlanRequiredError replaces a bare 403 from a plan-gated endpoint
+type PlanRequiredError struct {
+	Feature    string
+	Plan       string
+	UpgradeURL string
+	Err        *ErrorResponse
+}
+
+// Error implements the error interface for PlanRequiredError
+func (e *PlanRequiredError) Error() string {
+	subject := "this"
+	if e.Feature != "" {
+		subject = e.Feature
+	}
+	msg := subject + " requires a higher plan"
+	if e.Plan != "" {
+		msg = fmt.Sprintf("%s requires the %s plan", subject, e.Plan)
+	}
+	if e.UpgradeURL != "" {
+		msg += "; upgrade at " + e.UpgradeURL
+	}
+	return msg
+}
+
+// Unwrap exposes the underlying API error so status checks keep working
+func (e *PlanRequiredError) Unwrap() error {
+	return e.Err
+}
+
+// The catalog is fetched at most once per invocation, and only after a 403
+var (
+	planCatalogOnce sync.Once
+	planCatalog     *PlanCatalog
+)
+
+// fetchPlanCatalog loads the plan catalog, returning nil if it is unavailable.
+// It bypasses the normal request helpers so a failure here can't recurse.
+func (c *APIClient) fetchPlanCatalog() *PlanCatalog {
+	planCatalogOnce.Do(func() {
+		var catalog PlanCatalog
+		resp, err := c.client.R().SetResult(&catalog).Get("/api/v1/plans")
+		if err == nil && resp.IsSuccess() {
+			planCatalog = &catalog
+		}
+	})
+	return planCatalog
+}
+
+// planGateError explains a 403 from a plan-gated endpoint, or returns nil if
+// the endpoint isn't gated (or the catalog can't be fetched).
+func (c *APIClient) planGateError(method, endpoint string, errResp *ErrorResponse) error {
+	catalog := c.fetchPlanCatalog()
+	if catalog == nil {
+		return nil
+	}
+
+	endpointPath := endpoint
+	if i := strings.IndexByte(endpointPath, '?'); i >= 0 {
+		endpointPath = endpointPath[:i]
+	}
+
+	for _, gate := range catalog.Gates {
+		if gate.Method != "" && !strings.EqualFold(gate.Method, method) {
+			continue
+		}
+		if ok, _ := path.Match(gate.Path, endpointPath); !ok {
+			continue
+		}
+		return &PlanRequiredError{
+			Feature:    gate.Feature,
+			Plan:       catalog.planName(gate.Plan),
+			UpgradeURL: catalog.UpgradeURL,
+			Err:        errResp,
+		}
+	}
+	return nil
+}
+
+// planName returns the display name for a plan ID
+func (pc *PlanCatalog) planName(id string) string {
+	for _, plan := range pc.Plans {
+		if plan.ID == id && plan.Name != "" {
+			return plan.Name
+		}
+	}
+	if id == "" {
+		return ""
+	}
+	return strings.ToUpper(id[:1]) + id[1:]
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// rawResponses is set from the --raw flag: GET responses are printed as
+// the server sent them instead of being rendered by the command
+var rawResponses bool
+
+// ErrRawResponse is returned by GET requests once the raw response body has
+// been printed, so the command stops before its normal rendering. It is not
+// a failure.
+var ErrRawResponse = errors.New("raw response printed")
+
+// SetRawResponses enables raw response output for this invocation
+func SetRawResponses(enabled bool) {
+	rawResponses = enabled
+}
+
+// rejectMutationsWhenRaw is a request hook that keeps --raw read-only. It is
+// meant for inspecting what the API returns, so a command that would change
+// something is stopped before it sends the request.
+func rejectMutationsWhenRaw(_ *resty.Client, req *resty.Request) error {
+	if rawResponses && req.Method != http.MethodGet {
+		return fmt.Errorf("--raw only shows GET responses; not sending %s %s", req.Method, req.URL)
+	}
+	return nil
+}
+
+// printRawResponse is a response hook that, with --raw, prints the body of
+// every GET response, pretty-printed when it is JSON. Successful responses
+// then end the command with ErrRawResponse; error responses carry on to the
+// usual error handling, so the exit code still reflects the failure.
+func printRawResponse(_ *resty.Client, resp *resty.Response) error {
+	if !rawResponses || resp.Request.Method != http.MethodGet {
+		return nil
+	}
+
+	body := resp.Body()
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, body, "", "  "); err != nil {
+		// Not JSON; show it untouched rather than hiding it
+		os.Stdout.Write(body)
+	} else {
+		pretty.WriteByte('\n')
+		pretty.WriteTo(os.Stdout)
+	}
+
+	if resp.IsSuccess() {
+		return ErrRawResponse
+	}
+	return nil
+}
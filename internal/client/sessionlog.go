@@ -0,0 +1,221 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+)
+
+// sessionLogBodyLimit caps how much of each request and response body is
+// written to the session log
+const sessionLogBodyLimit = 4096
+
+// redactedValue replaces secrets in the session log
+const redactedValue = "[REDACTED]"
+
+// sessionLog receives a JSON line per event when --log-file is set. Any
+// writer can be plugged in with SetSessionLogOutput.
+var sessionLog struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// SetSessionLogOutput sends session log entries to w; nil turns logging off
+func SetSessionLogOutput(w io.Writer) {
+	sessionLog.mu.Lock()
+	defer sessionLog.mu.Unlock()
+	sessionLog.out = w
+}
+
+// OpenSessionLog appends the session log to the file at path
+func OpenSessionLog(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	SetSessionLogOutput(file)
+	return nil
+}
+
+// CloseSessionLog stops logging and closes the log file, if one is open
+func CloseSessionLog() error {
+	sessionLog.mu.Lock()
+	defer sessionLog.mu.Unlock()
+	closer, ok := sessionLog.out.(io.Closer)
+	sessionLog.out = nil
+	if ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// SessionLogEnabled reports whether events are being logged
+func SessionLogEnabled() bool {
+	sessionLog.mu.Lock()
+	defer sessionLog.mu.Unlock()
+	return sessionLog.out != nil
+}
+
+// LogEvent writes one timestamped event to the session log. It does nothing
+// when logging is off. Fields must already be free of secrets.
+func LogEvent(event string, fields map[string]interface{}) {
+	sessionLog.mu.Lock()
+	defer sessionLog.mu.Unlock()
+	if sessionLog.out == nil {
+		return
+	}
+
+	entry := make(map[string]interface{}, len(fields)+2)
+	for key, value := range fields {
+		entry[key] = value
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["event"] = event
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	sessionLog.out.Write(append(line, '\n'))
+}
+
+// logResponse records a completed API request and its response
+func logResponse(_ *resty.Client, resp *resty.Response) error {
+	if !SessionLogEnabled() {
+		return nil
+	}
+	LogEvent("api_response", map[string]interface{}{
+		"method":        resp.Request.Method,
+		"url":           redactURL(resp.Request.URL),
+		"status":        resp.StatusCode(),
+		"duration_ms":   resp.Time().Milliseconds(),
+		"request":       redactBody(resp.Request.Body),
+		"response":      redactBody(resp.Body()),
+		"headers":       redactHeaders(resp.Request.Header),
+		"response_size": len(resp.Body()),
+	})
+	return nil
+}
+
+// logRequestError records an API request that failed without a usable
+// response. A --raw stop is not a failure and is left out.
+func logRequestError(req *resty.Request, err error) {
+	if !SessionLogEnabled() || errors.Is(err, ErrRawResponse) {
+		return
+	}
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    redactURL(req.URL),
+		"error":  err.Error(),
+	}
+	if resp, ok := err.(*resty.ResponseError); ok && resp.Response != nil {
+		fields["status"] = resp.Response.StatusCode()
+	}
+	LogEvent("api_error", fields)
+}
+
+// redactURL hides credentials passed in the query string
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for key := range query {
+		if config.IsSecretKey(key) {
+			query.Set(key, redactedValue)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// IsSecretHeader reports whether a request header carries a credential
+func IsSecretHeader(key string) bool {
+	switch http.CanonicalHeaderKey(key) {
+	case "Authorization", "Cookie":
+		return true
+	}
+	return config.IsSecretKey(key)
+}
+
+// redactHeaders copies headers with credentials hidden
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key, values := range headers {
+		switch {
+		case IsSecretHeader(key):
+			redacted[key] = redactedValue
+		case len(values) > 0:
+			redacted[key] = values[0]
+		}
+	}
+	return redacted
+}
+
+// redactBody converts a request or response body to loggable JSON with
+// secret fields hidden. Bodies that are not JSON are summarized by size,
+// since they are usually file content.
+func redactBody(body interface{}) interface{} {
+	var raw []byte
+	switch b := body.(type) {
+	case nil:
+		return nil
+	case []byte:
+		raw = b
+	case string:
+		raw = []byte(b)
+	case io.Reader:
+		return "[stream]"
+	default:
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Sprintf("[%T]", body)
+		}
+		raw = encoded
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Sprintf("[%d bytes]", len(raw))
+	}
+	decoded = redactValue(decoded)
+
+	encoded, _ := json.Marshal(decoded)
+	if len(encoded) > sessionLogBodyLimit {
+		return string(encoded[:sessionLogBodyLimit]) + "...[truncated]"
+	}
+	return decoded
+}
+
+// redactValue hides secret fields anywhere in a decoded JSON value
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if config.IsSecretKey(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// SchemaVersion is the API schema this CLI was built against. It is sent in
+// the Accept-Version header so the server can keep answering in a shape the
+// CLI understands, or tell it that the schema is going away.
+const SchemaVersion = "1"
+
+// Versioning headers
+const (
+	AcceptVersionHeader = "Accept-Version"
+	// DeprecationHeader marks a deprecated schema or endpoint: "true" or an
+	// "@<unix time>" date (RFC 9745)
+	DeprecationHeader = "Deprecation"
+	// SunsetHeader is the HTTP date after which it stops working (RFC 8594)
+	SunsetHeader = "Sunset"
+	// VersionMismatchHeader is set when the server can't serve the requested
+	// schema version; its value names the versions it supports
+	VersionMismatchHeader = "X-Fleeks-Version-Mismatch"
+)
+
+// upgradeWarningOnce limits the upgrade advice to one line per run
+var upgradeWarningOnce sync.Once
+
+// checkVersionHeaders is a response hook that warns when the server reports
+// that this CLI's schema version is deprecated or unsupported
+func checkVersionHeaders(_ *resty.Client, resp *resty.Response) error {
+	warnIfOutdated(resp.Header())
+	return nil
+}
+
+// warnIfOutdated prints a one-line upgrade warning to stderr if the response
+// headers mark the requested schema version as mismatched, deprecated or
+// sunset
+func warnIfOutdated(header http.Header) {
+	if header == nil {
+		return
+	}
+	msg := outdatedMessage(header)
+	if msg == "" {
+		return
+	}
+	upgradeWarningOnce.Do(func() {
+		fmt.Fprintf(os.Stderr, "⚠️  %s; upgrade fleeks-cli to the latest release.\n", msg)
+	})
+}
+
+// outdatedMessage describes what the versioning headers report, or returns
+// "" when they report nothing
+func outdatedMessage(header http.Header) string {
+	if supported := strings.TrimSpace(header.Get(VersionMismatchHeader)); supported != "" {
+		return fmt.Sprintf("The API no longer supports schema version %s used by this CLI (supported: %s)",
+			SchemaVersion, supported)
+	}
+
+	deprecation := strings.TrimSpace(header.Get(DeprecationHeader))
+	sunset := strings.TrimSpace(header.Get(SunsetHeader))
+	if deprecation == "" && sunset == "" {
+		return ""
+	}
+	if strings.EqualFold(deprecation, "false") && sunset == "" {
+		return ""
+	}
+
+	msg := fmt.Sprintf("API schema version %s used by this CLI is deprecated", SchemaVersion)
+	if when, ok := parseDeprecationDate(deprecation); ok && when.After(time.Now()) {
+		msg = fmt.Sprintf("API schema version %s used by this CLI will be deprecated on %s",
+			SchemaVersion, when.Format("2006-01-02"))
+	}
+	if sunset != "" {
+		if when, err := http.ParseTime(sunset); err == nil {
+			msg += fmt.Sprintf(" and stops working on %s", when.UTC().Format("2006-01-02"))
+		}
+	}
+	return msg
+}
+
+// parseDeprecationDate reads an RFC 9745 "@<unix seconds>" Deprecation value
+func parseDeprecationDate(value string) (time.Time, bool) {
+	seconds, ok := strings.CutPrefix(value, "@")
+	if !ok {
+		return time.Time{}, false
+	}
+	unix, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(unix, 0).UTC(), true
+}
@@ -29,11 +29,12 @@ type APIConfig struct {
 
 // WorkspaceConfig contains workspace-related configuration
 type WorkspaceConfig struct {
-	DefaultTemplate string   `yaml:"default_template"`
-	SyncEnabled     bool     `yaml:"sync_enabled"`
-	SyncInterval    string   `yaml:"sync_interval"`
-	LocalPath       string   `yaml:"local_path"`
-	IgnorePatterns  []string `yaml:"ignore_patterns"`
+	DefaultTemplate string `yaml:"default_template"`
+	SyncEnabled     bool   `yaml:"sync_enabled"`
+	SyncInterval    string `yaml:"sync_interval"`
+	LocalPath       string `yaml:"local_path"`
+	// viper decodes with mapstructure, which doesn't read yaml tags
+	IgnorePatterns []string `yaml:"ignore_patterns" mapstructure:"ignore_patterns"`
 }
 
 // AgentConfig contains agent-related configuration
@@ -41,6 +42,7 @@ type AgentConfig struct {
 	MaxIterations    int  `yaml:"max_iterations"`
 	StreamingEnabled bool `yaml:"streaming_enabled"`
 	PreserveContext  bool `yaml:"preserve_context"`
+	MaxContextBytes  int  `yaml:"max_context_bytes"`
 }
 
 // StreamingConfig contains streaming-related configuration
@@ -48,6 +50,7 @@ type StreamingConfig struct {
 	Enabled        bool   `yaml:"enabled"`
 	BufferSize     int    `yaml:"buffer_size"`
 	ReconnectDelay string `yaml:"reconnect_delay"`
+	OverflowPolicy string `yaml:"overflow_policy"`
 }
 
 // AuthConfig contains authentication configuration
@@ -57,6 +60,7 @@ type AuthConfig struct {
 	RefreshToken   string `yaml:"refresh_token,omitempty"`
 	TokenExpiry    string `yaml:"token_expiry,omitempty"`
 	DefaultProject string `yaml:"default_project,omitempty"`
+	Organization   string `yaml:"organization,omitempty"`
 }
 
 // Load loads the configuration from file
@@ -81,6 +85,9 @@ func Load() (*Config, error) {
 	// Migrate deprecated fields
 	migrateDeprecatedFields()
 
+	// Expand ${VAR} references before anything reads the values
+	interpolateValues()
+
 	// Unmarshal into struct
 	if err := viper.Unmarshal(config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
@@ -92,13 +99,13 @@ func Load() (*Config, error) {
 // Save saves the configuration to file
 func (c *Config) Save() error {
 	// Marshal config to viper
-	viper.Set("api", c.API)
-	viper.Set("workspace", c.Workspace)
-	viper.Set("agent", c.Agent)
-	viper.Set("streaming", c.Streaming)
-	viper.Set("auth", c.Auth)
+	viper.Set("api", sectionMap(c.API))
+	viper.Set("workspace", sectionMap(c.Workspace))
+	viper.Set("agent", sectionMap(c.Agent))
+	viper.Set("streaming", sectionMap(c.Streaming))
+	viper.Set("auth", sectionMap(c.Auth))
 
-	return viper.WriteConfig()
+	return writeConfig()
 }
 
 // SetAPIKey securely stores the API key
@@ -116,11 +123,38 @@ func (c *Config) SetAPIKey(apiKey string) error {
 	viper.Set("auth.api_key", apiKey)
 	viper.Set("auth.api_key_hash", string(hash))
 
-	return viper.WriteConfig()
+	return writeConfig()
+}
+
+// SetRefreshToken stores the refresh token and access token expiry issued
+// by an SSO login
+func (c *Config) SetRefreshToken(refreshToken, expiry string) error {
+	c.Auth.RefreshToken = refreshToken
+	c.Auth.TokenExpiry = expiry
+
+	viper.Set("auth.refresh_token", refreshToken)
+	viper.Set("auth.token_expiry", expiry)
+
+	return writeConfig()
+}
+
+// SetOrganization stores the active organization applied to API requests
+func (c *Config) SetOrganization(orgID string) error {
+	c.Auth.Organization = orgID
+
+	viper.Set("auth.organization", orgID)
+
+	return writeConfig()
 }
 
+// mockAPIKey stands in for a real key in mock mode so commands run logged out
+const mockAPIKey = "fleeks_mock_key"
+
 // GetAPIKey returns the stored API key
 func (c *Config) GetAPIKey() string {
+	if c.Auth.APIKey == "" && MockAPIsEnabled() {
+		return mockAPIKey
+	}
 	return c.Auth.APIKey
 }
 
@@ -143,13 +177,21 @@ func GetConfigPath() string {
 	return filepath.Join(home, ".fleeksconfig.yaml")
 }
 
+// GetStateDir returns the directory for local CLI state and caches (~/.fleeks)
+func GetStateDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".fleeks"
+	}
+	return filepath.Join(home, ".fleeks")
+}
+
 // setDefaults sets default configuration values
 func setDefaults() {
 	// API defaults
 	viper.SetDefault("api.base_url", "https://api.fleeks.dev")
 	viper.SetDefault("api.timeout", "30s")
 	viper.SetDefault("api.retry_count", 3)
-	viper.SetDefault("api.user_agent", "fleeks-cli/1.0.0")
 	viper.SetDefault("api.tls_verify", true)
 
 	// Workspace defaults
@@ -166,11 +208,19 @@ func setDefaults() {
 	viper.SetDefault("agent.max_iterations", 10)
 	viper.SetDefault("agent.streaming_enabled", true)
 	viper.SetDefault("agent.preserve_context", true)
+	viper.SetDefault("agent.max_context_bytes", 64*1024*1024)
 
 	// Streaming defaults
 	viper.SetDefault("streaming.enabled", true)
 	viper.SetDefault("streaming.buffer_size", 1024)
 	viper.SetDefault("streaming.reconnect_delay", "5s")
+	viper.SetDefault("streaming.overflow_policy", "block")
+
+	// WebSocket defaults
+	viper.SetDefault("websocket.auth_mode", "header")
+
+	// UI defaults
+	viper.SetDefault("ui.theme", "auto")
 }
 
 // createDefaultConfig creates a default configuration file
@@ -237,6 +287,6 @@ func migrateDeprecatedFields() {
 
 	// Save updated config silently
 	if modified {
-		viper.WriteConfig()
+		writeConfig()
 	}
 }
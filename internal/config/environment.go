@@ -18,6 +18,30 @@ const (
 	Production  Environment = "production"
 )
 
+// environmentOverride is set from the --environment flag. It is kept outside
+// viper so that it is never written back to the config file.
+var environmentOverride Environment
+
+// SetEnvironmentOverride selects the environment for this invocation only
+func SetEnvironmentOverride(env string) {
+	environmentOverride = Environment(env)
+}
+
+// mockOverride is set from the --mock flag and, like environmentOverride,
+// never persisted.
+var mockOverride bool
+
+// SetMockOverride enables mock API mode for this invocation only
+func SetMockOverride(enabled bool) {
+	mockOverride = enabled
+}
+
+// MockAPIsEnabled reports whether API calls should be served by canned mock
+// responses, either from --mock or the dev.mock_apis setting.
+func MockAPIsEnabled() bool {
+	return mockOverride || viper.GetBool("dev.mock_apis")
+}
+
 // EnvironmentConfig manages environment-specific configurations
 type EnvironmentConfig struct {
 	Current Environment
@@ -47,9 +71,19 @@ func LoadEnvironment() (*EnvironmentConfig, error) {
 	return envConfig, nil
 }
 
+// CurrentEnvironment returns the environment this invocation resolves to
+func CurrentEnvironment() Environment {
+	return getEnvironment()
+}
+
 // getEnvironment determines the current environment
 func getEnvironment() Environment {
-	// Check CLI environment flag (set by main.go)
+	// Check CLI environment flag
+	if environmentOverride != "" {
+		return environmentOverride
+	}
+
+	// Check configured environment
 	if env := viper.GetString("environment"); env != "" {
 		return Environment(env)
 	}
@@ -147,6 +181,7 @@ func (e *EnvironmentConfig) parseEnvFile(file *os.File) error {
 		viperKey := strings.ToLower(strings.ReplaceAll(key, "FLEEKS_", ""))
 		viperKey = strings.ReplaceAll(viperKey, "_", ".")
 		viper.Set(viperKey, value)
+		envFileKeys[viperKey] = true
 	}
 
 	return nil
@@ -169,7 +204,7 @@ func (e *EnvironmentConfig) setEnvironmentDefaults() error {
 // setDevelopmentDefaults sets development environment defaults
 func (e *EnvironmentConfig) setDevelopmentDefaults() error {
 	// API defaults for development
-	viper.SetDefault("api.base_url", "http://localhost:8000")
+	viper.SetDefault("api.base_url", Development.DefaultAPIBaseURL())
 	viper.SetDefault("api.timeout", "30s")
 	viper.SetDefault("api.debug", true)
 	viper.SetDefault("api.tls_verify", false)
@@ -194,7 +229,7 @@ func (e *EnvironmentConfig) setDevelopmentDefaults() error {
 // setStagingDefaults sets staging environment defaults
 func (e *EnvironmentConfig) setStagingDefaults() error {
 	// API defaults for staging
-	viper.SetDefault("api.base_url", "https://staging-api.fleeks.dev")
+	viper.SetDefault("api.base_url", Staging.DefaultAPIBaseURL())
 	viper.SetDefault("api.timeout", "45s")
 	viper.SetDefault("api.debug", false)
 	viper.SetDefault("api.tls_verify", true)
@@ -218,7 +253,7 @@ func (e *EnvironmentConfig) setStagingDefaults() error {
 // setProductionDefaults sets production environment defaults
 func (e *EnvironmentConfig) setProductionDefaults() error {
 	// API defaults for production
-	viper.SetDefault("api.base_url", "https://api.fleeks.dev")
+	viper.SetDefault("api.base_url", Production.DefaultAPIBaseURL())
 	viper.SetDefault("api.timeout", "60s")
 	viper.SetDefault("api.debug", false)
 	viper.SetDefault("api.tls_verify", true)
@@ -254,6 +289,26 @@ func (e *EnvironmentConfig) GetEnvironmentInfo() map[string]interface{} {
 	}
 }
 
+// DefaultAPIBaseURL returns the API endpoint the environment uses unless
+// api.base_url is set
+func (e Environment) DefaultAPIBaseURL() string {
+	switch e {
+	case Development:
+		return "http://localhost:8000"
+	case Staging:
+		return "https://staging-api.fleeks.dev"
+	case Production:
+		return "https://api.fleeks.dev"
+	}
+	return ""
+}
+
+// SaveEnvironment makes env the default environment in the config file
+func SaveEnvironment(env Environment) error {
+	viper.Set("environment", string(env))
+	return writeConfig()
+}
+
 // String returns the string representation of the environment
 func (e Environment) String() string {
 	return string(e)
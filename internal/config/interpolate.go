@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// InterpolatedKeys are the config keys whose values may reference
+// environment variables as ${VAR} or ${VAR:-default}. References are expanded
+// when the config is loaded; the config file keeps the references.
+var InterpolatedKeys = []string{
+	"api.base_url",
+	"api.user_agent",
+	"websocket.base_url",
+	"services.lsp_url",
+	"services.mcp_url",
+	"workspace.default_template",
+	"workspace.local_path",
+	"auth.organization",
+}
+
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// rawValues holds the unexpanded value of each key that contained a
+// reference, so writing the config puts the references back
+var rawValues = map[string]string{}
+
+// ExpandEnvReferences replaces ${VAR} with the value of VAR and ${VAR:-default}
+// with the value of VAR, or default if VAR is unset or empty. Other uses of $
+// are left alone.
+func ExpandEnvReferences(value string) string {
+	return envReference.ReplaceAllStringFunc(value, func(ref string) string {
+		match := envReference.FindStringSubmatch(ref)
+		if v := os.Getenv(match[1]); v != "" || match[2] == "" {
+			return v
+		}
+		return match[3]
+	})
+}
+
+// interpolateValues expands environment references in InterpolatedKeys
+func interpolateValues() {
+	for _, key := range InterpolatedKeys {
+		raw, ok := rawValues[key]
+		if !ok {
+			raw = viper.GetString(key)
+			if !strings.Contains(raw, "${") {
+				continue
+			}
+			rawValues[key] = raw
+		}
+		viper.Set(key, ExpandEnvReferences(raw))
+	}
+}
+
+// writeConfig writes the config file with environment references intact
+func writeConfig() error {
+	for key, raw := range rawValues {
+		if viper.GetString(key) != ExpandEnvReferences(raw) {
+			// The value was changed since loading; write the new one
+			delete(rawValues, key)
+			continue
+		}
+		viper.Set(key, raw)
+	}
+	defer interpolateValues()
+	return viper.WriteConfig()
+}
+
+// sectionMap converts a config section to a map so that individual keys in it
+// can still be overridden after it is set in viper
+func sectionMap(section interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+	content, err := yaml.Marshal(section)
+	if err == nil {
+		yaml.Unmarshal(content, &values)
+	}
+	return values
+}
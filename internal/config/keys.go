@@ -0,0 +1,204 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// KeyType is the kind of value a configuration key holds
+type KeyType string
+
+// Configuration key types
+const (
+	TypeString   KeyType = "string"
+	TypeBool     KeyType = "bool"
+	TypeInt      KeyType = "int"
+	TypeDuration KeyType = "duration"
+	TypeURL      KeyType = "url"
+	TypeEnum     KeyType = "enum"
+	TypeList     KeyType = "list"
+)
+
+// KeyInfo describes a configuration key the CLI reads
+type KeyInfo struct {
+	Key         string   `json:"key"`
+	Type        KeyType  `json:"type"`
+	Description string   `json:"description"`
+	Values      []string `json:"values,omitempty"`  // allowed values of an enum
+	Schemes     []string `json:"schemes,omitempty"` // allowed schemes of a URL
+	Min         *int     `json:"min,omitempty"`     // lowest allowed int
+}
+
+func atLeast(n int) *int {
+	return &n
+}
+
+// knownKeys lists every configuration key with its type, in the order the
+// sections appear in the config file
+var knownKeys = []KeyInfo{
+	{Key: "environment", Type: TypeEnum, Values: []string{"development", "staging", "production"}, Description: "Environment to use when --environment isn't given"},
+
+	{Key: "api.base_url", Type: TypeURL, Schemes: []string{"http", "https"}, Description: "Fleeks API endpoint"},
+	{Key: "api.timeout", Type: TypeDuration, Description: "Timeout for API requests"},
+	{Key: "api.retry_count", Type: TypeInt, Min: atLeast(0), Description: "Retries for failed API requests"},
+	{Key: "api.user_agent", Type: TypeString, Description: "Custom User-Agent sent to the API instead of the CLI version and platform"},
+	{Key: "api.tls_verify", Type: TypeBool, Description: "Verify TLS certificates"},
+	{Key: "api.debug", Type: TypeBool, Description: "Log API requests and responses"},
+
+	{Key: "auth.organization", Type: TypeString, Description: "Organization ID used for requests"},
+	{Key: "auth.default_project", Type: TypeString, Description: "Project used when a command isn't given one"},
+
+	{Key: "workspace.default_template", Type: TypeString, Description: "Template for new workspaces"},
+	{Key: "workspace.sync_enabled", Type: TypeBool, Description: "Sync workspace files automatically"},
+	{Key: "workspace.sync_interval", Type: TypeDuration, Description: "Interval between workspace syncs"},
+	{Key: "workspace.local_path", Type: TypeString, Description: "Local directory for synced workspaces"},
+	{Key: "workspace.ignore_patterns", Type: TypeList, Description: "File patterns left out of syncs"},
+
+	{Key: "agent.max_iterations", Type: TypeInt, Min: atLeast(1), Description: "Maximum iterations per agent task"},
+	{Key: "agent.streaming_enabled", Type: TypeBool, Description: "Stream agent output by default"},
+	{Key: "agent.preserve_context", Type: TypeBool, Description: "Keep agent context between tasks"},
+	{Key: "agent.max_context_bytes", Type: TypeInt, Min: atLeast(0), Description: "Largest context sent to an agent"},
+
+	{Key: "streaming.enabled", Type: TypeBool, Description: "Use streaming connections"},
+	{Key: "streaming.buffer_size", Type: TypeInt, Min: atLeast(1), Description: "Messages buffered per stream"},
+	{Key: "streaming.reconnect_delay", Type: TypeDuration, Description: "Delay before reconnecting a dropped stream"},
+	{Key: "streaming.overflow_policy", Type: TypeEnum, Values: []string{"block", "drop-oldest"}, Description: "What a full stream buffer does"},
+
+	{Key: "websocket.base_url", Type: TypeURL, Schemes: []string{"ws", "wss"}, Description: "WebSocket endpoint"},
+	{Key: "websocket.timeout", Type: TypeDuration, Description: "Timeout for WebSocket handshakes"},
+	{Key: "websocket.auth_mode", Type: TypeEnum, Values: []string{"header", "query", "subprotocol"}, Description: "How WebSocket connections send the API key"},
+
+	{Key: "services.lsp_url", Type: TypeURL, Schemes: []string{"http", "https"}, Description: "Language server endpoint"},
+	{Key: "services.mcp_url", Type: TypeURL, Schemes: []string{"http", "https"}, Description: "MCP server endpoint"},
+
+	{Key: "ui.theme", Type: TypeEnum, Values: []string{"auto", "dark", "light", "none"}, Description: "Color theme: auto follows the terminal background"},
+
+	{Key: "dev.mode", Type: TypeBool, Description: "Enable development mode"},
+	{Key: "dev.verbose", Type: TypeBool, Description: "Verbose output in development"},
+	{Key: "dev.mock_apis", Type: TypeBool, Description: "Answer API calls with mock data"},
+	{Key: "dev.log_level", Type: TypeEnum, Values: []string{"debug", "info", "warn", "error"}, Description: "Log level in development"},
+}
+
+// KnownKeys returns every configuration key the CLI reads
+func KnownKeys() []KeyInfo {
+	keys := make([]KeyInfo, len(knownKeys))
+	copy(keys, knownKeys)
+	return keys
+}
+
+// LookupKey returns the description of a known configuration key
+func LookupKey(key string) (KeyInfo, bool) {
+	key = strings.ToLower(key)
+	for _, info := range knownKeys {
+		if info.Key == key {
+			return info, true
+		}
+	}
+	return KeyInfo{}, false
+}
+
+// Coerce parses raw as the key's type, returning the value to store or an
+// error explaining what was expected
+func (k KeyInfo) Coerce(raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	switch k.Type {
+	case TypeBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q (expected true or false)", raw)
+		}
+		return value, nil
+
+	case TypeInt:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", raw)
+		}
+		if err := k.checkMin(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+
+	case TypeList:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	}
+
+	if err := k.check(raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// check validates a string value of a duration, URL or enum key
+func (k KeyInfo) check(value string) error {
+	switch k.Type {
+	case TypeDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("invalid duration %q (expected e.g. 30s, 1m)", value)
+		}
+	case TypeURL:
+		return validateURL(value, k.Schemes)
+	case TypeEnum:
+		for _, allowed := range k.Values {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown value %q (expected %s)", value, joinChoices(k.Values))
+	}
+	return nil
+}
+
+// checkMin validates the lower bound of an int key
+func (k KeyInfo) checkMin(value int) error {
+	switch {
+	case k.Min == nil || value >= *k.Min:
+		return nil
+	case *k.Min == 0:
+		return fmt.Errorf("must not be negative")
+	case *k.Min == 1:
+		return fmt.Errorf("must be greater than zero")
+	default:
+		return fmt.Errorf("must be at least %d", *k.Min)
+	}
+}
+
+// joinChoices lists values as "a, b or c"
+func joinChoices(values []string) string {
+	if len(values) <= 1 {
+		return strings.Join(values, "")
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
+
+// SetValue coerces raw to the type of a known key and writes it to the
+// config file, returning the stored value. Unknown keys are stored as
+// strings.
+func SetValue(key, raw string) (interface{}, error) {
+	key = strings.ToLower(key)
+	var value interface{} = raw
+	if info, ok := LookupKey(key); ok {
+		coerced, err := info.Coerce(raw)
+		if err != nil {
+			return nil, ValidationError{Key: key, Message: err.Error()}
+		}
+		value = coerced
+	}
+
+	delete(rawValues, key)
+	viper.Set(key, value)
+	if err := writeConfig(); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+	return value, nil
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// SettingChange is one value changed by ImportSettings
+type SettingChange struct {
+	Key string      `json:"key"`
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// ShareableSettings returns the contents of the config file with every secret
+// removed, for sharing with a team. Values are returned as written, so
+// ${VAR} references stay unexpanded.
+func ShareableSettings() (map[string]interface{}, error) {
+	configPath := viper.ConfigFileUsed()
+	if configPath == "" {
+		configPath = GetConfigPath()
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	settings := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	removeSecrets("", settings)
+	return settings, nil
+}
+
+// removeSecrets deletes secret keys from a nested settings map, along with
+// any sections left empty
+func removeSecrets(prefix string, settings map[string]interface{}) {
+	for key, value := range settings {
+		fullKey := joinKey(prefix, key)
+		if IsSecretKey(fullKey) {
+			delete(settings, key)
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			removeSecrets(fullKey, nested)
+			if len(nested) == 0 {
+				delete(settings, key)
+			}
+		}
+	}
+}
+
+// ReadSettingsFile loads a shared settings file as flattened keys. Secrets
+// are never imported; their keys are returned separately so they can be
+// reported.
+func ReadSettingsFile(path string) (settings map[string]interface{}, skipped []string, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	nested := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &nested); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	settings = map[string]interface{}{}
+	flattenSettings("", nested, settings)
+	for key := range settings {
+		if IsSecretKey(key) {
+			delete(settings, key)
+			skipped = append(skipped, key)
+		}
+	}
+	sort.Strings(skipped)
+	return settings, skipped, nil
+}
+
+// ImportSettings merges settings into the config file, overriding the local
+// values for the same keys and leaving every other key untouched. With dryRun
+// the changes are computed but not written.
+func ImportSettings(settings map[string]interface{}, dryRun bool) ([]SettingChange, error) {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var changes []SettingChange
+	for _, key := range keys {
+		old := viper.Get(key)
+		if raw, ok := rawValues[key]; ok {
+			old = raw
+		}
+		if fmt.Sprint(old) == fmt.Sprint(settings[key]) {
+			continue
+		}
+		changes = append(changes, SettingChange{Key: key, Old: old, New: settings[key]})
+	}
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	for _, change := range changes {
+		delete(rawValues, change.Key)
+		viper.Set(change.Key, change.New)
+	}
+	if err := writeConfig(); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+	return changes, nil
+}
+
+// flattenSettings turns nested sections into dotted keys
+func flattenSettings(prefix string, nested map[string]interface{}, out map[string]interface{}) {
+	for key, value := range nested {
+		fullKey := joinKey(prefix, key)
+		if section, ok := value.(map[string]interface{}); ok && len(section) > 0 {
+			flattenSettings(fullKey, section, out)
+			continue
+		}
+		out[fullKey] = value
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Where an effective setting came from, lowest precedence first
+const (
+	SourceDefault = "default"
+	SourceConfig  = "config"
+	SourceEnvVar  = "env-var"
+	SourceEnvFile = "env-file"
+)
+
+// envFileKeys records the viper keys set by the loaded .env.{environment}
+// file, since viper itself can't tell those apart from other overrides.
+var envFileKeys = make(map[string]bool)
+
+// Setting is one effective configuration value and its source
+type Setting struct {
+	Key    string      `json:"key"`
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+	Secret bool        `json:"secret,omitempty"`
+}
+
+// EnvVarName returns the environment variable viper reads for key
+func EnvVarName(key string) string {
+	return "FLEEKS_" + strings.ToUpper(key)
+}
+
+// SettingSource reports which layer supplies the effective value of key,
+// following viper's precedence: env-file values are applied as overrides,
+// then environment variables, then the config file, then defaults.
+func SettingSource(key string) string {
+	key = strings.ToLower(key)
+	switch {
+	case envFileKeys[key]:
+		return SourceEnvFile
+	case os.Getenv(EnvVarName(key)) != "":
+		return SourceEnvVar
+	case viper.InConfig(key):
+		return SourceConfig
+	default:
+		return SourceDefault
+	}
+}
+
+// IsSecretKey reports whether key holds a credential that should be masked.
+// Dashes count as underscores, so header and flag names match too.
+func IsSecretKey(key string) bool {
+	key = strings.ReplaceAll(strings.ToLower(key), "-", "_")
+	for _, marker := range []string{"api_key", "apikey", "token", "secret", "password"} {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectiveSettings returns every known setting with its merged value and
+// source, sorted by key.
+func EffectiveSettings() []Setting {
+	keys := viper.AllKeys()
+	sort.Strings(keys)
+
+	settings := make([]Setting, 0, len(keys))
+	for _, key := range keys {
+		settings = append(settings, Setting{
+			Key:    key,
+			Value:  viper.Get(key),
+			Source: SettingSource(key),
+			Secret: IsSecretKey(key),
+		})
+	}
+	return settings
+}
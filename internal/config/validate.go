@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError describes a single invalid configuration value
+type ValidationError struct {
+	Key     string
+	Message string
+}
+
+// Error implements the error interface for ValidationError
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Key, e.Message)
+}
+
+// ValidateFile checks that the config file at path is well-formed YAML.
+// A missing file is not an error since defaults are used in that case.
+func ValidateFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return fmt.Errorf("invalid YAML in %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate checks the currently loaded configuration values and returns
+// every problem found, keyed by the offending configuration key.
+func Validate() []ValidationError {
+	var problems []ValidationError
+
+	for _, info := range knownKeys {
+		if info.Key == "environment" {
+			// Checked by the caller, which also sees the --environment flag
+			continue
+		}
+		switch info.Type {
+		case TypeDuration, TypeURL, TypeEnum:
+			value := viper.GetString(info.Key)
+			if value == "" {
+				continue
+			}
+			if err := info.check(value); err != nil {
+				problems = append(problems, ValidationError{Key: info.Key, Message: err.Error()})
+			}
+		case TypeInt:
+			if err := info.checkMin(viper.GetInt(info.Key)); err != nil {
+				problems = append(problems, ValidationError{Key: info.Key, Message: err.Error()})
+			}
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems
+}
+
+// validateURL checks that value is an absolute URL using one of the given schemes
+func validateURL(value string, schemes []string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %v", value, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid URL %q: missing host", value)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid URL %q: scheme must be one of %v", value, schemes)
+}
@@ -0,0 +1,90 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// lockTimeout is how long Update waits for another invocation's lock
+	lockTimeout = 10 * time.Second
+	// lockStale is the age after which a lock is assumed to belong to a
+	// process that died holding it
+	lockStale = 30 * time.Second
+	// lockRetry is the polling interval while waiting for a lock
+	lockRetry = 25 * time.Millisecond
+)
+
+// lockFile takes an exclusive lock by creating path, which fails while
+// another holder has it. Creating a file exclusively works the same on every
+// platform, unlike flock. The file holds a token naming this holder, and the
+// returned function releases the lock only if it still holds that token.
+func lockFile(path string) (func(), error) {
+	token := strconv.Itoa(os.Getpid()) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = file.WriteString(token)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to lock state file: %w", err)
+			}
+			return func() { unlockFile(path, token) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock state file: %w", err)
+		}
+
+		if removeStaleLock(path, token) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for state lock %s (remove it if no fleeks command is running)", path)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// unlockFile removes the lock at path if it still holds token. A lock that
+// was taken over as stale belongs to another process by now and is left
+// alone.
+func unlockFile(path, token string) {
+	if holder, err := os.ReadFile(path); err == nil && string(holder) == token {
+		os.Remove(path)
+	}
+}
+
+// removeStaleLock clears a lock left by a process that died holding it and
+// reports whether it did. The lock is renamed aside first, which only one of
+// several waiting processes can do, and checked again once it is out of the
+// way: if its holder released it and a new one was taken in between, the new
+// lock is put back.
+func removeStaleLock(path, token string) bool {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) <= lockStale {
+		return false
+	}
+	holder, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	aside := path + "." + token
+	if err := os.Rename(path, aside); err != nil {
+		return false
+	}
+	defer os.Remove(aside)
+	if moved, err := os.ReadFile(aside); err != nil || string(moved) != string(holder) {
+		// It was a live lock; this only fails if another one was taken in
+		// the moment it was aside
+		os.Link(aside, path)
+	}
+	return true
+}
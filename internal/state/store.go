@@ -0,0 +1,255 @@
+// Package state persists small pieces of local CLI state, such as sync
+// records and log cursors, in JSON files under ~/.fleeks/state.
+//
+// Values are keyed by project and resource. Every change goes through
+// Update, which holds a lock file for the read-modify-write and replaces
+// the file atomically, so simultaneous fleeks invocations don't lose each
+// other's writes or see a half-written file.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fleeks-inc/fleeks-cli/internal/config"
+)
+
+// SchemaVersion is the layout of the state files written by this build
+const SchemaVersion = 1
+
+// ErrNewerSchema is returned for a state file written by a newer CLI, which
+// this build leaves untouched rather than risk dropping data it can't read
+var ErrNewerSchema = errors.New("state file was written by a newer version of fleeks")
+
+// document is the on-disk layout: project -> resource -> value
+type document struct {
+	Version  int                                   `json:"version"`
+	Projects map[string]map[string]json.RawMessage `json:"projects"`
+}
+
+// migrations upgrade a document from the version they're keyed by to the
+// next one. Add an entry here whenever SchemaVersion is bumped.
+var migrations = map[int]func(doc *document) error{
+	// Version 0 is a file without a version field, laid out as version 1
+	0: func(doc *document) error { return nil },
+}
+
+// Store is one JSON state file
+type Store struct {
+	path string
+}
+
+// Open returns the store named name in the CLI state directory. The file
+// is created on the first write.
+func Open(name string) *Store {
+	return OpenFile(filepath.Join(config.GetStateDir(), "state", name+".json"))
+}
+
+// OpenFile returns a store backed by the file at path
+func OpenFile(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file backing the store
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Get decodes the value stored for project and resource into value,
+// reporting whether there was one
+func (s *Store) Get(project, resource string, value interface{}) (bool, error) {
+	doc, err := s.read()
+	if err != nil {
+		return false, err
+	}
+	return (&Tx{doc: doc}).Get(project, resource, value)
+}
+
+// Project returns the raw values stored for every resource of project
+func (s *Store) Project(project string) (map[string]json.RawMessage, error) {
+	doc, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]json.RawMessage, len(doc.Projects[project]))
+	for resource, raw := range doc.Projects[project] {
+		values[resource] = raw
+	}
+	return values, nil
+}
+
+// Set stores value for project and resource
+func (s *Store) Set(project, resource string, value interface{}) error {
+	return s.Update(func(tx *Tx) error {
+		return tx.Set(project, resource, value)
+	})
+}
+
+// Delete removes the value stored for project and resource
+func (s *Store) Delete(project, resource string) error {
+	return s.Update(func(tx *Tx) error {
+		tx.Delete(project, resource)
+		return nil
+	})
+}
+
+// Clear removes every value in the store, returning how many there were
+func (s *Store) Clear() (int, error) {
+	removed := 0
+	err := s.Update(func(tx *Tx) error {
+		for project, resources := range tx.doc.Projects {
+			removed += len(resources)
+			delete(tx.doc.Projects, project)
+			tx.changed = true
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// Update runs fn with the store locked against other processes and writes
+// the result if fn changed anything and returned no error
+func (s *Store) Update(fn func(tx *Tx) error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	unlock, err := lockFile(s.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	doc, err := s.read()
+	if err != nil {
+		return err
+	}
+	tx := &Tx{doc: doc}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if !tx.changed {
+		return nil
+	}
+	return s.write(doc)
+}
+
+// read loads and migrates the file, returning an empty document if it
+// doesn't exist yet
+func (s *Store) read() (*document, error) {
+	doc := &document{Version: SchemaVersion, Projects: make(map[string]map[string]json.RawMessage)}
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return doc, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	doc.Version = 0
+	if err := json.Unmarshal(content, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", s.path, err)
+	}
+	if doc.Version > SchemaVersion {
+		return nil, fmt.Errorf("%s: %w", s.path, ErrNewerSchema)
+	}
+	for doc.Version < SchemaVersion {
+		migrate, ok := migrations[doc.Version]
+		if !ok {
+			return nil, fmt.Errorf("no migration for state file version %d", doc.Version)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate state file from version %d: %w", doc.Version, err)
+		}
+		doc.Version++
+	}
+	if doc.Projects == nil {
+		doc.Projects = make(map[string]map[string]json.RawMessage)
+	}
+	return doc, nil
+}
+
+// write replaces the file atomically, so readers see either the old or the
+// new state
+func (s *Store) write(doc *document) error {
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// Tx reads and changes a store inside Update
+type Tx struct {
+	doc     *document
+	changed bool
+}
+
+// Get decodes the value stored for project and resource into value,
+// reporting whether there was one
+func (tx *Tx) Get(project, resource string, value interface{}) (bool, error) {
+	raw, ok := tx.doc.Projects[project][resource]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, value); err != nil {
+		return false, fmt.Errorf("failed to decode state %s/%s: %w", project, resource, err)
+	}
+	return true, nil
+}
+
+// Set stores value for project and resource
+func (tx *Tx) Set(project, resource string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode state %s/%s: %w", project, resource, err)
+	}
+	if tx.doc.Projects[project] == nil {
+		tx.doc.Projects[project] = make(map[string]json.RawMessage)
+	}
+	tx.doc.Projects[project][resource] = raw
+	tx.changed = true
+	return nil
+}
+
+// Delete removes the value stored for project and resource
+func (tx *Tx) Delete(project, resource string) {
+	resources, ok := tx.doc.Projects[project]
+	if !ok {
+		return
+	}
+	if _, ok := resources[resource]; !ok {
+		return
+	}
+	delete(resources, resource)
+	if len(resources) == 0 {
+		delete(tx.doc.Projects, project)
+	}
+	tx.changed = true
+}
@@ -0,0 +1,167 @@
+// Package ui holds the color theme used for all terminal output. Commands
+// color text through this package instead of picking colors themselves, so
+// the palette can follow the terminal background (ui.theme).
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Theme selects the palette
+type Theme string
+
+// Themes accepted by ui.theme
+const (
+	ThemeAuto  Theme = "auto"  // light or dark, from the terminal background
+	ThemeDark  Theme = "dark"  // bright colors for dark backgrounds
+	ThemeLight Theme = "light" // darker colors for light backgrounds
+	ThemeNone  Theme = "none"  // no colors at all
+)
+
+// Themes lists the accepted ui.theme values
+func Themes() []string {
+	return []string{string(ThemeAuto), string(ThemeDark), string(ThemeLight), string(ThemeNone)}
+}
+
+// lightPalette replaces colors that are hard to read on a light background.
+// Bright variants become their normal counterparts, and cyan, yellow and
+// green become darker 256-color shades.
+var lightPalette = map[color.Attribute][]color.Attribute{
+	color.FgCyan:      {38, 5, 30},
+	color.FgHiCyan:    {38, 5, 30},
+	color.FgYellow:    {38, 5, 130},
+	color.FgHiYellow:  {38, 5, 130},
+	color.FgGreen:     {38, 5, 28},
+	color.FgHiGreen:   {38, 5, 28},
+	color.FgWhite:     {color.FgBlack},
+	color.FgHiWhite:   {color.FgBlack},
+	color.FgHiBlue:    {color.FgBlue},
+	color.FgHiRed:     {color.FgRed},
+	color.FgHiMagenta: {color.FgMagenta},
+}
+
+var (
+	mu      sync.RWMutex
+	current = detectTheme()
+	noColor = color.NoColor // as decided by fatih/color from NO_COLOR and the terminal
+)
+
+// SetTheme switches to the named theme; an empty name means auto
+func SetTheme(name string) error {
+	theme := Theme(strings.ToLower(strings.TrimSpace(name)))
+	switch theme {
+	case "", ThemeAuto:
+		theme = detectTheme()
+	case ThemeDark, ThemeLight, ThemeNone:
+	default:
+		return fmt.Errorf("unknown theme %q (expected auto, dark, light or none)", name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = theme
+	color.NoColor = noColor || theme == ThemeNone
+	return nil
+}
+
+// Current returns the theme in use, with auto resolved to light or dark
+func Current() Theme {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// detectTheme picks light or dark from COLORFGBG ("fg;bg", set by rxvt,
+// Konsole, iTerm2 and others). Without it the terminal is assumed dark.
+func detectTheme() Theme {
+	value := os.Getenv("COLORFGBG")
+	if value == "" {
+		return ThemeDark
+	}
+	fields := strings.Split(value, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return ThemeDark
+	}
+	// 7 is light gray and 9-15 are the bright colors; 8 is dark gray
+	if bg == 7 || (bg >= 9 && bg <= 15) {
+		return ThemeLight
+	}
+	return ThemeDark
+}
+
+// themed maps attributes to the current palette
+func themed(attrs []color.Attribute) []color.Attribute {
+	if Current() != ThemeLight {
+		return attrs
+	}
+	mapped := make([]color.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		if replacement, ok := lightPalette[attr]; ok {
+			mapped = append(mapped, replacement...)
+		} else {
+			mapped = append(mapped, attr)
+		}
+	}
+	return mapped
+}
+
+// New returns a color for the given attributes in the current theme. It is
+// the themed counterpart of color.New.
+func New(attrs ...color.Attribute) *color.Color {
+	return color.New(themed(attrs)...)
+}
+
+// TableColors returns tablewriter header or column colors in the current
+// theme, or none when colors are off
+func TableColors(attrs ...color.Attribute) tablewriter.Colors {
+	if color.NoColor {
+		return tablewriter.Colors{}
+	}
+	colors := tablewriter.Colors{}
+	for _, attr := range themed(attrs) {
+		colors = append(colors, int(attr))
+	}
+	return colors
+}
+
+// sprint formats like the color.XString helpers: without arguments the
+// format is printed as is
+func sprint(attr color.Attribute, format string, a []interface{}) string {
+	c := New(attr)
+	if len(a) == 0 {
+		return c.Sprint(format)
+	}
+	return c.Sprintf(format, a...)
+}
+
+// Cyan formats text in the theme's cyan, like color.CyanString
+func Cyan(format string, a ...interface{}) string { return sprint(color.FgCyan, format, a) }
+
+// Yellow formats text in the theme's yellow, like color.YellowString
+func Yellow(format string, a ...interface{}) string { return sprint(color.FgYellow, format, a) }
+
+// Green formats text in the theme's green, like color.GreenString
+func Green(format string, a ...interface{}) string { return sprint(color.FgGreen, format, a) }
+
+// Red formats text in the theme's red, like color.RedString
+func Red(format string, a ...interface{}) string { return sprint(color.FgRed, format, a) }
+
+// Magenta formats text in the theme's magenta, like color.MagentaString
+func Magenta(format string, a ...interface{}) string { return sprint(color.FgMagenta, format, a) }
+
+// Blue formats text in the theme's blue, like color.BlueString
+func Blue(format string, a ...interface{}) string { return sprint(color.FgBlue, format, a) }
+
+// White formats text in the theme's white, like color.WhiteString
+func White(format string, a ...interface{}) string { return sprint(color.FgWhite, format, a) }
+
+// Muted formats secondary text in gray, like color.HiBlackString
+func Muted(format string, a ...interface{}) string { return sprint(color.FgHiBlack, format, a) }